@@ -7,12 +7,14 @@ import (
 	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
 var (
-	errNoPipelinePriority    = errors.New("No pipelines are defined in the priority list")
-	errInvalidRetryIntervals = errors.New("Retry interval must be positive")
+	errNoPipelinePriority         = errors.New("No pipelines are defined in the priority list")
+	errInvalidRetryIntervals      = errors.New("Retry interval must be positive")
+	errInvalidHealthCheckInterval = errors.New("Health check interval must be positive")
 )
 
 type Config struct {
@@ -33,6 +35,25 @@ type Config struct {
 	// MaxRetry is the maximum retries per level, once this limit is hit for a level, even if the next pipeline level fails,
 	// it will not try to recover the level that exceeded the maximum retries
 	MaxRetries int `mapstructure:"max_retries"` // **Deprecated**
+
+	// FailbackThreshold is the number of consecutive successful retries or health probes of a higher priority
+	// level required before routing back to it. Values above 1 prevent flapping between levels, values below 1
+	// behave as 1
+	FailbackThreshold int `mapstructure:"failback_threshold"`
+
+	// HealthCheck configures following the health of the priority levels in addition to relying on consume errors
+	HealthCheck HealthCheckConfig `mapstructure:"health_check"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+type HealthCheckConfig struct {
+	// Extension is the ID of the extension aggregating the component status of the pipelines, e.g. the
+	// healthcheckv2 extension. A level is unhealthy if any of its pipelines reports an error status
+	Extension *component.ID `mapstructure:"extension"`
+
+	// Interval is the frequency at which the higher priority levels are checked before failing back to them
+	Interval time.Duration `mapstructure:"interval"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -45,5 +66,8 @@ func (c *Config) Validate() error {
 	if c.RetryInterval <= 0 {
 		return errInvalidRetryIntervals
 	}
+	if c.HealthCheck.Extension != nil && c.HealthCheck.Interval <= 0 {
+		return errInvalidHealthCheckInterval
+	}
 	return nil
 }
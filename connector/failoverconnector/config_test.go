@@ -31,7 +31,11 @@ func TestLoadConfig(t *testing.T) {
 						pipeline.NewIDWithName(pipeline.SignalTraces, ""),
 					},
 				},
-				RetryInterval: 10 * time.Minute,
+				RetryInterval:     10 * time.Minute,
+				FailbackThreshold: 1,
+				HealthCheck: HealthCheckConfig{
+					Interval: 30 * time.Second,
+				},
 			},
 		},
 		{
@@ -52,7 +56,48 @@ func TestLoadConfig(t *testing.T) {
 						pipeline.NewIDWithName(pipeline.SignalTraces, "fourth"),
 					},
 				},
-				RetryInterval: 5 * time.Minute,
+				RetryInterval:     5 * time.Minute,
+				FailbackThreshold: 1,
+				HealthCheck: HealthCheckConfig{
+					Interval: 30 * time.Second,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "health_check"),
+			expected: &Config{
+				PipelinePriority: [][]pipeline.ID{
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "first"),
+					},
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "second"),
+					},
+				},
+				RetryInterval:     time.Minute,
+				FailbackThreshold: 3,
+				HealthCheck: HealthCheckConfig{
+					Extension: &healthCheckID,
+					Interval:  10 * time.Second,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "zero_failback_threshold"),
+			expected: &Config{
+				PipelinePriority: [][]pipeline.ID{
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "first"),
+					},
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "second"),
+					},
+				},
+				RetryInterval:     10 * time.Minute,
+				FailbackThreshold: 0,
+				HealthCheck: HealthCheckConfig{
+					Interval: 30 * time.Second,
+				},
 			},
 		},
 	}
@@ -91,6 +136,11 @@ func TestValidateConfig(t *testing.T) {
 			id:   component.NewIDWithName(metadata.Type, "invalid"),
 			err:  errInvalidRetryIntervals,
 		},
+		{
+			name: "invalid health check interval",
+			id:   component.NewIDWithName(metadata.Type, "invalid_health_check_interval"),
+			err:  errInvalidHealthCheckInterval,
+		},
 	}
 
 	for _, tc := range testcases {
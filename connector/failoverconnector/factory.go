@@ -26,9 +26,13 @@ func NewFactory() connector.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		RetryInterval: 10 * time.Minute,
-		RetryGap:      0,
-		MaxRetries:    0,
+		RetryInterval:     10 * time.Minute,
+		RetryGap:          0,
+		MaxRetries:        0,
+		FailbackThreshold: 1,
+		HealthCheck: HealthCheckConfig{
+			Interval: 30 * time.Second,
+		},
 	}
 }
 
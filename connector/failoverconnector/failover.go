@@ -4,10 +4,15 @@
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 
 import (
+	"context"
 	"errors"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
 )
 
@@ -27,6 +32,10 @@ type baseFailoverRouter[C any] struct {
 	errTryLock  *state.TryLock
 	notifyRetry chan struct{}
 	done        chan struct{}
+
+	// health is nil if no health check extension is configured
+	health           *healthWatcher
+	telemetryBuilder *metadata.TelemetryBuilder
 }
 
 // getCurrentConsumer returns the consumer for the current healthy level
@@ -49,17 +58,30 @@ func (f *baseFailoverRouter[C]) reportConsumerError(idx int) {
 	f.errTryLock.TryExecute(f.pS.HandleError, idx)
 }
 
+// Start subscribes to the health of the priority levels, if configured
+func (f *baseFailoverRouter[C]) Start(host component.Host) error {
+	if f.cfg.HealthCheck.Extension == nil {
+		return nil
+	}
+	return f.startHealthCheck(host)
+}
+
 func (f *baseFailoverRouter[C]) Shutdown() {
 	close(f.done)
+	if f.health != nil {
+		f.health.close()
+	}
+	f.telemetryBuilder.Shutdown()
 }
 
-func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config) (*baseFailoverRouter[C], error) {
+func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config, set component.TelemetrySettings) (*baseFailoverRouter[C], error) {
 	done := make(chan struct{})
 	notifyRetry := make(chan struct{}, 1)
 	pSConstants := state.PSConstants{
-		RetryInterval: cfg.RetryInterval,
-		RetryGap:      cfg.RetryGap,
-		MaxRetries:    cfg.MaxRetries,
+		RetryInterval:     cfg.RetryInterval,
+		RetryGap:          cfg.RetryGap,
+		MaxRetries:        cfg.MaxRetries,
+		FailbackThreshold: cfg.FailbackThreshold,
 	}
 
 	consumers := make([]C, 0)
@@ -72,13 +94,34 @@ func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config) (*b
 	}
 
 	selector := state.NewPipelineSelector(notifyRetry, done, pSConstants)
+
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	err = telemetryBuilder.RegisterConnectorFailoverLevelActiveCallback(func(_ context.Context, observer metric.Int64Observer) error {
+		current := selector.CurrentPipeline()
+		for idx := range cfg.PipelinePriority {
+			var active int64
+			if idx == current {
+				active = 1
+			}
+			observer.Observe(active, metric.WithAttributes(attribute.Int("priority_level", idx)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &baseFailoverRouter[C]{
-		consumers:   consumers,
-		cfg:         cfg,
-		pS:          selector,
-		errTryLock:  state.NewTryLock(),
-		done:        done,
-		notifyRetry: notifyRetry,
+		consumers:        consumers,
+		cfg:              cfg,
+		pS:               selector,
+		errTryLock:       state.NewTryLock(),
+		done:             done,
+		notifyRetry:      notifyRetry,
+		telemetryBuilder: telemetryBuilder,
 	}, nil
 }
 
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+// healthWatcher follows the aggregated component status of the pipelines of the priority levels
+type healthWatcher struct {
+	lock      sync.RWMutex
+	unhealthy map[pipeline.ID]bool
+
+	unsubscribe []status.UnsubscribeFunc
+	wg          sync.WaitGroup
+}
+
+// getStatusSubscriber returns the extension aggregating the component status of the pipelines
+func getStatusSubscriber(host component.Host, id component.ID) (status.Subscriber, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("health check extension %q not found", id)
+	}
+	subscriber, ok := ext.(status.Subscriber)
+	if !ok {
+		return nil, fmt.Errorf("extension %q does not expose the component status of the pipelines", id)
+	}
+	return subscriber, nil
+}
+
+// unhealthyStatus returns true if the status reports an error, a pipeline that did not report yet is healthy
+func unhealthyStatus(st *status.AggregateStatus) bool {
+	if st == nil || st.Event == nil {
+		return false
+	}
+	switch st.Status() {
+	case componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError, componentstatus.StatusFatalError:
+		return true
+	default:
+		return false
+	}
+}
+
+// healthy returns true if none of the pipelines reports an error
+func (h *healthWatcher) healthy(pipelines []pipeline.ID) bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	for _, id := range pipelines {
+		if h.unhealthy[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *healthWatcher) close() {
+	for _, unsubscribe := range h.unsubscribe {
+		unsubscribe()
+	}
+	h.wg.Wait()
+}
+
+// startHealthCheck subscribes to the status of the pipelines of every priority level and periodically
+// checks the higher priority levels for failback until the router is shut down
+func (f *baseFailoverRouter[C]) startHealthCheck(host component.Host) error {
+	subscriber, err := getStatusSubscriber(host, *f.cfg.HealthCheck.Extension)
+	if err != nil {
+		return err
+	}
+
+	h := &healthWatcher{unhealthy: make(map[pipeline.ID]bool)}
+	f.health = h
+	seen := make(map[pipeline.ID]bool)
+	for _, pipelines := range f.cfg.PipelinePriority {
+		for _, id := range pipelines {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			statusCh, unsubscribe := subscriber.Subscribe(status.Scope(id.String()), status.Concise)
+			h.unsubscribe = append(h.unsubscribe, unsubscribe)
+			h.wg.Add(1)
+			go f.watchPipeline(h, id, statusCh)
+		}
+	}
+
+	h.wg.Add(1)
+	go f.failbackLoop(f.cfg.HealthCheck.Interval)
+	return nil
+}
+
+// watchPipeline records the status updates of the pipeline, an unhealthy current level is failed over immediately
+func (f *baseFailoverRouter[C]) watchPipeline(h *healthWatcher, id pipeline.ID, statusCh <-chan *status.AggregateStatus) {
+	defer h.wg.Done()
+	for {
+		select {
+		case st, ok := <-statusCh:
+			if !ok {
+				return
+			}
+			h.lock.Lock()
+			h.unhealthy[id] = unhealthyStatus(st)
+			h.lock.Unlock()
+			f.checkCurrentLevel()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// failbackLoop periodically checks the health of the higher priority levels until the router is shut down
+func (f *baseFailoverRouter[C]) failbackLoop(interval time.Duration) {
+	defer f.health.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.checkLevels()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// checkCurrentLevel fails over the current level if it is unhealthy. The last level is never failed over by
+// the health check, as there would be no level left to route to
+func (f *baseFailoverRouter[C]) checkCurrentLevel() {
+	current := f.pS.CurrentPipeline()
+	if current >= len(f.cfg.PipelinePriority)-1 {
+		return
+	}
+	if !f.health.healthy(f.cfg.PipelinePriority[current]) {
+		f.reportConsumerError(current)
+	}
+}
+
+// checkLevels checks the health of the current level and of the higher priority levels. A healthy higher
+// priority level is failed back to once it was healthy for the configured number of consecutive checks
+func (f *baseFailoverRouter[C]) checkLevels() {
+	f.checkCurrentLevel()
+	current := f.pS.CurrentPipeline()
+	for idx := 0; idx < current && idx < len(f.cfg.PipelinePriority); idx++ {
+		if f.health.healthy(f.cfg.PipelinePriority[idx]) {
+			if f.pS.HandleRetrySuccess(idx) {
+				return
+			}
+			continue
+		}
+		f.pS.HandleRetryFailure(idx)
+	}
+}
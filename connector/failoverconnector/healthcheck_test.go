@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+var healthCheckID = component.MustNewID("healthcheckv2")
+
+// statusExtension exposes the statuses aggregated by its aggregator, like the healthcheckv2 extension
+type statusExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	*status.Aggregator
+}
+
+type statusHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *statusHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func newStatusHost(aggregator *status.Aggregator) component.Host {
+	return &statusHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{healthCheckID: &statusExtension{Aggregator: aggregator}},
+	}
+}
+
+// reportExporterStatus records the status event of an exporter of the pipeline
+func reportExporterStatus(aggregator *status.Aggregator, id pipeline.ID, event *componentstatus.Event) {
+	source := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter, id)
+	aggregator.RecordStatus(source, event)
+}
+
+func newHealthCheckConnector(t *testing.T, cfg *Config, tel *componenttest.Telemetry, host component.Host) (*tracesFailover, *consumertest.TracesSink, *consumertest.TracesSink) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		cfg.PipelinePriority[0][0]: &sinkFirst,
+		cfg.PipelinePriority[1][0]: &sinkSecond,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		metadatatest.NewSettings(tel), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, conn.Shutdown(t.Context()))
+	})
+	return conn.(*tracesFailover), &sinkFirst, &sinkSecond
+}
+
+func TestHealthCheckFailoverAndFailback(t *testing.T) {
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := &Config{
+		PipelinePriority:  [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:     time.Hour,
+		FailbackThreshold: 3,
+		HealthCheck: HealthCheckConfig{
+			Extension: &healthCheckID,
+			Interval:  10 * time.Millisecond,
+		},
+	}
+
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(t.Context()))
+	}()
+
+	aggregator := status.NewAggregator(status.PriorityRecoverable)
+	t.Cleanup(aggregator.Close)
+	reportExporterStatus(aggregator, tracesFirst, componentstatus.NewEvent(componentstatus.StatusOK))
+
+	failoverConnector, sinkFirst, sinkSecond := newHealthCheckConnector(t, cfg, tel, newStatusHost(aggregator))
+	require.Equal(t, 0, failoverConnector.failover.TestGetCurrentConsumerIndex())
+
+	// The primary level is failed over as soon as it reports an error, without any consume error
+	reportExporterStatus(aggregator, tracesFirst, componentstatus.NewRecoverableErrorEvent(errors.New("sending queue is full")))
+	require.Eventually(t, func() bool {
+		return failoverConnector.failover.TestGetCurrentConsumerIndex() == 1
+	}, 3*time.Second, 5*time.Millisecond)
+
+	require.NoError(t, failoverConnector.ConsumeTraces(t.Context(), sampleTrace()))
+	assert.Equal(t, 0, sinkFirst.SpanCount())
+	assert.Equal(t, 1, sinkSecond.SpanCount())
+
+	metadatatest.AssertEqualConnectorFailoverLevelActive(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 0, Attributes: attribute.NewSet(attribute.Int("priority_level", 0))},
+		{Value: 1, Attributes: attribute.NewSet(attribute.Int("priority_level", 1))},
+	}, metricdatatest.IgnoreTimestamp())
+
+	// The primary level is failed back to once it reports being healthy again
+	reportExporterStatus(aggregator, tracesFirst, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.Eventually(t, func() bool {
+		return failoverConnector.failover.TestGetCurrentConsumerIndex() == 0
+	}, 3*time.Second, 5*time.Millisecond)
+
+	metadatatest.AssertEqualConnectorFailoverLevelActive(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 1, Attributes: attribute.NewSet(attribute.Int("priority_level", 0))},
+		{Value: 0, Attributes: attribute.NewSet(attribute.Int("priority_level", 1))},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestHealthCheckLastLevel(t *testing.T) {
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    time.Hour,
+		HealthCheck: HealthCheckConfig{
+			Extension: &healthCheckID,
+			Interval:  10 * time.Millisecond,
+		},
+	}
+
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(t.Context()))
+	}()
+
+	aggregator := status.NewAggregator(status.PriorityRecoverable)
+	t.Cleanup(aggregator.Close)
+
+	failoverConnector, _, sinkSecond := newHealthCheckConnector(t, cfg, tel, newStatusHost(aggregator))
+
+	reportExporterStatus(aggregator, tracesFirst, componentstatus.NewPermanentErrorEvent(errors.New("invalid endpoint")))
+	require.Eventually(t, func() bool {
+		return failoverConnector.failover.TestGetCurrentConsumerIndex() == 1
+	}, 3*time.Second, 5*time.Millisecond)
+
+	// The unhealthy last level keeps receiving data, as there is no level left to fail over to
+	reportExporterStatus(aggregator, tracesSecond, componentstatus.NewPermanentErrorEvent(errors.New("invalid endpoint")))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, failoverConnector.failover.TestGetCurrentConsumerIndex())
+
+	require.NoError(t, failoverConnector.ConsumeTraces(t.Context(), sampleTrace()))
+	assert.Equal(t, 1, sinkSecond.SpanCount())
+}
+
+func TestHealthCheckExtensionNotFound(t *testing.T) {
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    time.Hour,
+		HealthCheck: HealthCheckConfig{
+			Extension: &healthCheckID,
+			Interval:  time.Second,
+		},
+	}
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  consumertest.NewNop(),
+		tracesSecond: consumertest.NewNop(),
+	})
+
+	tel := componenttest.NewTelemetry()
+	defer func() {
+		require.NoError(t, tel.Shutdown(t.Context()))
+	}()
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(), metadatatest.NewSettings(tel), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	require.ErrorContains(t, conn.Start(t.Context(), componenttest.NewNopHost()), `health check extension "healthcheckv2" not found`)
+	require.NoError(t, conn.Shutdown(t.Context()))
+}
@@ -18,6 +18,9 @@ type PipelineSelector struct {
 
 	retryCancel CancelManager
 	done        chan struct{}
+
+	// consecutiveSuccesses tracks the consecutive successful retries of the levels above the current one
+	consecutiveSuccesses map[int]int
 }
 
 // HandleError is called when an error is returned on a healthy pipeline
@@ -92,6 +95,34 @@ func (p *PipelineSelector) ResetHealthyPipeline(pipelineIndex int) {
 		p.retryCancel.Cancel()
 	}
 	p.currentPipeline = pipelineIndex
+	clear(p.consecutiveSuccesses)
+}
+
+// HandleRetrySuccess is called when a level above the current one was successfully retried or probed. The
+// level is reset back to healthy/active once it succeeded FailbackThreshold consecutive times, returns true
+// if the level was reset
+func (p *PipelineSelector) HandleRetrySuccess(idx int) bool {
+	p.lock.Lock()
+	if idx >= p.currentPipeline {
+		p.lock.Unlock()
+		return false
+	}
+	p.consecutiveSuccesses[idx]++
+	reset := p.consecutiveSuccesses[idx] >= p.constants.FailbackThreshold
+	p.lock.Unlock()
+
+	if reset {
+		p.ResetHealthyPipeline(idx)
+	}
+	return reset
+}
+
+// HandleRetryFailure is called when a level above the current one failed a retry or a probe, resetting
+// its consecutive successes
+func (p *PipelineSelector) HandleRetryFailure(idx int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.consecutiveSuccesses, idx)
 }
 
 func NewPipelineSelector(retryChan chan<- struct{}, done chan struct{}, consts PSConstants) *PipelineSelector {
@@ -99,11 +130,12 @@ func NewPipelineSelector(retryChan chan<- struct{}, done chan struct{}, consts P
 	retryEnabledToken <- struct{}{}
 
 	ps := &PipelineSelector{
-		currentPipeline:   0,
-		constants:         consts,
-		retryEnabledToken: retryEnabledToken,
-		retryChan:         retryChan,
-		done:              done,
+		currentPipeline:      0,
+		constants:            consts,
+		retryEnabledToken:    retryEnabledToken,
+		retryChan:            retryChan,
+		done:                 done,
+		consecutiveSuccesses: make(map[int]int),
 	}
 	return ps
 }
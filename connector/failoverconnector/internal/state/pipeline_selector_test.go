@@ -73,3 +73,34 @@ func TestCurrentPipelineWithReset(t *testing.T) {
 		return idx == 0
 	}, 3*time.Second, 5*time.Millisecond)
 }
+
+func TestHandleRetrySuccessWithFailbackThreshold(t *testing.T) {
+	done := make(chan struct{})
+	retryChan := make(chan struct{}, 1)
+	constants := PSConstants{
+		RetryInterval:     50 * time.Millisecond,
+		FailbackThreshold: 3,
+	}
+	pS := NewPipelineSelector(retryChan, done, constants)
+
+	defer func() {
+		close(done)
+	}()
+
+	pS.TestSetCurrentPipeline(2)
+
+	// A failure in between resets the consecutive successes
+	require.False(t, pS.HandleRetrySuccess(0))
+	require.False(t, pS.HandleRetrySuccess(0))
+	pS.HandleRetryFailure(0)
+	require.False(t, pS.HandleRetrySuccess(0))
+	require.False(t, pS.HandleRetrySuccess(0))
+	require.Equal(t, 2, pS.CurrentPipeline())
+
+	require.True(t, pS.HandleRetrySuccess(0))
+	require.Equal(t, 0, pS.CurrentPipeline())
+
+	// Successes of the current level are ignored
+	require.False(t, pS.HandleRetrySuccess(0))
+	require.Equal(t, 0, pS.CurrentPipeline())
+}
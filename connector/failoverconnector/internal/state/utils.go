@@ -10,9 +10,10 @@ import (
 )
 
 type PSConstants struct {
-	RetryInterval time.Duration
-	RetryGap      time.Duration
-	MaxRetries    int
+	RetryInterval     time.Duration
+	RetryGap          time.Duration
+	MaxRetries        int
+	FailbackThreshold int
 }
 
 type TryLock struct {
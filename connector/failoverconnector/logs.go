@@ -17,8 +17,8 @@ type logsRouter struct {
 	*baseFailoverRouter[consumer.Logs]
 }
 
-func newLogsRouter(provider consumerProvider[consumer.Logs], cfg *Config) (*logsRouter, error) {
-	failover, err := newBaseFailoverRouter(provider, cfg)
+func newLogsRouter(provider consumerProvider[consumer.Logs], cfg *Config, set component.TelemetrySettings) (*logsRouter, error) {
+	failover, err := newBaseFailoverRouter(provider, cfg, set)
 	if err != nil {
 		return nil, err
 	}
@@ -62,15 +62,15 @@ func (f *logsRouter) sampleRetryConsumers(ctx context.Context, ld plog.Logs) boo
 		consumer := f.getConsumerAtIndex(i)
 		err := consumer.ConsumeLogs(ctx, ld)
 		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
+			f.pS.HandleRetrySuccess(i)
 			return true
 		}
+		f.pS.HandleRetryFailure(i)
 	}
 	return false
 }
 
 type logsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -87,6 +87,10 @@ func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return f.failover.Consume(ctx, ld)
 }
 
+func (f *logsFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *logsFailover) Shutdown(context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -101,7 +105,7 @@ func newLogsToLogs(set connector.Settings, cfg component.Config, logs consumer.L
 		return nil, errors.New("consumer is not of type MetricsRouter")
 	}
 
-	failover, err := newLogsRouter(lr.Consumer, config)
+	failover, err := newLogsRouter(lr.Consumer, config, set.TelemetrySettings)
 	if err != nil {
 		return nil, err
 	}
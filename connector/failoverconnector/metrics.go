@@ -17,8 +17,8 @@ type metricsRouter struct {
 	*baseFailoverRouter[consumer.Metrics]
 }
 
-func newMetricsRouter(provider consumerProvider[consumer.Metrics], cfg *Config) (*metricsRouter, error) {
-	failover, err := newBaseFailoverRouter(provider, cfg)
+func newMetricsRouter(provider consumerProvider[consumer.Metrics], cfg *Config, set component.TelemetrySettings) (*metricsRouter, error) {
+	failover, err := newBaseFailoverRouter(provider, cfg, set)
 	if err != nil {
 		return nil, err
 	}
@@ -62,15 +62,15 @@ func (f *metricsRouter) sampleRetryConsumers(ctx context.Context, md pmetric.Met
 		consumer := f.getConsumerAtIndex(i)
 		err := consumer.ConsumeMetrics(ctx, md)
 		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
+			f.pS.HandleRetrySuccess(i)
 			return true
 		}
+		f.pS.HandleRetryFailure(i)
 	}
 	return false
 }
 
 type metricsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -87,6 +87,10 @@ func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics
 	return f.failover.Consume(ctx, md)
 }
 
+func (f *metricsFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *metricsFailover) Shutdown(context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -101,7 +105,7 @@ func newMetricsToMetrics(set connector.Settings, cfg component.Config, metrics c
 		return nil, errors.New("consumer is not of type MetricsRouter")
 	}
 
-	failover, err := newMetricsRouter(mr.Consumer, config)
+	failover, err := newMetricsRouter(mr.Consumer, config, set.TelemetrySettings)
 	if err != nil {
 		return nil, err
 	}
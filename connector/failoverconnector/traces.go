@@ -18,8 +18,8 @@ type tracesRouter struct {
 	*baseFailoverRouter[consumer.Traces]
 }
 
-func newTracesRouter(provider consumerProvider[consumer.Traces], cfg *Config) (*tracesRouter, error) {
-	failover, err := newBaseFailoverRouter(provider, cfg)
+func newTracesRouter(provider consumerProvider[consumer.Traces], cfg *Config, set component.TelemetrySettings) (*tracesRouter, error) {
+	failover, err := newBaseFailoverRouter(provider, cfg, set)
 	if err != nil {
 		return nil, err
 	}
@@ -63,15 +63,15 @@ func (f *tracesRouter) sampleRetryConsumers(ctx context.Context, td ptrace.Trace
 		consumer := f.getConsumerAtIndex(i)
 		err := consumer.ConsumeTraces(ctx, td)
 		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
+			f.pS.HandleRetrySuccess(i)
 			return true
 		}
+		f.pS.HandleRetryFailure(i)
 	}
 	return false
 }
 
 type tracesFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -88,6 +88,10 @@ func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) er
 	return f.failover.Consume(ctx, td)
 }
 
+func (f *tracesFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *tracesFailover) Shutdown(context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -102,7 +106,7 @@ func newTracesToTraces(set connector.Settings, cfg component.Config, traces cons
 		return nil, errors.New("consumer is not of type TracesRouter")
 	}
 
-	failover, err := newTracesRouter(tr.Consumer, config)
+	failover, err := newTracesRouter(tr.Consumer, config, set.TelemetrySettings)
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +18,18 @@ var (
 	errNoPipelines            = errors.New("invalid route: no pipelines defined")
 	errUnexpectedConsumer     = errors.New("expected consumer to be a connector router")
 	errNoTableItems           = errors.New("invalid routing table: the routing table is empty")
+	errInvalidPercentage      = errors.New("invalid route: percentage must be greater than 0 and at most 100")
+	errHashKeyNoPercentage    = errors.New("invalid route: 'hash_key' requires a 'percentage'")
+	errNoHashAttribute        = errors.New("invalid route: 'hash_key: resource_attribute' requires a 'hash_attribute'")
+	errHashAttributeNoKey     = errors.New("invalid route: 'hash_attribute' requires 'hash_key: resource_attribute'")
+)
+
+const (
+	// hashKeyTraceID makes percentage routing decisions consistent for all telemetry of a trace.
+	hashKeyTraceID = "trace_id"
+	// hashKeyResourceAttribute makes percentage routing decisions consistent for all telemetry
+	// with the same value of a resource attribute.
+	hashKeyResourceAttribute = "resource_attribute"
 )
 
 // Config defines configuration for the Routing processor.
@@ -53,7 +65,7 @@ func (c *Config) Validate() error {
 	// validate that every route has a value for the routing attribute and has
 	// at least one pipeline
 	for _, item := range c.Table {
-		if item.Statement == "" && item.Condition == "" {
+		if item.Statement == "" && item.Condition == "" && item.Percentage == 0 {
 			return errNoConditionOrStatement
 		}
 		if item.Statement != "" && item.Condition != "" {
@@ -75,6 +87,45 @@ func (c *Config) Validate() error {
 		default:
 			return errors.New("invalid context: " + item.Context)
 		}
+
+		if err := item.validatePercentage(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (item RoutingTableItem) validatePercentage() error {
+	if item.Percentage < 0 || item.Percentage > 100 {
+		return errInvalidPercentage
+	}
+	if item.HashAttribute != "" && item.HashKey != hashKeyResourceAttribute {
+		return errHashAttributeNoKey
+	}
+	if item.HashKey == "" {
+		return nil
+	}
+	if item.Percentage == 0 {
+		return errHashKeyNoPercentage
+	}
+	statementContext := item.Context
+	if statementContext == "" {
+		statementContext = "resource"
+	}
+	switch item.HashKey {
+	case hashKeyTraceID:
+		if statementContext != "span" && statementContext != "log" {
+			return fmt.Errorf("'hash_key: %s' is not supported in the %q context", item.HashKey, statementContext)
+		}
+	case hashKeyResourceAttribute:
+		if statementContext == "request" {
+			return fmt.Errorf("'hash_key: %s' is not supported in the %q context", item.HashKey, statementContext)
+		}
+		if item.HashAttribute == "" {
+			return errNoHashAttribute
+		}
+	default:
+		return errors.New("invalid hash key: " + item.HashKey)
 	}
 	return nil
 }
@@ -87,7 +138,7 @@ type RoutingTableItem struct {
 
 	// Statement is an OTTL statement used for making a routing decision.
 	// 'Statement' is disallowed for the "request" context.
-	// For other contexts, 'Statement' or 'Condition' must be provided.
+	// For other contexts, 'Statement' or 'Condition' must be provided, unless 'Percentage' is set.
 	Statement string `mapstructure:"statement"`
 
 	// Condition is an OTTL condition used for making a routing decision.
@@ -102,6 +153,22 @@ type RoutingTableItem struct {
 	// The routing processor will fail upon the first failure from these pipelines.
 	// Optional.
 	Pipelines []pipeline.ID `mapstructure:"pipelines"`
+
+	// Percentage is the share, greater than 0 and at most 100, of the telemetry matching this route
+	// which is sent to its pipelines. The rest continues to the next routes as if it didn't match.
+	// When neither 'Statement' nor 'Condition' are provided, all telemetry matches the route.
+	// Optional.
+	Percentage float64 `mapstructure:"percentage"`
+
+	// HashKey makes the percentage routing decision consistent instead of random.
+	// "trace_id" routes all spans or logs of a trace alike, and is only supported for the
+	// "span" and "log" contexts. "resource_attribute" routes all telemetry with the same value
+	// of the resource attribute 'HashAttribute' alike, and isn't supported for the "request" context.
+	// Optional. Requires 'Percentage'.
+	HashKey string `mapstructure:"hash_key"`
+
+	// HashAttribute is the name of the resource attribute used when 'HashKey' is "resource_attribute".
+	HashAttribute string `mapstructure:"hash_attribute"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -375,6 +375,157 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "percentage without condition",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Context:    "span",
+						Percentage: 10,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "percentage with condition",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Context:    "span",
+						Condition:  `attributes["attr"] == "acme"`,
+						Percentage: 10,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid percentage",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition:  `attributes["attr"] == "acme"`,
+						Percentage: 150,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: "invalid route: percentage must be greater than 0 and at most 100",
+		},
+		{
+			name: "hash key without percentage",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Context:   "span",
+						Condition: `attributes["attr"] == "acme"`,
+						HashKey:   "trace_id",
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: "invalid route: 'hash_key' requires a 'percentage'",
+		},
+		{
+			name: "trace_id hash key",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Context:    "log",
+						Percentage: 10,
+						HashKey:    "trace_id",
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "trace_id hash key in resource context",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Percentage: 10,
+						HashKey:    "trace_id",
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: "'hash_key: trace_id' is not supported in the \"resource\" context",
+		},
+		{
+			name: "resource_attribute hash key",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Context:       "datapoint",
+						Percentage:    10,
+						HashKey:       "resource_attribute",
+						HashAttribute: "service.name",
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "resource_attribute hash key without attribute",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Percentage: 10,
+						HashKey:    "resource_attribute",
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: "invalid route: 'hash_key: resource_attribute' requires a 'hash_attribute'",
+		},
+		{
+			name: "hash attribute without hash key",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Percentage:    10,
+						HashAttribute: "service.name",
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: "invalid route: 'hash_attribute' requires 'hash_key: resource_attribute'",
+		},
+		{
+			name: "invalid hash key",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Percentage: 10,
+						HashKey:    "span_id",
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: "invalid hash key: span_id",
+		},
 	}
 
 	for _, tt := range tests {
@@ -401,6 +552,18 @@ func withRoute(context, condition string, pipelines ...pipeline.ID) testConfigOp
 	}
 }
 
+func withPercentageRoute(context, condition string, percentage float64, pipelines ...pipeline.ID) testConfigOption {
+	return func(cfg *Config) {
+		cfg.Table = append(cfg.Table,
+			RoutingTableItem{
+				Context:    context,
+				Condition:  condition,
+				Pipelines:  pipelines,
+				Percentage: percentage,
+			})
+	}
+}
+
 func withDefault(pipelines ...pipeline.ID) testConfigOption {
 	return func(cfg *Config) {
 		cfg.DefaultPipelines = pipelines
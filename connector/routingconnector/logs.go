@@ -21,7 +21,6 @@ import (
 
 type logsConnector struct {
 	component.StartFunc
-	component.ShutdownFunc
 
 	logger *zap.Logger
 	config *Config
@@ -55,6 +54,11 @@ func newLogsConnector(
 	}, nil
 }
 
+func (c *logsConnector) Shutdown(context.Context) error {
+	c.router.telemetryBuilder.Shutdown()
+	return nil
+}
+
 func (*logsConnector) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
@@ -67,7 +71,8 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 		matchedLogs := plog.NewLogs()
 		switch route.statementContext {
 		case "request":
-			if route.requestCondition.matchRequest(ctx) {
+			if route.requestCondition.matchRequest(ctx) && route.sampler.sampleRandom() {
+				c.router.recordRouted(ctx, route.name, ld.LogRecordCount())
 				groupAllLogs(groups, route.consumer, ld)
 				ld = plog.NewLogs() // all logs have been routed
 			}
@@ -77,7 +82,7 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 					rtx := ottlresource.NewTransformContext(rl.Resource(), rl)
 					_, isMatch, err := route.resourceStatement.Execute(ctx, rtx)
 					errs = errors.Join(errs, err)
-					return isMatch
+					return isMatch && route.sampler.sampleResource(rl.Resource())
 				},
 			)
 		case "log":
@@ -86,7 +91,7 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 					ltx := ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource(), sl, rl)
 					_, isMatch, err := route.logStatement.Execute(ctx, ltx)
 					errs = errors.Join(errs, err)
-					return isMatch
+					return isMatch && route.sampler.sampleTraceID(lr.TraceID(), rl.Resource())
 				},
 			)
 		}
@@ -96,9 +101,11 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 			}
 			groupAllLogs(groups, c.router.defaultConsumer, matchedLogs)
 		}
+		c.router.recordRouted(ctx, route.name, matchedLogs.LogRecordCount())
 		groupAllLogs(groups, route.consumer, matchedLogs)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
+	c.router.recordRouted(ctx, c.router.defaultRoute, ld.LogRecordCount())
 	groupAllLogs(groups, c.router.defaultConsumer, ld)
 	for consumer, group := range groups {
 		errs = errors.Join(errs, consumer.ConsumeLogs(ctx, group))
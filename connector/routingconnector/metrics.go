@@ -22,7 +22,6 @@ import (
 
 type metricsConnector struct {
 	component.StartFunc
-	component.ShutdownFunc
 
 	logger *zap.Logger
 	config *Config
@@ -56,6 +55,11 @@ func newMetricsConnector(
 	}, nil
 }
 
+func (c *metricsConnector) Shutdown(context.Context) error {
+	c.router.telemetryBuilder.Shutdown()
+	return nil
+}
+
 func (*metricsConnector) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
@@ -68,7 +72,8 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 		matchedMetrics := pmetric.NewMetrics()
 		switch route.statementContext {
 		case "request":
-			if route.requestCondition.matchRequest(ctx) {
+			if route.requestCondition.matchRequest(ctx) && route.sampler.sampleRandom() {
+				c.router.recordRouted(ctx, route.name, md.DataPointCount())
 				groupAllMetrics(groups, route.consumer, md)
 				md = pmetric.NewMetrics() // all metrics have been routed
 			}
@@ -78,7 +83,7 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 					rtx := ottlresource.NewTransformContext(rs.Resource(), rs)
 					_, isMatch, err := route.resourceStatement.Execute(ctx, rtx)
 					errs = errors.Join(errs, err)
-					return isMatch
+					return isMatch && route.sampler.sampleResource(rs.Resource())
 				},
 			)
 		case "metric":
@@ -87,7 +92,7 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 					mtx := ottlmetric.NewTransformContext(m, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)
 					_, isMatch, err := route.metricStatement.Execute(ctx, mtx)
 					errs = errors.Join(errs, err)
-					return isMatch
+					return isMatch && route.sampler.sampleResource(rm.Resource())
 				},
 			)
 		case "datapoint":
@@ -96,7 +101,7 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 					dptx := ottldatapoint.NewTransformContext(dp, m, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)
 					_, isMatch, err := route.dataPointStatement.Execute(ctx, dptx)
 					errs = errors.Join(errs, err)
-					return isMatch
+					return isMatch && route.sampler.sampleResource(rm.Resource())
 				},
 			)
 		}
@@ -106,9 +111,11 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 			}
 			groupAllMetrics(groups, c.router.defaultConsumer, matchedMetrics)
 		}
+		c.router.recordRouted(ctx, route.name, matchedMetrics.DataPointCount())
 		groupAllMetrics(groups, route.consumer, matchedMetrics)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
+	c.router.recordRouted(ctx, c.router.defaultRoute, md.DataPointCount())
 	groupAllMetrics(groups, c.router.defaultConsumer, md)
 	for consumer, group := range groups {
 		errs = errors.Join(errs, consumer.ConsumeMetrics(ctx, group))
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// percentageSampler selects a share of the telemetry matching a route. A nil
+// sampler selects everything, so routes without a percentage can use it too.
+type percentageSampler struct {
+	// threshold is compared against uniformly distributed 64-bit values, the
+	// ones below it are selected. Unused when all is set.
+	threshold uint64
+	all       bool
+	// salt makes the hashed decisions of different routes independent.
+	salt          []byte
+	hashKey       string
+	hashAttribute string
+}
+
+func newPercentageSampler(item RoutingTableItem) *percentageSampler {
+	if item.Percentage == 0 {
+		return nil
+	}
+	s := &percentageSampler{
+		hashKey:       item.HashKey,
+		hashAttribute: item.HashAttribute,
+		salt:          []byte(key(item)),
+	}
+	if item.Percentage >= 100 {
+		s.all = true
+	} else {
+		s.threshold = uint64(math.Ldexp(item.Percentage/100, 64))
+	}
+	return s
+}
+
+// sampleRandom makes a random decision, used when there is no hash key.
+func (s *percentageSampler) sampleRandom() bool {
+	if s == nil || s.all {
+		return true
+	}
+	return rand.Uint64() < s.threshold
+}
+
+// sampleResource makes a decision consistent for the resource when hashing on
+// a resource attribute. Telemetry without the attribute is sampled randomly.
+func (s *percentageSampler) sampleResource(res pcommon.Resource) bool {
+	if s == nil || s.all {
+		return true
+	}
+	if s.hashKey == hashKeyResourceAttribute {
+		if v, ok := res.Attributes().Get(s.hashAttribute); ok {
+			return s.sampleHash([]byte(v.AsString()))
+		}
+	}
+	return s.sampleRandom()
+}
+
+// sampleTraceID makes a decision consistent for the trace when hashing on the
+// trace ID, falling back to sampleResource otherwise.
+func (s *percentageSampler) sampleTraceID(traceID pcommon.TraceID, res pcommon.Resource) bool {
+	if s == nil || s.all {
+		return true
+	}
+	if s.hashKey == hashKeyTraceID && !traceID.IsEmpty() {
+		return s.sampleHash(traceID[:])
+	}
+	return s.sampleResource(res)
+}
+
+func (s *percentageSampler) sampleHash(value []byte) bool {
+	h := fnv.New64a()
+	_, _ = h.Write(s.salt)
+	_, _ = h.Write(value)
+	return mix64(h.Sum64()) < s.threshold
+}
+
+// mix64 is the finalizer of the SplitMix64 generator. FNV hashes of similar
+// inputs differ mostly in their low bits, mixing spreads them to the high bits
+// that decide whether the value is below the threshold.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
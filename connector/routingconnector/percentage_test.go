@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestPercentageSamplerNil(t *testing.T) {
+	s := newPercentageSampler(RoutingTableItem{Statement: "route()"})
+	assert.Nil(t, s)
+	assert.True(t, s.sampleRandom())
+	assert.True(t, s.sampleResource(pcommon.NewResource()))
+	assert.True(t, s.sampleTraceID(traceIDFromInt(1), pcommon.NewResource()))
+}
+
+func TestPercentageSamplerAll(t *testing.T) {
+	s := newPercentageSampler(RoutingTableItem{Statement: "route()", Percentage: 100})
+	for i := range 100 {
+		assert.True(t, s.sampleRandom())
+		assert.True(t, s.sampleTraceID(traceIDFromInt(i), pcommon.NewResource()))
+	}
+}
+
+func TestPercentageSamplerRandom(t *testing.T) {
+	s := newPercentageSampler(RoutingTableItem{Statement: "route()", Percentage: 25})
+	assert.InDelta(t, 0.25, sampledRatio(func(int) bool { return s.sampleRandom() }), 0.03)
+}
+
+func TestPercentageSamplerTraceID(t *testing.T) {
+	s := newPercentageSampler(RoutingTableItem{Context: "span", Statement: "route()", Percentage: 25, HashKey: hashKeyTraceID})
+	res := pcommon.NewResource()
+	assert.InDelta(t, 0.25, sampledRatio(func(i int) bool { return s.sampleTraceID(traceIDFromInt(i), res) }), 0.03)
+	for i := range 1000 {
+		assert.Equal(t, s.sampleTraceID(traceIDFromInt(i), res), s.sampleTraceID(traceIDFromInt(i), res))
+	}
+
+	// routes with different keys decide independently
+	other := newPercentageSampler(RoutingTableItem{Context: "span", Statement: `route() where name != ""`, Percentage: 25, HashKey: hashKeyTraceID})
+	both := sampledRatio(func(i int) bool {
+		return s.sampleTraceID(traceIDFromInt(i), res) && other.sampleTraceID(traceIDFromInt(i), res)
+	})
+	assert.InDelta(t, 0.0625, both, 0.02)
+}
+
+func TestPercentageSamplerResourceAttribute(t *testing.T) {
+	s := newPercentageSampler(RoutingTableItem{Statement: "route()", Percentage: 50, HashKey: hashKeyResourceAttribute, HashAttribute: "service.name"})
+	sampled := 0
+	for i := range 100 {
+		res := pcommon.NewResource()
+		res.Attributes().PutStr("service.name", "svc-"+string(rune('a'+i%26))+string(rune('a'+i/26)))
+		decision := s.sampleResource(res)
+		for range 10 {
+			assert.Equal(t, decision, s.sampleResource(res))
+		}
+		if decision {
+			sampled++
+		}
+	}
+	assert.Positive(t, sampled)
+	assert.Less(t, sampled, 100)
+
+	// resources without the attribute are sampled randomly
+	assert.InDelta(t, 0.5, sampledRatio(func(int) bool { return s.sampleResource(pcommon.NewResource()) }), 0.03)
+}
+
+func sampledRatio(sample func(i int) bool) float64 {
+	const n = 10000
+	sampled := 0
+	for i := range n {
+		if sample(i) {
+			sampled++
+		}
+	}
+	return float64(sampled) / n
+}
+
+func traceIDFromInt(i int) pcommon.TraceID {
+	var id pcommon.TraceID
+	binary.BigEndian.PutUint64(id[8:], uint64(i+1))
+	return id
+}
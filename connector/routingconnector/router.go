@@ -4,15 +4,20 @@
 package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
@@ -38,7 +43,9 @@ type router[C any] struct {
 	dataPointParser  ottl.Parser[ottldatapoint.TransformContext]
 	logParser        ottl.Parser[ottllog.TransformContext]
 	defaultConsumer  C
+	defaultRoute     string
 	logger           *zap.Logger
+	telemetryBuilder *metadata.TelemetryBuilder
 	routes           map[string]routingItem[C]
 	consumerProvider consumerProvider[C]
 	table            []RoutingTableItem
@@ -64,6 +71,12 @@ func newRouter[C any](
 		return nil, err
 	}
 
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(settings)
+	if err != nil {
+		return nil, err
+	}
+	r.telemetryBuilder = telemetryBuilder
+
 	if err := r.registerConsumers(defaultPipelineIDs); err != nil {
 		return nil, err
 	}
@@ -80,6 +93,21 @@ type routingItem[C any] struct {
 	dataPointStatement *ottl.Statement[ottldatapoint.TransformContext]
 	logStatement       *ottl.Statement[ottllog.TransformContext]
 	statementContext   string
+	sampler            *percentageSampler
+	// name identifies the route in the routed items counter, it is the index of the route in the table
+	name string
+}
+
+// defaultRouteName identifies the default pipelines in the routed items counter
+const defaultRouteName = "default"
+
+// recordRouted counts the items sent by a route.
+func (r *router[C]) recordRouted(ctx context.Context, route string, count int) {
+	if count == 0 || route == "" {
+		return
+	}
+	r.telemetryBuilder.ConnectorRoutingRoutedItems.Add(ctx, int64(count),
+		metric.WithAttributeSet(attribute.NewSet(attribute.String("route", route))))
 }
 
 func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings) error {
@@ -188,26 +216,32 @@ func (r *router[C]) registerDefaultConsumer(pipelineIDs []pipeline.ID) error {
 	}
 
 	r.defaultConsumer = consumer
+	r.defaultRoute = defaultRouteName
 
 	return nil
 }
 
-// convert conditions to statements
+// convert conditions to statements, routes with only a percentage match everything
 func (r *router[C]) normalizeConditions() {
 	for i := range r.table {
 		item := &r.table[i]
-		if item.Condition != "" {
+		switch {
+		case item.Condition != "":
 			item.Statement = fmt.Sprintf("route() where %s", item.Condition)
+		case item.Statement == "":
+			item.Statement = "route()"
 		}
 	}
 }
 
 // registerRouteConsumers registers a consumer for the pipelines configured for each route
 func (r *router[C]) registerRouteConsumers() (err error) {
-	for _, item := range r.table {
+	for i, item := range r.table {
 		route, ok := r.routes[key(item)]
 		if !ok {
 			route.statementContext = item.Context
+			route.sampler = newPercentageSampler(item)
+			route.name = strconv.Itoa(i)
 			switch item.Context {
 			case "request":
 				route.requestCondition, err = parseRequestCondition(item.Condition)
@@ -269,6 +303,13 @@ func (r *router[C]) registerRouteConsumers() (err error) {
 }
 
 func key(entry RoutingTableItem) string {
+	if entry.Percentage > 0 {
+		return fmt.Sprintf("%s [percentage %g %s %s]", statementKey(entry), entry.Percentage, entry.HashKey, entry.HashAttribute)
+	}
+	return statementKey(entry)
+}
+
+func statementKey(entry RoutingTableItem) string {
 	switch entry.Context {
 	case "", "resource":
 		return entry.Statement
@@ -21,7 +21,6 @@ import (
 
 type tracesConnector struct {
 	component.StartFunc
-	component.ShutdownFunc
 
 	logger *zap.Logger
 	config *Config
@@ -55,6 +54,11 @@ func newTracesConnector(
 	}, nil
 }
 
+func (c *tracesConnector) Shutdown(context.Context) error {
+	c.router.telemetryBuilder.Shutdown()
+	return nil
+}
+
 func (*tracesConnector) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
@@ -67,7 +71,8 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 		matchedSpans := ptrace.NewTraces()
 		switch route.statementContext {
 		case "request":
-			if route.requestCondition.matchRequest(ctx) {
+			if route.requestCondition.matchRequest(ctx) && route.sampler.sampleRandom() {
+				c.router.recordRouted(ctx, route.name, td.SpanCount())
 				groupAllTraces(groups, route.consumer, td)
 				td = ptrace.NewTraces() // all traces have been routed
 			}
@@ -77,7 +82,7 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 					rtx := ottlresource.NewTransformContext(rs.Resource(), rs)
 					_, isMatch, err := route.resourceStatement.Execute(ctx, rtx)
 					errs = errors.Join(errs, err)
-					return isMatch
+					return isMatch && route.sampler.sampleResource(rs.Resource())
 				},
 			)
 		case "span":
@@ -86,7 +91,7 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 					mtx := ottlspan.NewTransformContext(s, ss.Scope(), rs.Resource(), ss, rs)
 					_, isMatch, err := route.spanStatement.Execute(ctx, mtx)
 					errs = errors.Join(errs, err)
-					return isMatch
+					return isMatch && route.sampler.sampleTraceID(s.TraceID(), rs.Resource())
 				},
 			)
 		}
@@ -96,9 +101,11 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 			}
 			groupAllTraces(groups, c.router.defaultConsumer, matchedSpans)
 		}
+		c.router.recordRouted(ctx, route.name, matchedSpans.SpanCount())
 		groupAllTraces(groups, route.consumer, matchedSpans)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
+	c.router.recordRouted(ctx, c.router.defaultRoute, td.SpanCount())
 	groupAllTraces(groups, c.router.defaultConsumer, td)
 	for consumer, group := range groups {
 		errs = errors.Join(errs, consumer.ConsumeTraces(ctx, group))
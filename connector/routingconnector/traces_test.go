@@ -14,11 +14,16 @@ import (
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadatatest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/ptraceutiltest"
 )
 
@@ -861,6 +866,26 @@ func TestTracesConnectorDetailed(t *testing.T) {
 			expectSink1: ptraceutiltest.NewTraces("AB", "CD", "E", "GH"),
 			expectSinkD: ptrace.Traces{},
 		},
+		{
+			name: "percentage/span/no_condition",
+			cfg: testConfig(
+				withPercentageRoute("span", "", 100, idSink0),
+				withDefault(idSinkD),
+			),
+			input:       ptraceutiltest.NewTraces("AB", "CD", "EF", "GH"),
+			expectSink0: ptraceutiltest.NewTraces("AB", "CD", "EF", "GH"),
+			expectSinkD: ptrace.Traces{},
+		},
+		{
+			name: "percentage/span/with_condition",
+			cfg: testConfig(
+				withPercentageRoute("span", isSpanF, 100, idSink0),
+				withDefault(idSinkD),
+			),
+			input:       ptraceutiltest.NewTraces("AB", "CD", "EF", "GH"),
+			expectSink0: ptraceutiltest.NewTraces("AB", "CD", "F", "GH"),
+			expectSinkD: ptraceutiltest.NewTraces("AB", "CD", "E", "GH"),
+		},
 	}
 
 	for _, tt := range testCases {
@@ -901,3 +926,76 @@ func TestTracesConnectorDetailed(t *testing.T) {
 		})
 	}
 }
+
+func TestTracesPercentageRoutingByTraceID(t *testing.T) {
+	canary := pipeline.NewIDWithName(pipeline.SignalTraces, "canary")
+	stable := pipeline.NewIDWithName(pipeline.SignalTraces, "stable")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{stable},
+		Table: []RoutingTableItem{
+			{
+				Context:    "span",
+				Percentage: 20,
+				HashKey:    hashKeyTraceID,
+				Pipelines:  []pipeline.ID{canary},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var sinkCanary, sinkStable consumertest.TracesSink
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		canary: &sinkCanary,
+		stable: &sinkStable,
+	})
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(), metadatatest.NewSettings(tel), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	// every trace has a span in each of the two resources
+	td := ptrace.NewTraces()
+	for range 2 {
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for i := range 500 {
+			spans.AppendEmpty().SetTraceID(traceIDFromInt(i))
+		}
+	}
+	require.NoError(t, conn.ConsumeTraces(t.Context(), td))
+
+	traceIDs := func(sink *consumertest.TracesSink) map[pcommon.TraceID]int {
+		ids := map[pcommon.TraceID]int{}
+		for _, traces := range sink.AllTraces() {
+			for i := 0; i < traces.ResourceSpans().Len(); i++ {
+				spans := traces.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+				for j := 0; j < spans.Len(); j++ {
+					ids[spans.At(j).TraceID()]++
+				}
+			}
+		}
+		return ids
+	}
+	canaryIDs, stableIDs := traceIDs(&sinkCanary), traceIDs(&sinkStable)
+	for id, count := range canaryIDs {
+		assert.Equal(t, 2, count, "all spans of a trace are routed alike")
+		assert.NotContains(t, stableIDs, id)
+	}
+	assert.Len(t, stableIDs, 500-len(canaryIDs))
+	assert.InDelta(t, 100, len(canaryIDs), 35)
+
+	metadatatest.AssertEqualConnectorRoutingRoutedItems(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Value:      int64(sinkCanary.SpanCount()),
+			Attributes: attribute.NewSet(attribute.String("route", "0")),
+		},
+		{
+			Value:      int64(sinkStable.SpanCount()),
+			Attributes: attribute.NewSet(attribute.String("route", defaultRouteName)),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+
+	require.NoError(t, conn.Shutdown(t.Context()))
+}
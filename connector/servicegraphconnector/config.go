@@ -53,9 +53,29 @@ type Config struct {
 	MetricsFlushInterval *time.Duration `mapstructure:"metrics_flush_interval"`
 
 	// DatabaseNameAttributes is the attribute name list of attributes need to match used to identify the database name from span attributes, the higher the front, the higher the priority.
-	// The default value is {"db.name"}.
+	// The default value is {"db.name", "db.namespace"}.
 	DatabaseNameAttributes []string `mapstructure:"database_name_attributes"`
 
+	// MessagingDestinationAttributes is the attribute name list used to identify the destination of
+	// producer and consumer spans, the higher the front, the higher the priority.
+	// The default value is {"messaging.destination.name", "messaging.destination"}.
+	MessagingDestinationAttributes []string `mapstructure:"messaging_destination_attributes"`
+
+	// MessagingDestinationNodes enables representing messaging destinations, e.g. Kafka topics, as nodes.
+	// A request across a messaging system is recorded as an edge from the producer to the destination
+	// and an edge from the destination to the consumer.
+	MessagingDestinationNodes bool `mapstructure:"messaging_destination_nodes"`
+
+	// MessagingSpanLinks enables pairing consumer spans without a parent with producer spans through span links.
+	MessagingSpanLinks bool `mapstructure:"messaging_span_links"`
+
+	// EnableMessagingSystemLatencyHistogram enables the histogram of the time messages spend in the
+	// messaging system, measured from the end of the producer span to the start of the consumer span.
+	EnableMessagingSystemLatencyHistogram bool `mapstructure:"enable_messaging_system_latency_histogram"`
+
+	// ConnectionSystemExtraLabel enables the `connection_system` label holding the messaging or database system of the edge.
+	ConnectionSystemExtraLabel bool `mapstructure:"connection_system_extra_label"`
+
 	// MetricsTimestampOffset is the offset to subtract from metric timestamps.
 	// If set to a positive duration, metric timestamps will be set to (current time - offset),
 	// effectively shifting metrics to appear as if they were generated in the past.
@@ -35,9 +35,12 @@ func TestLoadConfig(t *testing.T) {
 				TTL:      time.Second,
 				MaxItems: 10,
 			},
-			CacheLoop:              time.Minute,
-			StoreExpirationLoop:    2 * time.Second,
-			DatabaseNameAttributes: []string{"db.name"},
+			CacheLoop:                             time.Minute,
+			StoreExpirationLoop:                   2 * time.Second,
+			DatabaseNameAttributes:                []string{"db.name"},
+			MessagingDestinationNodes:             true,
+			MessagingSpanLinks:                    true,
+			EnableMessagingSystemLatencyHistogram: true,
 		},
 		cfg.Connectors[component.NewID(metadata.Type)],
 	)
@@ -35,6 +35,16 @@ const (
 	virtualNodeLabel   = "virtual_node"
 	millisecondsUnit   = "ms"
 	secondsUnit        = "s"
+
+	connectionSystemLabel = "connection_system"
+	// unknownDatabaseSystem prefixes the name of database nodes when the database system isn't known
+	unknownDatabaseSystem = "database"
+
+	// Attributes introduced in later versions of the semantic conventions.
+	dbNamespaceKey  = "db.namespace"
+	dbSystemNameKey = "db.system.name"
+	// Attribute used before messaging.destination.name was introduced in the semantic conventions.
+	legacyMessagingDestinationKey = "messaging.destination"
 )
 
 var (
@@ -49,7 +59,12 @@ var (
 		string(semconv.PeerServiceKey), string(semconv.DBNameKey), string(semconv.DBSystemKey),
 	}
 
-	defaultDatabaseNameAttributes = []string{string(semconv.DBNameKey)}
+	defaultDatabaseNameAttributes = []string{string(semconv.DBNameKey), dbNamespaceKey}
+
+	defaultMessagingDestinationAttributes = []string{string(semconv.MessagingDestinationNameKey), legacyMessagingDestinationKey}
+
+	databaseSystemAttributes  = []string{string(semconv.DBSystemKey), dbSystemNameKey}
+	messagingSystemAttributes = []string{string(semconv.MessagingSystemKey)}
 
 	defaultMetricsFlushInterval = 60 * time.Second // 1 DPM
 )
@@ -70,18 +85,22 @@ type serviceGraphConnector struct {
 
 	startTime time.Time
 
-	seriesMutex                          sync.Mutex
-	reqTotal                             map[string]int64
-	reqFailedTotal                       map[string]int64
-	reqClientDurationSecondsCount        map[string]uint64
-	reqClientDurationSecondsSum          map[string]float64
-	reqClientDurationSecondsBucketCounts map[string][]uint64
-	reqClientDurationExpHistogram        map[string]*structure.Histogram[float64]
-	reqServerDurationSecondsCount        map[string]uint64
-	reqServerDurationSecondsSum          map[string]float64
-	reqServerDurationSecondsBucketCounts map[string][]uint64
-	reqServerDurationExpHistogram        map[string]*structure.Histogram[float64]
-	reqDurationBounds                    []float64
+	seriesMutex                           sync.Mutex
+	reqTotal                              map[string]int64
+	reqFailedTotal                        map[string]int64
+	reqClientDurationSecondsCount         map[string]uint64
+	reqClientDurationSecondsSum           map[string]float64
+	reqClientDurationSecondsBucketCounts  map[string][]uint64
+	reqClientDurationExpHistogram         map[string]*structure.Histogram[float64]
+	reqServerDurationSecondsCount         map[string]uint64
+	reqServerDurationSecondsSum           map[string]float64
+	reqServerDurationSecondsBucketCounts  map[string][]uint64
+	reqServerDurationExpHistogram         map[string]*structure.Histogram[float64]
+	reqMessagingSystemSecondsCount        map[string]uint64
+	reqMessagingSystemSecondsSum          map[string]float64
+	reqMessagingSystemSecondsBucketCounts map[string][]uint64
+	reqMessagingSystemExpHistogram        map[string]*structure.Histogram[float64]
+	reqDurationBounds                     []float64
 
 	metricMutex sync.RWMutex
 	keyToMetric map[string]metricSeries
@@ -125,6 +144,10 @@ func newConnector(set component.TelemetrySettings, config component.Config, next
 		pConfig.DatabaseNameAttributes = defaultDatabaseNameAttributes
 	}
 
+	if len(pConfig.MessagingDestinationAttributes) == 0 {
+		pConfig.MessagingDestinationAttributes = defaultMessagingDestinationAttributes
+	}
+
 	if pConfig.MetricsFlushInterval == nil {
 		pConfig.MetricsFlushInterval = &defaultMetricsFlushInterval
 	} else if pConfig.MetricsFlushInterval.Nanoseconds() <= 0 {
@@ -141,21 +164,25 @@ func newConnector(set component.TelemetrySettings, config component.Config, next
 		logger:          set.Logger,
 		metricsConsumer: next,
 
-		startTime:                            time.Now(),
-		reqTotal:                             make(map[string]int64),
-		reqFailedTotal:                       make(map[string]int64),
-		reqClientDurationSecondsCount:        make(map[string]uint64),
-		reqClientDurationSecondsSum:          make(map[string]float64),
-		reqClientDurationSecondsBucketCounts: make(map[string][]uint64),
-		reqClientDurationExpHistogram:        make(map[string]*structure.Histogram[float64]),
-		reqServerDurationSecondsCount:        make(map[string]uint64),
-		reqServerDurationSecondsSum:          make(map[string]float64),
-		reqServerDurationSecondsBucketCounts: make(map[string][]uint64),
-		reqServerDurationExpHistogram:        make(map[string]*structure.Histogram[float64]),
-		reqDurationBounds:                    bounds,
-		keyToMetric:                          make(map[string]metricSeries),
-		shutdownCh:                           make(chan any),
-		telemetryBuilder:                     telemetryBuilder,
+		startTime:                             time.Now(),
+		reqTotal:                              make(map[string]int64),
+		reqFailedTotal:                        make(map[string]int64),
+		reqClientDurationSecondsCount:         make(map[string]uint64),
+		reqClientDurationSecondsSum:           make(map[string]float64),
+		reqClientDurationSecondsBucketCounts:  make(map[string][]uint64),
+		reqClientDurationExpHistogram:         make(map[string]*structure.Histogram[float64]),
+		reqServerDurationSecondsCount:         make(map[string]uint64),
+		reqServerDurationSecondsSum:           make(map[string]float64),
+		reqServerDurationSecondsBucketCounts:  make(map[string][]uint64),
+		reqServerDurationExpHistogram:         make(map[string]*structure.Histogram[float64]),
+		reqMessagingSystemSecondsCount:        make(map[string]uint64),
+		reqMessagingSystemSecondsSum:          make(map[string]float64),
+		reqMessagingSystemSecondsBucketCounts: make(map[string][]uint64),
+		reqMessagingSystemExpHistogram:        make(map[string]*structure.Histogram[float64]),
+		reqDurationBounds:                     bounds,
+		keyToMetric:                           make(map[string]metricSeries),
+		shutdownCh:                            make(chan any),
+		telemetryBuilder:                      telemetryBuilder,
 	}, nil
 }
 
@@ -259,6 +286,10 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 
 				connectionType := store.Unknown
 
+				var (
+					keys   []store.Key
+					update store.Callback
+				)
 				switch span.Kind() {
 				case ptrace.SpanKindProducer:
 					// override connection type and continue processing as span kind client
@@ -266,15 +297,20 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 					fallthrough
 				case ptrace.SpanKindClient:
 					traceID := span.TraceID()
-					key := store.NewKey(traceID, span.SpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					keys = []store.Key{store.NewKey(traceID, span.SpanID())}
+					update = func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ClientService = serviceName
 						e.ClientLatencySec = spanDuration(span)
+						e.ClientEndTime = span.EndTimestamp()
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(clientKind, e.Dimensions, rAttributes, span.Attributes())
 
+						if connectionType == store.MessagingSystem {
+							p.upsertMessagingAttributes(e, rAttributes, span.Attributes())
+						}
+
 						if virtualNodeFeatureGate.IsEnabled() {
 							p.upsertPeerAttributes(p.config.VirtualNodePeerAttributes, e.Peer, span.Attributes())
 						}
@@ -283,43 +319,59 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 						// span but just copy details from the client span
 						if dbName, ok := getFirstMatchingValue(p.config.DatabaseNameAttributes, rAttributes, span.Attributes()); ok {
 							e.ConnectionType = store.Database
-							e.ServerService = dbName
+							// The database node is named after the system, so that it can't be mistaken for a service
+							// with the same name, and databases with the same name in different systems are different nodes
+							dbSystem, ok := getFirstMatchingValue(databaseSystemAttributes, rAttributes, span.Attributes())
+							if ok {
+								e.ConnectionSystem = dbSystem
+							} else {
+								dbSystem = unknownDatabaseSystem
+							}
+							e.ServerService = dbSystem + "/" + dbName
 							e.ServerLatencySec = spanDuration(span)
 						}
-					})
+					}
 				case ptrace.SpanKindConsumer:
 					// override connection type and continue processing as span kind server
 					connectionType = store.MessagingSystem
 					fallthrough
 				case ptrace.SpanKindServer:
 					traceID := span.TraceID()
-					key := store.NewKey(traceID, span.ParentSpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					keys = p.serverKeys(span, connectionType)
+					update = func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ServerService = serviceName
 						e.ServerLatencySec = spanDuration(span)
+						e.ServerStartTime = span.StartTimestamp()
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
-					})
+
+						if connectionType == store.MessagingSystem {
+							p.upsertMessagingAttributes(e, rAttributes, span.Attributes())
+						}
+					}
 				default:
 					// this span is not part of an edge
 					continue
 				}
 
-				if errors.Is(err, store.ErrTooManyItems) {
-					totalDroppedSpans++
-					p.telemetryBuilder.ConnectorServicegraphDroppedSpans.Add(ctx, 1)
-					continue
-				}
-
-				// UpsertEdge will only return ErrTooManyItems
-				if err != nil {
-					return err
-				}
-
-				if isNew {
-					p.telemetryBuilder.ConnectorServicegraphTotalEdges.Add(ctx, 1)
+				for _, key := range keys {
+					isNew, err = p.store.UpsertEdge(key, update)
+					if errors.Is(err, store.ErrTooManyItems) {
+						totalDroppedSpans++
+						p.telemetryBuilder.ConnectorServicegraphDroppedSpans.Add(ctx, 1)
+						continue
+					}
+
+					// UpsertEdge will only return ErrTooManyItems
+					if err != nil {
+						return err
+					}
+
+					if isNew {
+						p.telemetryBuilder.ConnectorServicegraphTotalEdges.Add(ctx, 1)
+					}
 				}
 			}
 		}
@@ -327,6 +379,22 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 	return nil
 }
 
+// serverKeys returns the keys of the edges a server or consumer span belongs to. Consumer spans
+// without a parent, e.g. spans consuming a batch of messages, are paired with the producer spans
+// they link to when MessagingSpanLinks is enabled.
+func (p *serviceGraphConnector) serverKeys(span ptrace.Span, connectionType store.ConnectionType) []store.Key {
+	links := span.Links()
+	if !p.config.MessagingSpanLinks || connectionType != store.MessagingSystem || !span.ParentSpanID().IsEmpty() || links.Len() == 0 {
+		return []store.Key{store.NewKey(span.TraceID(), span.ParentSpanID())}
+	}
+
+	keys := make([]store.Key, 0, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		keys = append(keys, store.NewKey(links.At(i).TraceID(), links.At(i).SpanID()))
+	}
+	return keys
+}
+
 func (p *serviceGraphConnector) upsertDimensions(kind string, m map[string]string, resourceAttr, spanAttr pcommon.Map) {
 	for _, dim := range p.config.Dimensions {
 		if v, ok := pdatautil.GetAttributeValue(dim, resourceAttr, spanAttr); ok {
@@ -335,6 +403,15 @@ func (p *serviceGraphConnector) upsertDimensions(kind string, m map[string]strin
 	}
 }
 
+func (p *serviceGraphConnector) upsertMessagingAttributes(e *store.Edge, resourceAttr, spanAttr pcommon.Map) {
+	if v, ok := getFirstMatchingValue(messagingSystemAttributes, resourceAttr, spanAttr); ok {
+		e.ConnectionSystem = v
+	}
+	if v, ok := getFirstMatchingValue(p.config.MessagingDestinationAttributes, resourceAttr, spanAttr); ok {
+		e.MessagingDestination = v
+	}
+}
+
 func (*serviceGraphConnector) upsertPeerAttributes(m []string, peers map[string]string, spanAttr pcommon.Map) {
 	for _, s := range m {
 		if v, ok := pdatautil.GetAttributeValue(s, spanAttr); ok {
@@ -352,6 +429,11 @@ func (p *serviceGraphConnector) onComplete(e *store.Edge) {
 		zap.String("connection_type", string(e.ConnectionType)),
 		zap.Stringer("trace_id", e.TraceID),
 	)
+
+	if p.hasDestinationNode(e) {
+		p.aggregateMetricsForDestinationEdges(e)
+		return
+	}
 	p.aggregateMetricsForEdge(e)
 }
 
@@ -366,6 +448,12 @@ func (p *serviceGraphConnector) onExpire(e *store.Edge) {
 
 	p.telemetryBuilder.ConnectorServicegraphExpiredEdges.Add(context.Background(), 1)
 
+	// The destination is a node on its own, so the side of the request that was seen can still be recorded
+	if p.hasDestinationNode(e) {
+		p.aggregateMetricsForDestinationEdges(e)
+		return
+	}
+
 	if virtualNodeFeatureGate.IsEnabled() && len(p.config.VirtualNodePeerAttributes) > 0 {
 		e.ConnectionType = store.VirtualNode
 		if e.ClientService == "" && e.Key.SpanIDIsEmpty() {
@@ -386,6 +474,31 @@ func (p *serviceGraphConnector) onExpire(e *store.Edge) {
 	}
 }
 
+func (p *serviceGraphConnector) hasDestinationNode(e *store.Edge) bool {
+	return p.config.MessagingDestinationNodes && e.ConnectionType == store.MessagingSystem && e.MessagingDestination != ""
+}
+
+// aggregateMetricsForDestinationEdges records a request across a messaging system as an edge from the
+// producer to the destination and an edge from the destination to the consumer. Either side is missing
+// if the edge expired before the producer and the consumer spans were paired.
+func (p *serviceGraphConnector) aggregateMetricsForDestinationEdges(e *store.Edge) {
+	if e.ClientService != "" {
+		producer := *e
+		producer.ServerService = e.MessagingDestination
+		producer.ServerLatencySec = e.ClientLatencySec
+		// The time spent in the messaging system is recorded on the consumer side
+		producer.ServerStartTime = 0
+		p.aggregateMetricsForEdge(&producer)
+	}
+
+	if e.ServerService != "" {
+		consumer := *e
+		consumer.ClientService = e.MessagingDestination
+		consumer.ClientLatencySec = e.ServerLatencySec
+		p.aggregateMetricsForEdge(&consumer)
+	}
+}
+
 func (p *serviceGraphConnector) aggregateMetricsForEdge(e *store.Edge) {
 	metricKey := p.buildMetricKey(e.ClientService, e.ServerService, string(e.ConnectionType), strconv.FormatBool(e.Failed), e.Dimensions)
 	dimensions := buildDimensions(e)
@@ -394,6 +507,11 @@ func (p *serviceGraphConnector) aggregateMetricsForEdge(e *store.Edge) {
 		dimensions = addExtraLabel(dimensions, virtualNodeLabel, string(e.VirtualNodeLabel))
 	}
 
+	if p.config.ConnectionSystemExtraLabel {
+		metricKey += metricKeySeparator + e.ConnectionSystem
+		dimensions = addExtraLabel(dimensions, connectionSystemLabel, e.ConnectionSystem)
+	}
+
 	p.seriesMutex.Lock()
 	defer p.seriesMutex.Unlock()
 	p.updateSeries(metricKey, dimensions)
@@ -402,6 +520,9 @@ func (p *serviceGraphConnector) aggregateMetricsForEdge(e *store.Edge) {
 		p.updateErrorMetrics(metricKey)
 	}
 	p.updateDurationMetrics(metricKey, e.ServerLatencySec, e.ClientLatencySec)
+	if latency, ok := messagingSystemLatency(e); ok && p.config.EnableMessagingSystemLatencyHistogram {
+		p.updateMessagingSystemDurationMetrics(metricKey, latency)
+	}
 }
 
 func (p *serviceGraphConnector) updateSeries(key string, dimensions pcommon.Map) {
@@ -483,6 +604,31 @@ func (p *serviceGraphConnector) updateClientDurationMetrics(key string, duration
 	}
 }
 
+func (p *serviceGraphConnector) updateMessagingSystemDurationMetrics(key string, duration float64) {
+	if p.reqDurationBounds == nil {
+		histogram, ok := p.reqMessagingSystemExpHistogram[key]
+		if !ok {
+			histogram = new(structure.Histogram[float64])
+			cfg := structure.NewConfig(
+				structure.WithMaxSize(p.config.ExponentialHistogramMaxSize),
+			)
+			histogram.Init(cfg)
+			p.reqMessagingSystemExpHistogram[key] = histogram
+		}
+
+		histogram.Update(duration)
+	} else {
+		index := sort.SearchFloat64s(p.reqDurationBounds, duration) // Search bucket index
+		if _, ok := p.reqMessagingSystemSecondsBucketCounts[key]; !ok {
+			p.reqMessagingSystemSecondsBucketCounts[key] = make([]uint64, len(p.reqDurationBounds)+1)
+		}
+
+		p.reqMessagingSystemSecondsSum[key] += duration
+		p.reqMessagingSystemSecondsCount[key]++
+		p.reqMessagingSystemSecondsBucketCounts[key][index]++
+	}
+}
+
 func buildDimensions(e *store.Edge) pcommon.Map {
 	dims := pcommon.NewMap()
 	dims.PutStr("client", e.ClientService)
@@ -576,14 +722,23 @@ func (p *serviceGraphConnector) collectCountMetrics(ilm pmetric.ScopeMetrics) er
 func (p *serviceGraphConnector) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
 	// TODO: Remove this once legacy metric names are removed
 	if legacyMetricNamesFeatureGate.IsEnabled() {
-		return p.collectServerLatencyMetrics(ilm, "traces_service_graph_request_duration")
-	}
+		if err := p.collectServerLatencyMetrics(ilm, "traces_service_graph_request_duration"); err != nil {
+			return err
+		}
+	} else {
+		if err := p.collectServerLatencyMetrics(ilm, "traces_service_graph_request_server"); err != nil {
+			return err
+		}
 
-	if err := p.collectServerLatencyMetrics(ilm, "traces_service_graph_request_server"); err != nil {
-		return err
+		if err := p.collectClientLatencyMetrics(ilm); err != nil {
+			return err
+		}
 	}
 
-	return p.collectClientLatencyMetrics(ilm)
+	if p.config.EnableMessagingSystemLatencyHistogram {
+		return p.collectMessagingSystemLatencyMetrics(ilm)
+	}
+	return nil
 }
 
 func (p *serviceGraphConnector) collectClientLatencyMetrics(ilm pmetric.ScopeMetrics) error {
@@ -637,6 +792,61 @@ func (p *serviceGraphConnector) collectClientLatencyMetrics(ilm pmetric.ScopeMet
 	return nil
 }
 
+func (p *serviceGraphConnector) collectMessagingSystemLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+	mDuration := pmetric.NewMetric()
+	mDuration.SetName("traces_service_graph_request_messaging_system")
+	mDuration.SetUnit(secondsUnit)
+	if legacyLatencyUnitMsFeatureGate.IsEnabled() {
+		mDuration.SetUnit(millisecondsUnit)
+	}
+
+	if p.reqDurationBounds == nil {
+		if len(p.reqMessagingSystemExpHistogram) == 0 {
+			return nil
+		}
+		mDuration.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		timestamp := pcommon.NewTimestampFromTime(p.nowWithOffset())
+
+		for key, expHistogram := range p.reqMessagingSystemExpHistogram {
+			dpDuration := mDuration.ExponentialHistogram().DataPoints().AppendEmpty()
+			dpDuration.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+			dpDuration.SetTimestamp(timestamp)
+			dimensions, ok := p.dimensionsForSeries(key)
+			if !ok {
+				return fmt.Errorf("failed to find dimensions for key %s", key)
+			}
+
+			dimensions.CopyTo(dpDuration.Attributes())
+			dpDuration.SetCount(expHistogram.Count())
+			dpDuration.SetSum(expHistogram.Sum())
+			pdatautil.ExpoHistToExponentialDataPoint(expHistogram, dpDuration)
+		}
+		mDuration.CopyTo(ilm.Metrics().AppendEmpty())
+	} else if len(p.reqMessagingSystemSecondsCount) > 0 {
+		mDuration.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		timestamp := pcommon.NewTimestampFromTime(p.nowWithOffset())
+
+		for key := range p.reqMessagingSystemSecondsCount {
+			dpDuration := mDuration.Histogram().DataPoints().AppendEmpty()
+			dpDuration.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+			dpDuration.SetTimestamp(timestamp)
+			dpDuration.ExplicitBounds().FromRaw(p.reqDurationBounds)
+			dpDuration.BucketCounts().FromRaw(p.reqMessagingSystemSecondsBucketCounts[key])
+			dpDuration.SetCount(p.reqMessagingSystemSecondsCount[key])
+			dpDuration.SetSum(p.reqMessagingSystemSecondsSum[key])
+
+			dimensions, ok := p.dimensionsForSeries(key)
+			if !ok {
+				return fmt.Errorf("failed to find dimensions for key %s", key)
+			}
+
+			dimensions.CopyTo(dpDuration.Attributes())
+		}
+		mDuration.CopyTo(ilm.Metrics().AppendEmpty())
+	}
+	return nil
+}
+
 func (p *serviceGraphConnector) collectServerLatencyMetrics(ilm pmetric.ScopeMetrics, mName string) error {
 	timestamp := pcommon.NewTimestampFromTime(time.Now())
 	mDuration := pmetric.NewMetric()
@@ -765,6 +975,10 @@ func (p *serviceGraphConnector) cleanCache() {
 		delete(p.reqServerDurationSecondsCount, key)
 		delete(p.reqServerDurationSecondsSum, key)
 		delete(p.reqServerDurationSecondsBucketCounts, key)
+		delete(p.reqMessagingSystemSecondsCount, key)
+		delete(p.reqMessagingSystemSecondsSum, key)
+		delete(p.reqMessagingSystemSecondsBucketCounts, key)
+		delete(p.reqMessagingSystemExpHistogram, key)
 	}
 	p.seriesMutex.Unlock()
 
@@ -777,10 +991,28 @@ func (p *serviceGraphConnector) cleanCache() {
 
 // spanDuration returns the duration of the given span in seconds (legacy ms).
 func spanDuration(span ptrace.Span) float64 {
+	return timestampDiff(span.StartTimestamp(), span.EndTimestamp())
+}
+
+// messagingSystemLatency returns the time between the end of the producer span and the start of the
+// consumer span of a messaging system edge in seconds (legacy ms). A negative latency, caused by clock
+// skew between the producer and the consumer, is reported as zero.
+func messagingSystemLatency(e *store.Edge) (float64, bool) {
+	if e.ConnectionType != store.MessagingSystem || e.ClientEndTime == 0 || e.ServerStartTime == 0 {
+		return 0, false
+	}
+	if e.ServerStartTime <= e.ClientEndTime {
+		return 0, true
+	}
+	return timestampDiff(e.ClientEndTime, e.ServerStartTime), true
+}
+
+// timestampDiff returns the time between the given timestamps in seconds (legacy ms).
+func timestampDiff(start, end pcommon.Timestamp) float64 {
 	if legacyLatencyUnitMsFeatureGate.IsEnabled() {
-		return float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
+		return float64(end-start) / float64(time.Millisecond.Nanoseconds())
 	}
-	return float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Second.Nanoseconds())
+	return float64(end-start) / float64(time.Second.Nanoseconds())
 }
 
 // durationToFloat converts the given duration to the number of seconds (legacy ms) it represents.
@@ -30,6 +30,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/store"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
 )
@@ -168,6 +169,92 @@ func TestConnectorConsume(t *testing.T) {
 			gates:         []*featuregate.Gate{legacyLatencyUnitMsFeatureGate},
 			verifyMetrics: verifyHappyCaseLatencyMetrics(),
 		},
+		{
+			name: "messaging spans paired through span links with destination nodes",
+			cfg: &Config{
+				Store: StoreConfig{
+					MaxItems: 10,
+					TTL:      time.Nanosecond,
+				},
+				MessagingSpanLinks:                    true,
+				MessagingDestinationNodes:             true,
+				EnableMessagingSystemLatencyHistogram: true,
+				ConnectionSystemExtraLabel:            true,
+			},
+			sampleTraces:  buildMessagingTrace(),
+			verifyMetrics: verifyMessagingDestinationMetrics,
+		},
+		{
+			name: "incomplete messaging spans with destination nodes",
+			cfg: &Config{
+				Store: StoreConfig{
+					MaxItems: 10,
+					TTL:      time.Nanosecond,
+				},
+				MessagingDestinationNodes: true,
+			},
+			// The consumer span is not linked to the producer span, so each side is recorded on expiration
+			sampleTraces: buildMessagingTrace(),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				m, ok := findMetric(md, "traces_service_graph_request_total")
+				require.True(t, ok)
+				dps := m.Sum().DataPoints()
+				require.Equal(t, 2, dps.Len())
+				edges := make(map[string]int64)
+				for i := 0; i < dps.Len(); i++ {
+					client, _ := dps.At(i).Attributes().Get("client")
+					server, _ := dps.At(i).Attributes().Get("server")
+					edges[client.Str()+"->"+server.Str()] = dps.At(i).IntValue()
+				}
+				assert.Equal(t, map[string]int64{"producer->orders": 1, "orders->consumer": 1}, edges)
+
+				_, ok = findMetric(md, "traces_service_graph_request_messaging_system")
+				assert.False(t, ok)
+			},
+		},
+		{
+			name: "database spans with system in node name",
+			cfg: &Config{
+				Store: StoreConfig{
+					MaxItems: 10,
+					TTL:      time.Nanosecond,
+				},
+				ConnectionSystemExtraLabel: true,
+			},
+			sampleTraces: buildDatabaseTrace(),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				m, ok := findMetric(md, "traces_service_graph_request_total")
+				require.True(t, ok)
+				require.Equal(t, 1, m.Sum().DataPoints().Len())
+				attrs := m.Sum().DataPoints().At(0).Attributes()
+				verifyAttr(t, attrs, "client", "some-client-service")
+				verifyAttr(t, attrs, "server", "postgresql/orders")
+				verifyAttr(t, attrs, "connection_type", string(store.Database))
+				verifyAttr(t, attrs, connectionSystemLabel, "postgresql")
+			},
+		},
+		{
+			name: "database spans without system",
+			cfg: &Config{
+				Store: StoreConfig{
+					MaxItems: 10,
+					TTL:      time.Nanosecond,
+				},
+			},
+			sampleTraces: func() ptrace.Traces {
+				td := buildDatabaseTrace()
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Remove(string(semconv.DBSystemKey))
+				return td
+			}(),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				m, ok := findMetric(md, "traces_service_graph_request_total")
+				require.True(t, ok)
+				require.Equal(t, 1, m.Sum().DataPoints().Len())
+				attrs := m.Sum().DataPoints().At(0).Attributes()
+				verifyAttr(t, attrs, "server", "database/orders")
+				verifyAttr(t, attrs, "connection_type", string(store.Database))
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			// Set feature gates
@@ -339,6 +426,105 @@ func buildSampleTrace(t *testing.T, attrValue string) ptrace.Traces {
 	return traces
 }
 
+func verifyMessagingDestinationMetrics(t *testing.T, md pmetric.Metrics) {
+	m, ok := findMetric(md, "traces_service_graph_request_total")
+	require.True(t, ok)
+	dps := m.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		verifyAttr(t, attrs, "connection_type", string(store.MessagingSystem))
+		verifyAttr(t, attrs, connectionSystemLabel, "kafka")
+		assert.Equal(t, int64(1), dps.At(i).IntValue())
+	}
+
+	m, ok = findMetric(md, "traces_service_graph_request_messaging_system")
+	require.True(t, ok)
+	verifyUnit(t, secondsUnit, m.Unit())
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp := m.Histogram().DataPoints().At(0)
+	// The message was consumed 2 seconds after it was published
+	assert.Equal(t, uint64(1), dp.Count())
+	assert.InDelta(t, 2.0, dp.Sum(), 0.0001)
+	verifyAttr(t, dp.Attributes(), "client", "orders")
+	verifyAttr(t, dp.Attributes(), "server", "consumer")
+}
+
+func findMetric(md pmetric.Metrics, name string) (pmetric.Metric, bool) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				if ms.At(k).Name() == name {
+					return ms.At(k), true
+				}
+			}
+		}
+	}
+	return pmetric.Metric{}, false
+}
+
+// buildMessagingTrace builds a producer span and a consumer span in different traces
+// where the consumer span links to the producer span.
+func buildMessagingTrace() ptrace.Traces {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	traces := ptrace.NewTraces()
+
+	producerTraceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	producerSpanID := pcommon.SpanID([8]byte{1, 2, 3, 4, 4, 3, 2, 1})
+
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), "producer")
+	producerSpan := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	producerSpan.SetName("orders publish")
+	producerSpan.SetTraceID(producerTraceID)
+	producerSpan.SetSpanID(producerSpanID)
+	producerSpan.SetKind(ptrace.SpanKindProducer)
+	producerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	producerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tStart.Add(time.Second)))
+	producerSpan.Attributes().PutStr(string(semconv.MessagingSystemKey), "kafka")
+	producerSpan.Attributes().PutStr(string(semconv.MessagingDestinationNameKey), "orders")
+
+	resourceSpans = traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), "consumer")
+	consumerSpan := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	consumerSpan.SetName("orders process")
+	consumerSpan.SetTraceID(pcommon.TraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}))
+	consumerSpan.SetSpanID([8]byte{0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26})
+	consumerSpan.SetKind(ptrace.SpanKindConsumer)
+	consumerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart.Add(3 * time.Second)))
+	consumerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tStart.Add(4 * time.Second)))
+	consumerSpan.Attributes().PutStr(string(semconv.MessagingSystemKey), "kafka")
+	consumerSpan.Attributes().PutStr(string(semconv.MessagingDestinationNameKey), "orders")
+	link := consumerSpan.Links().AppendEmpty()
+	link.SetTraceID(producerTraceID)
+	link.SetSpanID(producerSpanID)
+
+	return traces
+}
+
+func buildDatabaseTrace() ptrace.Traces {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	traces := ptrace.NewTraces()
+
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), "some-client-service")
+	span := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("SELECT orders")
+	span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID([8]byte{1, 2, 3, 4, 4, 3, 2, 1})
+	span.SetKind(ptrace.SpanKindClient)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(tStart.Add(time.Second)))
+	span.Attributes().PutStr(dbNamespaceKey, "orders")
+	span.Attributes().PutStr(string(semconv.DBSystemKey), "postgresql")
+
+	return traces
+}
+
 func incompleteClientTraces() ptrace.Traces {
 	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
 	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
@@ -50,6 +50,15 @@ type Edge struct {
 
 	// VirtualNodeLabel is an optional label to be added to the spans
 	VirtualNodeLabel VirtualNodeLabel
+
+	// ConnectionSystem is the messaging or database system of the Edge, e.g. kafka or postgresql
+	ConnectionSystem string
+
+	// MessagingDestination is the destination of a messaging system Edge, e.g. a Kafka topic
+	MessagingDestination string
+
+	// ClientEndTime and ServerStartTime are used to compute the time spent in a messaging system
+	ClientEndTime, ServerStartTime pcommon.Timestamp
 }
 
 func newEdge(key Key, ttl time.Duration) *Edge {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/component"
@@ -17,6 +18,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/customottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/sketch"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
@@ -32,12 +34,25 @@ const (
 	// error of less than 5%.
 	// Ref: https://opentelemetry.io/docs/specs/otel/metrics/sdk/#base2-exponential-bucket-histogram-aggregation
 	defaultExponentialHistogramMaxSize = 160
+	// defaultSummaryRelativeAccuracy is the default relative accuracy
+	// of the quantiles reported by summary metrics.
+	defaultSummaryRelativeAccuracy = 0.01
+	// defaultDistinctCountPrecision is the default precision used by the
+	// HyperLogLog sketch for distinct count metrics. A precision of 14
+	// uses 16KiB of memory per data point with a standard error of ~0.8%.
+	defaultDistinctCountPrecision = 14
+	// DefaultSketchExpiration is the default duration after which the
+	// summary and distinct count sketches of a stream that is not updated
+	// anymore are removed.
+	DefaultSketchExpiration = 5 * time.Minute
 )
 
 var defaultHistogramBuckets = []float64{
 	2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000,
 }
 
+var defaultSummaryQuantiles = []float64{0.5, 0.9, 0.95, 0.99}
+
 // Regex for [key] selector after ExtractGrokPatterns
 var grokPatternKey = regexp.MustCompile(`ExtractGrokPatterns\([^)]*\)\s*\[[^\]]+\]`)
 
@@ -50,6 +65,11 @@ type Config struct {
 	Datapoints []MetricInfo `mapstructure:"datapoints"`
 	Logs       []MetricInfo `mapstructure:"logs"`
 	Profiles   []MetricInfo `mapstructure:"profiles"`
+	// SketchExpiration is the duration after which the summary and distinct
+	// count sketches of a stream that is not updated anymore are removed.
+	// The sketches are merged across the consume calls until then. A zero
+	// value keeps the sketches forever.
+	SketchExpiration time.Duration `mapstructure:"sketch_expiration"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -58,6 +78,9 @@ func (c *Config) Validate() error {
 	if len(c.Spans) == 0 && len(c.Datapoints) == 0 && len(c.Logs) == 0 && len(c.Profiles) == 0 {
 		return errors.New("no configuration provided, at least one should be specified")
 	}
+	if c.SketchExpiration < 0 {
+		return errors.New("sketch_expiration must not be negative")
+	}
 	var multiError error // collect all errors at once
 	if len(c.Spans) > 0 {
 		parser, err := ottlspan.NewParser(
@@ -165,6 +188,22 @@ type ExponentialHistogram struct {
 	Value   string `mapstructure:"value"`
 }
 
+// Summary produces a summary metric with quantiles calculated using a
+// streaming quantile sketch.
+type Summary struct {
+	Quantiles        []float64 `mapstructure:"quantiles"`
+	RelativeAccuracy float64   `mapstructure:"relative_accuracy"`
+	Count            string    `mapstructure:"count"`
+	Value            string    `mapstructure:"value"`
+}
+
+// DistinctCount produces a gauge metric with the approximate number of
+// distinct values, calculated using a HyperLogLog sketch.
+type DistinctCount struct {
+	Precision uint8  `mapstructure:"precision"`
+	Value     string `mapstructure:"value"`
+}
+
 type Sum struct {
 	Value string `mapstructure:"value"`
 }
@@ -192,6 +231,8 @@ type MetricInfo struct {
 	ExponentialHistogram configoptional.Optional[ExponentialHistogram] `mapstructure:"exponential_histogram"`
 	Sum                  configoptional.Optional[Sum]                  `mapstructure:"sum"`
 	Gauge                configoptional.Optional[Gauge]                `mapstructure:"gauge"`
+	Summary              configoptional.Optional[Summary]              `mapstructure:"summary"`
+	DistinctCount        configoptional.Optional[DistinctCount]        `mapstructure:"distinct_count"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -208,6 +249,19 @@ func (mi *MetricInfo) ensureDefaults() {
 			mi.ExponentialHistogram.Get().MaxSize = defaultExponentialHistogramMaxSize
 		}
 	}
+	if mi.Summary.HasValue() {
+		if len(mi.Summary.Get().Quantiles) == 0 {
+			mi.Summary.Get().Quantiles = defaultSummaryQuantiles
+		}
+		if mi.Summary.Get().RelativeAccuracy == 0 {
+			mi.Summary.Get().RelativeAccuracy = defaultSummaryRelativeAccuracy
+		}
+	}
+	if mi.DistinctCount.HasValue() {
+		if mi.DistinctCount.Get().Precision == 0 {
+			mi.DistinctCount.Get().Precision = defaultDistinctCountPrecision
+		}
+	}
 }
 
 func (mi *MetricInfo) validateAttributes() error {
@@ -255,6 +309,40 @@ func (mi *MetricInfo) validateHistogram() error {
 	return nil
 }
 
+func (mi *MetricInfo) validateSummary() error {
+	if mi.Summary.HasValue() {
+		s := mi.Summary.Get()
+		if len(s.Quantiles) == 0 {
+			return errors.New("summary quantiles missing")
+		}
+		for _, q := range s.Quantiles {
+			if q < 0 || q > 1 {
+				return fmt.Errorf("summary quantiles must be between 0 and 1, got %v", q)
+			}
+		}
+		if err := sketch.ValidateDDSketchRelativeAccuracy(s.RelativeAccuracy); err != nil {
+			return err
+		}
+		if s.Value == "" {
+			return errors.New("value OTTL statement is required")
+		}
+	}
+	return nil
+}
+
+func (mi *MetricInfo) validateDistinctCount() error {
+	if mi.DistinctCount.HasValue() {
+		dc := mi.DistinctCount.Get()
+		if err := sketch.ValidateHyperLogLogPrecision(dc.Precision); err != nil {
+			return err
+		}
+		if dc.Value == "" {
+			return errors.New("value must be defined for distinct count metrics")
+		}
+	}
+	return nil
+}
+
 func (mi *MetricInfo) validateSum() error {
 	if mi.Sum.HasValue() {
 		if mi.Sum.Get().Value == "" {
@@ -291,6 +379,12 @@ func validateMetricInfo[K any](mi MetricInfo, parser ottl.Parser[K]) error {
 	if err := mi.validateGauge(); err != nil {
 		return fmt.Errorf("gauge validation failed: %w", err)
 	}
+	if err := mi.validateSummary(); err != nil {
+		return fmt.Errorf("summary validation failed: %w", err)
+	}
+	if err := mi.validateDistinctCount(); err != nil {
+		return fmt.Errorf("distinct count validation failed: %w", err)
+	}
 
 	// Exactly one metric should be defined. Also, validate OTTL expressions,
 	// note that, here we only evaluate if statements are valid. Check for
@@ -340,6 +434,24 @@ func validateMetricInfo[K any](mi MetricInfo, parser ottl.Parser[K]) error {
 			}
 		}
 	}
+	if mi.Summary.HasValue() {
+		metricsDefinedCount++
+		sm := mi.Summary.Get()
+		if sm.Count != "" {
+			if _, err := parser.ParseValueExpression(sm.Count); err != nil {
+				return fmt.Errorf("failed to parse count OTTL expression for summary: %w", err)
+			}
+		}
+		if _, err := parser.ParseValueExpression(sm.Value); err != nil {
+			return fmt.Errorf("failed to parse value OTTL expression for summary: %w", err)
+		}
+	}
+	if mi.DistinctCount.HasValue() {
+		metricsDefinedCount++
+		if _, err := parser.ParseValueExpression(mi.DistinctCount.Get().Value); err != nil {
+			return fmt.Errorf("failed to parse value OTTL expression for distinct count: %w", err)
+		}
+	}
 	if metricsDefinedCount != 1 {
 		return fmt.Errorf("exactly one of the metrics must be defined, %d found", metricsDefinedCount)
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -91,6 +92,30 @@ func TestConfig(t *testing.T) {
 				fullErrorForSignal(t, "profiles", "sum validation failed"),
 			},
 		},
+		{
+			path: "invalid_summary",
+			errorMsgs: []string{
+				fullErrorForSignal(t, "spans", "summary validation failed: summary quantiles must be between 0 and 1"),
+				fullErrorForSignal(t, "datapoints", "summary validation failed: relative accuracy must be between 0 and 1"),
+				fullErrorForSignal(t, "logs", "summary validation failed: value OTTL statement is required"),
+				fullErrorForSignal(t, "profiles", "summary validation failed: value OTTL statement is required"),
+			},
+		},
+		{
+			path: "invalid_sketch_expiration",
+			errorMsgs: []string{
+				"sketch_expiration must not be negative",
+			},
+		},
+		{
+			path: "invalid_distinct_count",
+			errorMsgs: []string{
+				fullErrorForSignal(t, "spans", "distinct count validation failed: precision must be between 4 and 18"),
+				fullErrorForSignal(t, "datapoints", "distinct count validation failed: precision must be between 4 and 18"),
+				fullErrorForSignal(t, "logs", "distinct count validation failed: value must be defined"),
+				fullErrorForSignal(t, "profiles", "distinct count validation failed: value must be defined"),
+			},
+		},
 		{
 			path: "multiple_metric",
 			errorMsgs: []string{
@@ -127,6 +152,7 @@ func TestConfig(t *testing.T) {
 		{
 			path: "valid_full",
 			expected: &Config{
+				SketchExpiration: 10 * time.Minute,
 				Spans: []MetricInfo{
 					{
 						Name:                      "span.exp_histogram",
@@ -203,6 +229,26 @@ func TestConfig(t *testing.T) {
 							Value: "1",
 						}),
 					},
+					{
+						Name:        "log.summary",
+						Description: "Summary",
+						Unit:        "ms",
+						Summary: configoptional.Some(Summary{
+							Quantiles:        []float64{0.5, 0.99},
+							RelativeAccuracy: 0.02,
+							Count:            "1",
+							Value:            `attributes["duration"]`,
+						}),
+					},
+					{
+						Name:        "log.distinct_count",
+						Description: "Distinct count",
+						Unit:        "{user}",
+						DistinctCount: configoptional.Some(DistinctCount{
+							Precision: defaultDistinctCountPrecision,
+							Value:     `attributes["user.id"]`,
+						}),
+					},
 				},
 				Profiles: []MetricInfo{
 					{
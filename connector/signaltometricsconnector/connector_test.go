@@ -108,6 +108,8 @@ func TestConnectorWithLogs(t *testing.T) {
 		"exponential_histograms",
 		"metric_identity",
 		"gauge",
+		"summary",
+		"distinct_count",
 	}
 
 	ctx, cancel := context.WithCancel(t.Context())
@@ -135,6 +137,57 @@ func TestConnectorWithLogs(t *testing.T) {
 	}
 }
 
+func TestConnectorMergesSketchesAcrossCalls(t *testing.T) {
+	logTestDataDir := filepath.Join(testDataDir, "logs")
+	inputLogs, err := golden.ReadLogs(filepath.Join(logTestDataDir, "logs.yaml"))
+	require.NoError(t, err)
+
+	next := &consumertest.MetricsSink{}
+	factory, settings, cfg := setupConnector(t, filepath.Join(logTestDataDir, "summary"))
+	cfg.(*config.Config).Logs = append(cfg.(*config.Config).Logs, config.MetricInfo{
+		Name: "log.foo.distinct_count",
+		DistinctCount: configoptional.Some(config.DistinctCount{
+			Precision: 14,
+			Value:     `attributes["log.foo"]`,
+		}),
+	})
+	connector, err := factory.CreateLogsToMetrics(t.Context(), settings, cfg, next)
+	require.NoError(t, err)
+
+	require.NoError(t, connector.ConsumeLogs(t.Context(), inputLogs))
+	require.NoError(t, connector.ConsumeLogs(t.Context(), inputLogs))
+	require.Len(t, next.AllMetrics(), 2)
+
+	// The sketches of the second call are merged with the ones of the first call
+	first := findMetric(t, next.AllMetrics()[0], "log.duration.min_max").Summary().DataPoints().At(0)
+	second := findMetric(t, next.AllMetrics()[1], "log.duration.min_max").Summary().DataPoints().At(0)
+	assert.Equal(t, 2*first.Count(), second.Count())
+	assert.Equal(t, 2*first.Sum(), second.Sum())
+	assert.Equal(t, first.StartTimestamp(), second.StartTimestamp())
+
+	firstDistinct := findMetric(t, next.AllMetrics()[0], "log.foo.distinct_count").Gauge().DataPoints().At(0)
+	secondDistinct := findMetric(t, next.AllMetrics()[1], "log.foo.distinct_count").Gauge().DataPoints().At(0)
+	assert.Equal(t, firstDistinct.IntValue(), secondDistinct.IntValue())
+	assert.Equal(t, firstDistinct.StartTimestamp(), secondDistinct.StartTimestamp())
+}
+
+func findMetric(t *testing.T, md pmetric.Metrics, name string) pmetric.Metric {
+	t.Helper()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if metrics.At(k).Name() == name {
+					return metrics.At(k)
+				}
+			}
+		}
+	}
+	require.Failf(t, "metric not found", "metric %s not found", name)
+	return pmetric.NewMetric()
+}
+
 func TestConnectorWithProfiles(t *testing.T) {
 	testCases := []string{
 		"sum",
@@ -361,5 +414,6 @@ func assertAggregatedMetrics(t *testing.T, expected, actual pmetric.Metrics) {
 		pmetrictest.IgnoreMetricDataPointsOrder(),
 		pmetrictest.IgnoreMetricsOrder(),
 		pmetrictest.IgnoreTimestamp(),
+		pmetrictest.IgnoreStartTimestamp(),
 	))
 }
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/customottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/model"
@@ -35,7 +36,9 @@ func NewFactory() connector.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &config.Config{}
+	return &config.Config{
+		SketchExpiration: config.DefaultSketchExpiration,
+	}
 }
 
 func createTracesToMetrics(
@@ -60,7 +63,8 @@ func createTracesToMetrics(
 	}
 
 	return &signalToMetrics{
-		logger: set.Logger,
+		logger:   set.Logger,
+		sketches: aggregator.NewSketchStore(c.SketchExpiration),
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
@@ -91,7 +95,8 @@ func createMetricsToMetrics(
 	}
 
 	return &signalToMetrics{
-		logger: set.Logger,
+		logger:   set.Logger,
+		sketches: aggregator.NewSketchStore(c.SketchExpiration),
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
@@ -122,7 +127,8 @@ func createLogsToMetrics(
 	}
 
 	return &signalToMetrics{
-		logger: set.Logger,
+		logger:   set.Logger,
+		sketches: aggregator.NewSketchStore(c.SketchExpiration),
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
@@ -153,7 +159,8 @@ func createProfilesToMetrics(
 	}
 
 	return &signalToMetrics{
-		logger: set.Logger,
+		logger:   set.Logger,
+		sketches: aggregator.NewSketchStore(c.SketchExpiration),
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
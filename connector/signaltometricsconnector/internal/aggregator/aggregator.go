@@ -29,17 +29,24 @@ type Aggregator[K any] struct {
 	valueCounts map[model.MetricKey]map[[16]byte]map[[16]byte]*valueCountDP
 	sums        map[model.MetricKey]map[[16]byte]map[[16]byte]*sumDP
 	gauges      map[model.MetricKey]map[[16]byte]map[[16]byte]*gaugeDP
-	timestamp   time.Time
+	distincts   map[model.MetricKey]map[[16]byte]map[[16]byte]*distinctCountDP
+	// sketches holds the summary and distinct count sketches across
+	// aggregator instances.
+	sketches  *SketchStore
+	timestamp time.Time
 }
 
-// NewAggregator creates a new instance of aggregator.
-func NewAggregator[K any](metrics pmetric.Metrics) *Aggregator[K] {
+// NewAggregator creates a new instance of aggregator. The summary and
+// distinct count sketches are merged with the ones stored in sketches.
+func NewAggregator[K any](metrics pmetric.Metrics, sketches *SketchStore) *Aggregator[K] {
 	return &Aggregator[K]{
 		result:      metrics,
 		smLookup:    make(map[[16]byte]pmetric.ScopeMetrics),
 		valueCounts: make(map[model.MetricKey]map[[16]byte]map[[16]byte]*valueCountDP),
 		sums:        make(map[model.MetricKey]map[[16]byte]map[[16]byte]*sumDP),
 		gauges:      make(map[model.MetricKey]map[[16]byte]map[[16]byte]*gaugeDP),
+		distincts:   make(map[model.MetricKey]map[[16]byte]map[[16]byte]*distinctCountDP),
+		sketches:    sketches,
 		timestamp:   time.Now(),
 	}
 }
@@ -74,6 +81,17 @@ func (a *Aggregator[K]) Aggregate(
 			return err
 		}
 		return a.aggregateValueCount(md, resAttrs, srcAttrs, val, count)
+	case pmetric.MetricTypeSummary:
+		val, count, err := getValueCount(
+			ctx, tCtx,
+			md.Summary.Value,
+			md.Summary.Count,
+			defaultCount,
+		)
+		if err != nil {
+			return err
+		}
+		return a.aggregateValueCount(md, resAttrs, srcAttrs, val, count)
 	case pmetric.MetricTypeSum:
 		raw, err := md.Sum.Value.Eval(ctx, tCtx)
 		if err != nil {
@@ -91,6 +109,9 @@ func (a *Aggregator[K]) Aggregate(
 			)
 		}
 	case pmetric.MetricTypeGauge:
+		if md.DistinctCount != nil {
+			return a.aggregateDistinctCount(ctx, tCtx, md, resAttrs, srcAttrs)
+		}
 		raw, err := md.Gauge.Value.Eval(ctx, tCtx)
 		if err != nil {
 			if strings.Contains(err.Error(), "key not found in map") {
@@ -126,6 +147,7 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 			var (
 				destExpHist      pmetric.ExponentialHistogram
 				destExplicitHist pmetric.Histogram
+				destSummary      pmetric.Summary
 			)
 			switch md.Key.Type {
 			case pmetric.MetricTypeExponentialHistogram:
@@ -144,12 +166,24 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 				destExplicitHist = destMetric.SetEmptyHistogram()
 				destExplicitHist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 				destExplicitHist.DataPoints().EnsureCapacity(len(dpMap))
+			case pmetric.MetricTypeSummary:
+				destMetric := metrics.AppendEmpty()
+				destMetric.SetName(md.Key.Name)
+				destMetric.SetUnit(md.Key.Unit)
+				destMetric.SetDescription(md.Key.Description)
+				destSummary = destMetric.SetEmptySummary()
+				destSummary.DataPoints().EnsureCapacity(len(dpMap))
 			}
-			for _, dp := range dpMap {
+			for attrID, dp := range dpMap {
+				if dp.summaryDP != nil {
+					key := streamKey{metric: md.Key, resID: resID, attrID: attrID}
+					dp.summaryDP.start = a.sketches.mergeSummary(key, dp.summaryDP.data, a.timestamp)
+				}
 				dp.Copy(
 					a.timestamp,
 					destExpHist,
 					destExplicitHist,
+					destSummary,
 				)
 			}
 		}
@@ -184,12 +218,30 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 				dp.Copy(a.timestamp, destGauge.DataPoints().AppendEmpty())
 			}
 		}
+		for resID, dpMap := range a.distincts[md.Key] {
+			if md.DistinctCount == nil {
+				continue
+			}
+			metrics := a.smLookup[resID].Metrics()
+			destMetric := metrics.AppendEmpty()
+			destMetric.SetName(md.Key.Name)
+			destMetric.SetUnit(md.Key.Unit)
+			destMetric.SetDescription(md.Key.Description)
+			destGauge := destMetric.SetEmptyGauge()
+			destGauge.DataPoints().EnsureCapacity(len(dpMap))
+			for attrID, dp := range dpMap {
+				key := streamKey{metric: md.Key, resID: resID, attrID: attrID}
+				dp.start = a.sketches.mergeDistinctCount(key, dp.data, a.timestamp)
+				dp.Copy(a.timestamp, destGauge.DataPoints().AppendEmpty())
+			}
+		}
 		// If there are two metric defined with the same key required by metricKey
 		// then they will be aggregated within the same metric and produced
 		// together. Deleting the key ensures this while preventing duplicates.
 		delete(a.valueCounts, md.Key)
 		delete(a.sums, md.Key)
 		delete(a.gauges, md.Key)
+		delete(a.distincts, md.Key)
 	}
 }
 
@@ -253,6 +305,42 @@ func (a *Aggregator[K]) aggregateGauge(
 	return nil
 }
 
+func (a *Aggregator[K]) aggregateDistinctCount(
+	ctx context.Context,
+	tCtx K,
+	md model.MetricDef[K],
+	resAttrs, srcAttrs pcommon.Map,
+) error {
+	raw, err := md.DistinctCount.Value.Eval(ctx, tCtx)
+	if err != nil {
+		return fmt.Errorf("failed to execute OTTL value for distinct count: %w", err)
+	}
+	switch raw.(type) {
+	case nil:
+		// Missing values are not counted
+		return nil
+	case string, []byte, bool, int64, float64:
+	default:
+		return fmt.Errorf(
+			"failed to parse distinct count OTTL value of type %T into string, bytes, bool, int64 or float64: %v",
+			raw, raw,
+		)
+	}
+	resID := a.getResourceID(resAttrs)
+	attrID := pdatautil.MapHash(srcAttrs)
+	if _, ok := a.distincts[md.Key]; !ok {
+		a.distincts[md.Key] = make(map[[16]byte]map[[16]byte]*distinctCountDP)
+	}
+	if _, ok := a.distincts[md.Key][resID]; !ok {
+		a.distincts[md.Key][resID] = make(map[[16]byte]*distinctCountDP)
+	}
+	if _, ok := a.distincts[md.Key][resID][attrID]; !ok {
+		a.distincts[md.Key][resID][attrID] = newDistinctCountDP(srcAttrs, md.DistinctCount.Precision)
+	}
+	a.distincts[md.Key][resID][attrID].Aggregate(raw)
+	return nil
+}
+
 func (a *Aggregator[K]) aggregateValueCount(
 	md model.MetricDef[K],
 	resAttrs, srcAttrs pcommon.Map,
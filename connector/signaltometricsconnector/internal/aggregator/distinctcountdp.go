@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/sketch"
+)
+
+// distinctCountDP is a data point for distinct count metrics backed by
+// a HyperLogLog sketch. The sketch is merged with the sketch stored for
+// the stream before being copied, the estimated distinct count since the
+// stream start is produced as an int gauge.
+type distinctCountDP struct {
+	attrs pcommon.Map
+	data  *sketch.HyperLogLog
+	start time.Time
+	buf   []byte
+}
+
+func newDistinctCountDP(attrs pcommon.Map, precision uint8) *distinctCountDP {
+	return &distinctCountDP{
+		attrs: attrs,
+		data:  sketch.NewHyperLogLog(precision),
+	}
+}
+
+// Aggregate records the value in the sketch. Numeric values are inserted
+// using their binary representation.
+func (dp *distinctCountDP) Aggregate(v any) {
+	dp.buf = dp.buf[:0]
+	switch v := v.(type) {
+	case string:
+		dp.buf = append(dp.buf, v...)
+	case []byte:
+		dp.buf = append(dp.buf, v...)
+	case int64:
+		dp.buf = binary.LittleEndian.AppendUint64(dp.buf, uint64(v))
+	case float64:
+		dp.buf = binary.LittleEndian.AppendUint64(dp.buf, math.Float64bits(v))
+	case bool:
+		if v {
+			dp.buf = append(dp.buf, 1)
+		} else {
+			dp.buf = append(dp.buf, 0)
+		}
+	default:
+		panic("unexpected usage of distinct count datapoint, only string, bytes, bool, double or int value expected")
+	}
+	dp.data.Insert(dp.buf)
+}
+
+// Copy copies the distinct count estimate to the destination number data point.
+func (dp *distinctCountDP) Copy(
+	timestamp time.Time,
+	dest pmetric.NumberDataPoint,
+) {
+	dp.attrs.CopyTo(dest.Attributes())
+	dest.SetIntValue(int64(dp.data.Estimate()))
+	dest.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.start))
+	dest.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
+
+import (
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/model"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/sketch"
+)
+
+// streamKey identifies a data point stream produced by the connector.
+type streamKey struct {
+	metric model.MetricKey
+	resID  [16]byte
+	attrID [16]byte
+}
+
+type storedSketch[S any] struct {
+	data      S
+	start     time.Time
+	updatedAt time.Time
+}
+
+// SketchStore holds the summary and distinct count sketches of each stream
+// across the consume calls. The sketch aggregated for a call is merged with
+// the stored one, so that the produced quantiles and distinct counts cover
+// all the values recorded since the stream was first seen. Streams that were
+// not updated for the expiration are removed, a zero expiration keeps the
+// streams forever.
+//
+// A stored sketch is never modified once stored: each call merges the stored
+// sketch into its own one and stores the result, so that the stored sketches
+// can be read without holding the lock.
+type SketchStore struct {
+	mu         sync.Mutex
+	expiration time.Duration
+	lastSweep  time.Time
+	summaries  map[streamKey]*storedSketch[*sketch.DDSketch]
+	distincts  map[streamKey]*storedSketch[*sketch.HyperLogLog]
+}
+
+// NewSketchStore creates a new sketch store removing the streams not
+// updated for the given expiration.
+func NewSketchStore(expiration time.Duration) *SketchStore {
+	return &SketchStore{
+		expiration: expiration,
+		summaries:  make(map[streamKey]*storedSketch[*sketch.DDSketch]),
+		distincts:  make(map[streamKey]*storedSketch[*sketch.HyperLogLog]),
+	}
+}
+
+// mergeSummary merges the stored sketch of the stream into the given one,
+// stores the result and returns the start time of the stream.
+func (s *SketchStore) mergeSummary(key streamKey, data *sketch.DDSketch, now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	return mergeStored(s.summaries, key, data, (*sketch.DDSketch).Merge, now)
+}
+
+// mergeDistinctCount merges the stored sketch of the stream into the given
+// one, stores the result and returns the start time of the stream.
+func (s *SketchStore) mergeDistinctCount(key streamKey, data *sketch.HyperLogLog, now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	return mergeStored(s.distincts, key, data, (*sketch.HyperLogLog).Merge, now)
+}
+
+func mergeStored[S any](
+	stored map[streamKey]*storedSketch[S],
+	key streamKey,
+	data S,
+	merge func(S, S) error,
+	now time.Time,
+) time.Time {
+	start := now
+	if prev, ok := stored[key]; ok {
+		// The sketches of a stream are created with the parameters of the
+		// same metric definition, the merge cannot fail.
+		if err := merge(data, prev.data); err == nil {
+			start = prev.start
+		}
+	}
+	stored[key] = &storedSketch[S]{data: data, start: start, updatedAt: now}
+	return start
+}
+
+// sweep removes the expired streams, at most once per expiration.
+func (s *SketchStore) sweep(now time.Time) {
+	if s.expiration <= 0 || now.Sub(s.lastSweep) < s.expiration {
+		return
+	}
+	s.lastSweep = now
+	for key, stored := range s.summaries {
+		if now.Sub(stored.updatedAt) > s.expiration {
+			delete(s.summaries, key)
+		}
+	}
+	for key, stored := range s.distincts {
+		if now.Sub(stored.updatedAt) > s.expiration {
+			delete(s.distincts, key)
+		}
+	}
+}
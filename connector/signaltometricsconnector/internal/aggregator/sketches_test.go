@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/model"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/sketch"
+)
+
+func TestSketchStoreMergeSummary(t *testing.T) {
+	store := NewSketchStore(time.Minute)
+	key := streamKey{metric: model.MetricKey{Name: "summary", Type: pmetric.MetricTypeSummary}}
+	start := time.Unix(1000, 0)
+
+	first := sketch.NewDDSketch(0.01)
+	first.Add(1, 2)
+	assert.Equal(t, start, store.mergeSummary(key, first, start))
+
+	second := sketch.NewDDSketch(0.01)
+	second.Add(3, 1)
+	assert.Equal(t, start, store.mergeSummary(key, second, start.Add(30*time.Second)))
+	assert.Equal(t, uint64(3), second.Count())
+	assert.Equal(t, 5.0, second.Sum())
+	// The stored sketches are not modified
+	assert.Equal(t, uint64(2), first.Count())
+}
+
+func TestSketchStoreMergeDistinctCount(t *testing.T) {
+	store := NewSketchStore(0)
+	key := streamKey{metric: model.MetricKey{Name: "distinct", Type: pmetric.MetricTypeGauge}}
+	start := time.Unix(1000, 0)
+
+	first := sketch.NewHyperLogLog(14)
+	first.Insert([]byte("a"))
+	first.Insert([]byte("b"))
+	assert.Equal(t, start, store.mergeDistinctCount(key, first, start))
+
+	// Without expiration, the streams are kept forever
+	second := sketch.NewHyperLogLog(14)
+	second.Insert([]byte("b"))
+	second.Insert([]byte("c"))
+	assert.Equal(t, start, store.mergeDistinctCount(key, second, start.Add(24*time.Hour)))
+	assert.InDelta(t, 3, second.Estimate(), 0.5)
+}
+
+func TestSketchStoreExpiration(t *testing.T) {
+	store := NewSketchStore(time.Minute)
+	expired := streamKey{metric: model.MetricKey{Name: "expired", Type: pmetric.MetricTypeSummary}}
+	active := streamKey{metric: model.MetricKey{Name: "active", Type: pmetric.MetricTypeSummary}}
+	start := time.Unix(1000, 0)
+
+	store.mergeSummary(expired, sketch.NewDDSketch(0.01), start)
+	store.mergeSummary(active, sketch.NewDDSketch(0.01), start)
+	store.mergeSummary(active, sketch.NewDDSketch(0.01), start.Add(50*time.Second))
+	assert.Len(t, store.summaries, 2)
+
+	// The expired stream starts again, the active one is kept
+	now := start.Add(100 * time.Second)
+	assert.Equal(t, now, store.mergeSummary(expired, sketch.NewDDSketch(0.01), now))
+	assert.Equal(t, start, store.mergeSummary(active, sketch.NewDDSketch(0.01), now))
+}
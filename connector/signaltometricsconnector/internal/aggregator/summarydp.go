@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/sketch"
+)
+
+// summaryDP is a data point for summary metrics backed by a DDSketch. The
+// sketch is merged with the sketch stored for the stream before being copied,
+// so that the summary covers all the values since the stream start.
+type summaryDP struct {
+	attrs     pcommon.Map
+	quantiles []float64
+	data      *sketch.DDSketch
+	start     time.Time
+}
+
+func newSummaryDP(attrs pcommon.Map, quantiles []float64, relativeAccuracy float64) *summaryDP {
+	return &summaryDP{
+		attrs:     attrs,
+		quantiles: quantiles,
+		data:      sketch.NewDDSketch(relativeAccuracy),
+	}
+}
+
+func (dp *summaryDP) Aggregate(value float64, count int64) {
+	dp.data.Add(value, uint64(count))
+}
+
+func (dp *summaryDP) Copy(
+	timestamp time.Time,
+	dest pmetric.SummaryDataPoint,
+) {
+	dp.attrs.CopyTo(dest.Attributes())
+	dest.SetCount(dp.data.Count())
+	dest.SetSum(dp.data.Sum())
+	dest.QuantileValues().EnsureCapacity(len(dp.quantiles))
+	if dp.data.Count() > 0 {
+		for _, q := range dp.quantiles {
+			qv := dest.QuantileValues().AppendEmpty()
+			qv.SetQuantile(q)
+			qv.SetValue(dp.data.Quantile(q))
+		}
+	}
+	dest.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.start))
+	dest.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+}
@@ -16,6 +16,7 @@ import (
 type valueCountDP struct {
 	expHistogramDP      *exponentialHistogramDP
 	explicitHistogramDP *explicitHistogramDP
+	summaryDP           *summaryDP
 }
 
 func newValueCountDP[K any](
@@ -33,6 +34,11 @@ func newValueCountDP[K any](
 			attrs, md.ExplicitHistogram.Buckets,
 		)
 	}
+	if md.Key.Type == pmetric.MetricTypeSummary {
+		dp.summaryDP = newSummaryDP(
+			attrs, md.Summary.Quantiles, md.Summary.RelativeAccuracy,
+		)
+	}
 	return &dp
 }
 
@@ -43,12 +49,16 @@ func (dp *valueCountDP) Aggregate(value float64, count int64) {
 	if dp.explicitHistogramDP != nil {
 		dp.explicitHistogramDP.Aggregate(value, count)
 	}
+	if dp.summaryDP != nil {
+		dp.summaryDP.Aggregate(value, count)
+	}
 }
 
 func (dp *valueCountDP) Copy(
 	timestamp time.Time,
 	destExpHist pmetric.ExponentialHistogram,
 	destExplicitHist pmetric.Histogram,
+	destSummary pmetric.Summary,
 ) {
 	if dp.expHistogramDP != nil {
 		dp.expHistogramDP.Copy(timestamp, destExpHist.DataPoints().AppendEmpty())
@@ -56,4 +66,7 @@ func (dp *valueCountDP) Copy(
 	if dp.explicitHistogramDP != nil {
 		dp.explicitHistogramDP.Copy(timestamp, destExplicitHist.DataPoints().AppendEmpty())
 	}
+	if dp.summaryDP != nil {
+		dp.summaryDP.Copy(timestamp, destSummary.DataPoints().AppendEmpty())
+	}
 }
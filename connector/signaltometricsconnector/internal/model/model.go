@@ -86,6 +86,59 @@ func (h *ExponentialHistogram[K]) fromConfig(
 	return nil
 }
 
+type Summary[K any] struct {
+	Quantiles        []float64
+	RelativeAccuracy float64
+	Count            *ottl.ValueExpression[K]
+	Value            *ottl.ValueExpression[K]
+}
+
+func (s *Summary[K]) fromConfig(
+	mi *config.Summary,
+	parser ottl.Parser[K],
+) error {
+	if mi == nil {
+		return nil
+	}
+
+	var err error
+	s.Quantiles = mi.Quantiles
+	s.RelativeAccuracy = mi.RelativeAccuracy
+	if mi.Count != "" {
+		s.Count, err = parser.ParseValueExpression(mi.Count)
+		if err != nil {
+			return fmt.Errorf("failed to parse count OTTL expression for summary: %w", err)
+		}
+	}
+	s.Value, err = parser.ParseValueExpression(mi.Value)
+	if err != nil {
+		return fmt.Errorf("failed to parse value OTTL expression for summary: %w", err)
+	}
+	return nil
+}
+
+type DistinctCount[K any] struct {
+	Precision uint8
+	Value     *ottl.ValueExpression[K]
+}
+
+func (dc *DistinctCount[K]) fromConfig(
+	mi *config.DistinctCount,
+	parser ottl.Parser[K],
+) error {
+	if mi == nil {
+		return nil
+	}
+
+	var err error
+	dc.Precision = mi.Precision
+	dc.Value, err = parser.ParseValueExpression(mi.Value)
+	if err != nil {
+		return fmt.Errorf("failed to parse value OTTL expression for distinct count: %w", err)
+	}
+	return nil
+}
+
 type Sum[K any] struct {
 	Value *ottl.ValueExpression[K]
 }
@@ -135,6 +188,10 @@ type MetricDef[K any] struct {
 	ExplicitHistogram         *ExplicitHistogram[K]
 	Sum                       *Sum[K]
 	Gauge                     *Gauge[K]
+	Summary                   *Summary[K]
+	// DistinctCount is produced as a gauge metric, i.e. the key type
+	// of the metric definition is gauge if DistinctCount is defined.
+	DistinctCount *DistinctCount[K]
 }
 
 func (md *MetricDef[K]) FromMetricInfo(
@@ -195,6 +252,20 @@ func (md *MetricDef[K]) FromMetricInfo(
 			return fmt.Errorf("failed to parse gauge config: %w", err)
 		}
 	}
+	if mi.Summary.HasValue() {
+		md.Key.Type = pmetric.MetricTypeSummary
+		md.Summary = new(Summary[K])
+		if err := md.Summary.fromConfig(mi.Summary.Get(), parser); err != nil {
+			return fmt.Errorf("failed to parse summary config: %w", err)
+		}
+	}
+	if mi.DistinctCount.HasValue() {
+		md.Key.Type = pmetric.MetricTypeGauge
+		md.DistinctCount = new(DistinctCount[K])
+		if err := md.DistinctCount.fromConfig(mi.DistinctCount.Get(), parser); err != nil {
+			return fmt.Errorf("failed to parse distinct count config: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sketch // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/sketch"
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// DDSketch is a quantile sketch with relative-error guarantees as
+// described in https://arxiv.org/abs/1908.10693. Values are mapped to
+// logarithmically sized buckets so that every quantile returned by the
+// sketch is within the configured relative accuracy of the true value.
+// Sketches with the same relative accuracy can be merged losslessly.
+type DDSketch struct {
+	relativeAccuracy float64
+	gamma            float64
+	multiplier       float64
+	minIndexable     float64
+
+	positive  map[int]uint64
+	negative  map[int]uint64
+	zeroCount uint64
+
+	count uint64
+	sum   float64
+	min   float64
+	max   float64
+}
+
+// ValidateDDSketchRelativeAccuracy returns an error if the relative
+// accuracy is not in the range (0, 1).
+func ValidateDDSketchRelativeAccuracy(relativeAccuracy float64) error {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		return fmt.Errorf("relative accuracy must be between 0 and 1 (exclusive), got %v", relativeAccuracy)
+	}
+	return nil
+}
+
+// NewDDSketch creates a new DDSketch with the given relative accuracy,
+// which must be validated with ValidateDDSketchRelativeAccuracy.
+func NewDDSketch(relativeAccuracy float64) *DDSketch {
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	multiplier := 1 / math.Log(gamma)
+	return &DDSketch{
+		relativeAccuracy: relativeAccuracy,
+		gamma:            gamma,
+		multiplier:       multiplier,
+		// Values smaller than the smallest normal float64 (scaled by
+		// gamma to keep the index stable) are counted as zeros.
+		minIndexable: math.Max(
+			math.Exp(float64(math.MinInt32+1)/multiplier),
+			0x1p-1022*gamma,
+		),
+		positive: make(map[int]uint64),
+		negative: make(map[int]uint64),
+		min:      math.Inf(1),
+		max:      math.Inf(-1),
+	}
+}
+
+// RelativeAccuracy returns the relative accuracy the sketch was created with.
+func (s *DDSketch) RelativeAccuracy() float64 {
+	return s.relativeAccuracy
+}
+
+// Add records the value in the sketch count number of times.
+func (s *DDSketch) Add(value float64, count uint64) {
+	if count == 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+	switch {
+	case value > s.minIndexable:
+		s.positive[s.index(value)] += count
+	case value < -s.minIndexable:
+		s.negative[s.index(-value)] += count
+	default:
+		s.zeroCount += count
+	}
+	s.count += count
+	s.sum += value * float64(count)
+	s.min = math.Min(s.min, value)
+	s.max = math.Max(s.max, value)
+}
+
+// Merge merges other into the sketch. Both sketches must be created
+// with the same relative accuracy.
+func (s *DDSketch) Merge(other *DDSketch) error {
+	if other == nil || other.count == 0 {
+		return nil
+	}
+	if s.relativeAccuracy != other.relativeAccuracy {
+		return errors.New("cannot merge DDSketch with different relative accuracy")
+	}
+	for i, c := range other.positive {
+		s.positive[i] += c
+	}
+	for i, c := range other.negative {
+		s.negative[i] += c
+	}
+	s.zeroCount += other.zeroCount
+	s.count += other.count
+	s.sum += other.sum
+	s.min = math.Min(s.min, other.min)
+	s.max = math.Max(s.max, other.max)
+	return nil
+}
+
+// Count returns the total number of values recorded in the sketch.
+func (s *DDSketch) Count() uint64 {
+	return s.count
+}
+
+// Sum returns the sum of all values recorded in the sketch.
+func (s *DDSketch) Sum() float64 {
+	return s.sum
+}
+
+// Quantile returns the approximate value at the given quantile, which
+// must be in the range [0, 1]. Returns NaN if the sketch is empty or
+// the quantile is out of range.
+func (s *DDSketch) Quantile(q float64) float64 {
+	if s.count == 0 || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	// Exact values are tracked for the extremes.
+	if q == 0 {
+		return s.min
+	}
+	if q == 1 {
+		return s.max
+	}
+
+	rank := uint64(q * float64(s.count-1))
+	var cumulative uint64
+	// Negative values are traversed from the largest magnitude, i.e.
+	// the smallest value, to the smallest magnitude.
+	negIdx := slices.Sorted(maps.Keys(s.negative))
+	for i := len(negIdx) - 1; i >= 0; i-- {
+		cumulative += s.negative[negIdx[i]]
+		if cumulative > rank {
+			return s.clamp(-s.value(negIdx[i]))
+		}
+	}
+	cumulative += s.zeroCount
+	if cumulative > rank {
+		return s.clamp(0)
+	}
+	for _, idx := range slices.Sorted(maps.Keys(s.positive)) {
+		cumulative += s.positive[idx]
+		if cumulative > rank {
+			return s.clamp(s.value(idx))
+		}
+	}
+	return s.max
+}
+
+// index returns the index of the bucket the given positive value
+// belongs to. Bucket i covers the range (gamma^(i-1), gamma^i].
+func (s *DDSketch) index(value float64) int {
+	return int(math.Ceil(math.Log(value) * s.multiplier))
+}
+
+// value returns the representative value of the bucket with the given
+// index which is within the relative accuracy of every value in the
+// bucket.
+func (s *DDSketch) value(index int) float64 {
+	return 2 * math.Pow(s.gamma, float64(index)) / (1 + s.gamma)
+}
+
+// clamp bounds the value to the exact minimum and maximum recorded.
+func (s *DDSketch) clamp(v float64) float64 {
+	return math.Max(s.min, math.Min(s.max, v))
+}
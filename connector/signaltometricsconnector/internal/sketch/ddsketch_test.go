@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sketch
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDDSketch(t *testing.T) {
+	for _, ra := range []float64{0, -0.1, 1, 1.5} {
+		assert.Error(t, ValidateDDSketchRelativeAccuracy(ra))
+	}
+	require.NoError(t, ValidateDDSketchRelativeAccuracy(0.01))
+	s := NewDDSketch(0.01)
+	assert.Equal(t, 0.01, s.RelativeAccuracy())
+	assert.True(t, math.IsNaN(s.Quantile(0.5)))
+}
+
+func TestDDSketchQuantile(t *testing.T) {
+	const relativeAccuracy = 0.01
+	s := NewDDSketch(relativeAccuracy)
+	// Record values -100..1000 including zero
+	for i := -100; i <= 1000; i++ {
+		s.Add(float64(i), 1)
+	}
+	s.Add(math.NaN(), 1)
+	s.Add(math.Inf(1), 1)
+	s.Add(1, 0)
+
+	assert.Equal(t, uint64(1101), s.Count())
+	assert.InDelta(t, 495_450, s.Sum(), 1e-9)
+	assert.Equal(t, -100.0, s.Quantile(0))
+	assert.Equal(t, 1000.0, s.Quantile(1))
+	assert.True(t, math.IsNaN(s.Quantile(1.1)))
+	for _, tc := range []struct {
+		q        float64
+		expected float64
+	}{
+		{q: 0.01, expected: -89},
+		{q: 0.5, expected: 450},
+		{q: 0.9, expected: 890},
+		{q: 0.99, expected: 989},
+	} {
+		assert.InEpsilon(t, tc.expected, s.Quantile(tc.q), relativeAccuracy, "quantile %v", tc.q)
+	}
+}
+
+func TestDDSketchWeighted(t *testing.T) {
+	s := NewDDSketch(0.01)
+	s.Add(10, 99)
+	s.Add(1000, 1)
+	s.Add(0, 0)
+
+	assert.Equal(t, uint64(100), s.Count())
+	assert.InEpsilon(t, 10, s.Quantile(0.5), 0.01)
+	assert.InEpsilon(t, 10, s.Quantile(0.98), 0.01)
+	assert.Equal(t, 1000.0, s.Quantile(1))
+}
+
+func TestDDSketchMerge(t *testing.T) {
+	a := NewDDSketch(0.02)
+	b := NewDDSketch(0.02)
+	all := NewDDSketch(0.02)
+	for i := -50; i < 500; i++ {
+		v := float64(i) * 1.5
+		all.Add(v, 2)
+		if i%3 == 0 {
+			a.Add(v, 2)
+		} else {
+			b.Add(v, 2)
+		}
+	}
+	require.NoError(t, a.Merge(b))
+	assert.Equal(t, all.Count(), a.Count())
+	assert.InDelta(t, all.Sum(), a.Sum(), 1e-9)
+	for _, q := range []float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 1} {
+		assert.Equal(t, all.Quantile(q), a.Quantile(q), "quantile %v", q)
+	}
+
+	c := NewDDSketch(0.01)
+	c.Add(1, 1)
+	assert.Error(t, a.Merge(c))
+	assert.NoError(t, a.Merge(nil))
+}
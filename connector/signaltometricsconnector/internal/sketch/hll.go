@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sketch // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/sketch"
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+const (
+	// MinHLLPrecision is the minimum supported precision for HyperLogLog.
+	MinHLLPrecision = 4
+	// MaxHLLPrecision is the maximum supported precision for HyperLogLog.
+	MaxHLLPrecision = 18
+)
+
+// HyperLogLog is an approximate distinct counter. Sketches with the same
+// precision can be merged losslessly, i.e. the estimate of the merged
+// sketch is the same as if all values were inserted into a single sketch.
+//
+// Values are hashed with a stable, unseeded hash function so that
+// sketches created by different processes remain mergeable.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// ValidateHyperLogLogPrecision returns an error if the precision is not
+// between MinHLLPrecision and MaxHLLPrecision.
+func ValidateHyperLogLogPrecision(precision uint8) error {
+	if precision < MinHLLPrecision || precision > MaxHLLPrecision {
+		return fmt.Errorf(
+			"precision must be between %d and %d, got %d",
+			MinHLLPrecision, MaxHLLPrecision, precision,
+		)
+	}
+	return nil
+}
+
+// NewHyperLogLog creates a new HyperLogLog sketch using 2^precision
+// registers. The standard error of the estimate is ~1.04/sqrt(2^precision).
+// The precision must be validated with ValidateHyperLogLogPrecision.
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// Precision returns the precision the sketch was created with.
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// Insert adds the given value to the sketch.
+func (h *HyperLogLog) Insert(v []byte) {
+	h.InsertHash(hash64(v))
+}
+
+// InsertHash adds an already hashed value to the sketch. The hash
+// must be uniformly distributed over the 64 bit space.
+func (h *HyperLogLog) InsertHash(x uint64) {
+	idx := x >> (64 - h.precision)
+	// Set a sentinel bit so that the rank is bounded by 64-precision+1
+	// even if all the remaining bits are zero.
+	w := x<<h.precision | 1<<(h.precision-1)
+	rank := uint8(bits.LeadingZeros64(w)) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Merge merges other into the sketch. Both sketches must be created
+// with the same precision.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if other == nil {
+		return nil
+	}
+	if h.precision != other.precision {
+		return errors.New("cannot merge HyperLogLog sketches with different precision")
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// Estimate returns the approximate number of distinct values inserted
+// into the sketch.
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(len(h.registers))
+	var (
+		sum   float64
+		zeros int
+	)
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(len(h.registers)) * m * m / sum
+	// Use linear counting for small cardinalities where the raw
+	// estimate is known to be heavily biased.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// hash64 hashes the input using FNV-1a followed by the murmur3 finalizer
+// to improve the avalanche behaviour of the high bits, which are used as
+// register index.
+func hash64(v []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(v)
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sketch
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHyperLogLog(t *testing.T) {
+	require.Error(t, ValidateHyperLogLogPrecision(MinHLLPrecision-1))
+	require.Error(t, ValidateHyperLogLogPrecision(MaxHLLPrecision+1))
+	require.NoError(t, ValidateHyperLogLogPrecision(14))
+
+	hll := NewHyperLogLog(14)
+	assert.Equal(t, uint8(14), hll.Precision())
+	assert.Equal(t, uint64(0), hll.Estimate())
+}
+
+func TestHyperLogLogEstimate(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 1_000, 10_000, 100_000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			hll := NewHyperLogLog(14)
+			for i := 0; i < n; i++ {
+				v := []byte("user-" + strconv.Itoa(i))
+				// Duplicates must not affect the estimate.
+				hll.Insert(v)
+				hll.Insert(v)
+			}
+			// Standard error with precision 14 is ~0.8%, allow 3 sigma.
+			assert.InEpsilon(t, n, hll.Estimate(), 0.025)
+		})
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a := NewHyperLogLog(12)
+	b := NewHyperLogLog(12)
+	all := NewHyperLogLog(12)
+	for i := 0; i < 5_000; i++ {
+		v := []byte(strconv.Itoa(i))
+		all.Insert(v)
+		if i%2 == 0 {
+			a.Insert(v)
+		} else {
+			b.Insert(v)
+		}
+		// Overlapping values
+		if i%10 == 0 {
+			b.Insert(v)
+		}
+	}
+	require.NoError(t, a.Merge(b))
+	assert.Equal(t, all.Estimate(), a.Estimate())
+
+	assert.Error(t, a.Merge(NewHyperLogLog(10)))
+	assert.NoError(t, a.Merge(nil))
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -93,6 +94,17 @@ type Config struct {
 	IncludeInstrumentationScope []string `mapstructure:"include_instrumentation_scope"`
 
 	AggregationCardinalityLimit int `mapstructure:"aggregation_cardinality_limit"`
+
+	// Storage is the ID of the storage extension used to persist the cumulative aggregation state across restarts.
+	// The state is restored on start, including the original start timestamps, so that the cumulative series remain
+	// continuous. Only supported with cumulative temporality.
+	// Optional. If not set, the state is kept in memory only.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// CheckpointInterval is the minimum time period between two checkpoints of the aggregation state to the storage.
+	// Checkpoints are taken after metrics are flushed and on shutdown.
+	// Default value (0) means that the state is checkpointed every time the metrics are flushed.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
 }
 
 type HistogramConfig struct {
@@ -168,6 +180,14 @@ func (c Config) Validate() error {
 		return fmt.Errorf("invalid aggregation_cardinality_limit: %v, the limit should be positive", c.AggregationCardinalityLimit)
 	}
 
+	if c.Storage != nil && c.GetAggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+		return errors.New("storage is only supported with cumulative aggregation temporality")
+	}
+
+	if c.CheckpointInterval < 0 {
+		return fmt.Errorf("invalid checkpoint_interval: %v, the duration should be positive", c.CheckpointInterval)
+	}
+
 	if c.Exemplars.Enabled && c.Exemplars.MaxPerDataPoint < 0 {
 		return fmt.Errorf("invalid max_per_data_point: %v, the value should be positive", c.Exemplars.MaxPerDataPoint)
 	}
@@ -30,6 +30,7 @@ func TestLoadConfig(t *testing.T) {
 
 	defaultMethod := http.MethodGet
 	customTimestampCacheSize := 123
+	storageID := component.MustNewID("file_storage")
 	tests := []struct {
 		name            string
 		id              component.ID
@@ -205,6 +206,27 @@ func TestLoadConfig(t *testing.T) {
 				Namespace: DefaultNamespace,
 			},
 		},
+		{
+			name: "storage",
+			id:   component.NewIDWithName(metadata.Type, "storage"),
+			expected: &Config{
+				AggregationTemporality:   "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				ResourceMetricsCacheSize: defaultResourceMetricsCacheSize,
+				MetricsFlushInterval:     60 * time.Second,
+				Exemplars: ExemplarsConfig{
+					MaxPerDataPoint: defaultMaxPerDatapoint,
+				},
+				Histogram:          HistogramConfig{Disable: false, Unit: defaultUnit},
+				Namespace:          DefaultNamespace,
+				Storage:            &storageID,
+				CheckpointInterval: 5 * time.Minute,
+			},
+		},
+		{
+			name:         "storage_with_delta",
+			id:           component.NewIDWithName(metadata.Type, "storage_with_delta"),
+			errorMessage: "storage is only supported with cumulative aggregation temporality",
+		},
 	}
 
 	for _, tt := range tests {
@@ -421,6 +443,15 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectedErr: "failed validating event dimensions: no dimensions configured for events",
 		},
+		{
+			name: "invalid checkpoint interval",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				CheckpointInterval:       -1 * time.Second,
+			},
+			expectedErr: "invalid checkpoint_interval: -1s, the duration should be positive",
+		},
 	}
 
 	for _, tt := range tests {
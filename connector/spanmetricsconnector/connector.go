@@ -6,6 +6,7 @@ package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-
 import (
 	"bytes"
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -54,6 +56,7 @@ type connectorImp struct {
 	lock   sync.Mutex
 	logger *zap.Logger
 	config Config
+	id     component.ID
 
 	metricsConsumer consumer.Metrics
 
@@ -86,6 +89,10 @@ type connectorImp struct {
 
 	// Tracks the last TimestampUnixNano for delta metrics so that they represent an uninterrupted series. Unused for cumulative span metrics.
 	lastDeltaTimestamps *simplelru.LRU[metrics.Key, pcommon.Timestamp]
+
+	// storageClient persists the cumulative aggregation state, nil if storage is not configured.
+	storageClient  storage.Client
+	lastCheckpoint time.Time
 }
 
 type resourceMetrics struct {
@@ -207,9 +214,21 @@ func durationsToUnits(vs []time.Duration, unitDivider int64) []float64 {
 }
 
 // Start implements the component.Component interface.
-func (p *connectorImp) Start(ctx context.Context, _ component.Host) error {
+func (p *connectorImp) Start(ctx context.Context, host component.Host) error {
 	p.logger.Info("Starting spanmetrics connector")
 
+	if p.config.Storage != nil {
+		client, err := getStorageClient(ctx, host, p.config.Storage, p.id)
+		if err != nil {
+			return err
+		}
+		p.storageClient = client
+		if err := p.restoreState(ctx); err != nil {
+			return err
+		}
+		p.lastCheckpoint = p.clock.Now()
+	}
+
 	p.started = true
 	go func() {
 		for {
@@ -226,7 +245,8 @@ func (p *connectorImp) Start(ctx context.Context, _ component.Host) error {
 }
 
 // Shutdown implements the component.Component interface.
-func (p *connectorImp) Shutdown(context.Context) error {
+func (p *connectorImp) Shutdown(ctx context.Context) error {
+	var err error
 	p.shutdownOnce.Do(func() {
 		p.logger.Info("Shutting down spanmetrics connector")
 		if p.started {
@@ -235,8 +255,11 @@ func (p *connectorImp) Shutdown(context.Context) error {
 			p.done <- struct{}{}
 			p.started = false
 		}
+		if p.storageClient != nil {
+			err = errors.Join(p.checkpointState(ctx), p.storageClient.Close(ctx))
+		}
 	})
-	return nil
+	return err
 }
 
 // Capabilities implements the consumer interface.
@@ -262,6 +285,12 @@ func (p *connectorImp) exportMetrics(ctx context.Context) {
 	// This component no longer needs to read the metrics once built, so it is safe to unlock.
 	p.lock.Unlock()
 
+	if p.shouldCheckpoint() {
+		if err := p.checkpointState(ctx); err != nil {
+			p.logger.Error("Failed to checkpoint spanmetrics state", zap.Error(err))
+		}
+	}
+
 	if err := p.metricsConsumer.ConsumeMetrics(ctx, m); err != nil {
 		p.logger.Error("Failed ConsumeMetrics", zap.Error(err))
 		return
@@ -4,6 +4,7 @@
 package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
 
 import (
+	"math"
 	"sort"
 
 	"github.com/lightstep/go-expohisto/structure"
@@ -20,6 +21,11 @@ type HistogramMetrics interface {
 	GetOrCreate(key Key, attributesFun BuildAttributesFun, startTimestamp pcommon.Timestamp) (Histogram, bool)
 	BuildMetrics(pmetric.Metric, pcommon.Timestamp, func(Key, pcommon.Timestamp) pcommon.Timestamp, pmetric.AggregationTemporality)
 	ClearExemplars()
+	// Checkpoint writes the aggregation state into the given metric so that
+	// it can be persisted and later restored using Restore.
+	Checkpoint(pmetric.Metric)
+	// Restore restores the aggregation state from a metric written by Checkpoint.
+	Restore(pmetric.Metric) error
 }
 
 type Histogram interface {
@@ -61,6 +67,11 @@ type exponentialHistogram struct {
 	exemplars  pmetric.ExemplarSlice
 
 	histogram *structure.Histogram[float64]
+	// sum, min and max are tracked outside of the histogram structure
+	// as they cannot be restored into the structure from a checkpoint.
+	sum float64
+	min float64
+	max float64
 
 	maxExemplarCount int
 
@@ -194,6 +205,8 @@ func (m *exponentialHistogramMetrics) GetOrCreate(key Key, attributesFun BuildAt
 			exemplars:        pmetric.NewExemplarSlice(),
 			maxExemplarCount: m.maxExemplarCount,
 			startTimestamp:   startTimeStamp,
+			min:              math.Inf(1),
+			max:              math.Inf(-1),
 		}
 		m.metrics[key] = h
 	}
@@ -214,7 +227,7 @@ func (m *exponentialHistogramMetrics) BuildMetrics(
 		startTimestamp := startTimeStampGenerator(k, e.startTimestamp)
 		dp.SetStartTimestamp(startTimestamp)
 		dp.SetTimestamp(timestamp)
-		expoHistToExponentialDataPoint(e.histogram, dp)
+		e.copyTo(dp)
 		for i := 0; i < e.exemplars.Len(); i++ {
 			e.exemplars.At(i).SetTimestamp(timestamp)
 		}
@@ -223,6 +236,16 @@ func (m *exponentialHistogramMetrics) BuildMetrics(
 	}
 }
 
+// copyTo copies the exponential histogram to pmetric.ExponentialHistogramDataPoint
+func (e *exponentialHistogram) copyTo(dp pmetric.ExponentialHistogramDataPoint) {
+	expoHistToExponentialDataPoint(e.histogram, dp)
+	dp.SetSum(e.sum)
+	if e.histogram.Count() != 0 {
+		dp.SetMin(e.min)
+		dp.SetMax(e.max)
+	}
+}
+
 // expoHistToExponentialDataPoint copies `lightstep/go-expohisto` structure.Histogram to
 // pmetric.ExponentialHistogramDataPoint
 func expoHistToExponentialDataPoint(agg *structure.Histogram[float64], dp pmetric.ExponentialHistogramDataPoint) {
@@ -281,6 +304,9 @@ func (h *explicitHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.
 
 func (h *exponentialHistogram) Observe(value float64) {
 	h.histogram.Update(value)
+	h.sum += value
+	h.min = math.Min(h.min, value)
+	h.max = math.Max(h.max, value)
 }
 
 func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
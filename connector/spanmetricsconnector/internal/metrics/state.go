@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/lightstep/go-expohisto/structure"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// stateKeysMetadataKey is the metric metadata key holding the metric
+	// keys of the checkpointed data points, in data point order.
+	stateKeysMetadataKey = "spanmetrics.state.keys"
+	// stateIsFirstMetadataKey is the metric metadata key holding the isFirst
+	// flag of the checkpointed sum data points, in data point order.
+	stateIsFirstMetadataKey = "spanmetrics.state.is_first"
+)
+
+// bucketBoundaryEpsilon is the relative distance from the bucket boundaries
+// used when restoring exponential histograms. It is small enough to always
+// fall within a bucket at the maximum scale of 20.
+const bucketBoundaryEpsilon = 0x1p-24
+
+var errStateMismatch = errors.New("number of checkpointed keys does not match the number of data points")
+
+func (m *SumMetrics) Checkpoint(metric pmetric.Metric) {
+	dps := metric.SetEmptySum().DataPoints()
+	dps.EnsureCapacity(len(m.metrics))
+	keys := metric.Metadata().PutEmptySlice(stateKeysMetadataKey)
+	keys.EnsureCapacity(len(m.metrics))
+	isFirst := metric.Metadata().PutEmptySlice(stateIsFirstMetadataKey)
+	isFirst.EnsureCapacity(len(m.metrics))
+	for k, s := range m.metrics {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(s.startTimestamp)
+		dp.SetIntValue(int64(s.count))
+		s.attributes.CopyTo(dp.Attributes())
+		keys.AppendEmpty().SetStr(string(k))
+		isFirst.AppendEmpty().SetBool(s.isFirst)
+	}
+}
+
+func (m *SumMetrics) Restore(metric pmetric.Metric) error {
+	if metric.Type() != pmetric.MetricTypeSum {
+		return fmt.Errorf("unexpected metric type %s for sum state", metric.Type())
+	}
+	dps := metric.Sum().DataPoints()
+	keys, err := stateKeys(metric, dps.Len())
+	if err != nil {
+		return err
+	}
+	isFirst, hasIsFirst := metric.Metadata().Get(stateIsFirstMetadataKey)
+	hasIsFirst = hasIsFirst && isFirst.Type() == pcommon.ValueTypeSlice && isFirst.Slice().Len() == dps.Len()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		s := &Sum{
+			attributes:       pcommon.NewMap(),
+			count:            uint64(dp.IntValue()),
+			exemplars:        pmetric.NewExemplarSlice(),
+			maxExemplarCount: m.maxExemplarCount,
+			startTimestamp:   dp.StartTimestamp(),
+		}
+		if hasIsFirst {
+			s.isFirst = isFirst.Slice().At(i).Bool()
+		}
+		dp.Attributes().CopyTo(s.attributes)
+		m.metrics[keys[i]] = s
+	}
+	return nil
+}
+
+func (m *explicitHistogramMetrics) Checkpoint(metric pmetric.Metric) {
+	dps := metric.SetEmptyHistogram().DataPoints()
+	dps.EnsureCapacity(len(m.metrics))
+	keys := metric.Metadata().PutEmptySlice(stateKeysMetadataKey)
+	keys.EnsureCapacity(len(m.metrics))
+	for k, h := range m.metrics {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(h.startTimestamp)
+		dp.ExplicitBounds().FromRaw(h.bounds)
+		dp.BucketCounts().FromRaw(h.bucketCounts)
+		dp.SetCount(h.count)
+		dp.SetSum(h.sum)
+		h.attributes.CopyTo(dp.Attributes())
+		keys.AppendEmpty().SetStr(string(k))
+	}
+}
+
+func (m *explicitHistogramMetrics) Restore(metric pmetric.Metric) error {
+	if metric.Type() != pmetric.MetricTypeHistogram {
+		return fmt.Errorf("unexpected metric type %s for explicit histogram state", metric.Type())
+	}
+	dps := metric.Histogram().DataPoints()
+	keys, err := stateKeys(metric, dps.Len())
+	if err != nil {
+		return err
+	}
+	// Validate all data points before restoring any of them, a change in
+	// the configured buckets invalidates the whole state.
+	for i := 0; i < dps.Len(); i++ {
+		if !slices.Equal(dps.At(i).ExplicitBounds().AsRaw(), m.bounds) {
+			return errors.New("checkpointed histogram buckets do not match the configured buckets")
+		}
+	}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		h := &explicitHistogram{
+			attributes:       pcommon.NewMap(),
+			exemplars:        pmetric.NewExemplarSlice(),
+			bucketCounts:     dp.BucketCounts().AsRaw(),
+			count:            dp.Count(),
+			sum:              dp.Sum(),
+			bounds:           m.bounds,
+			maxExemplarCount: m.maxExemplarCount,
+			startTimestamp:   dp.StartTimestamp(),
+		}
+		dp.Attributes().CopyTo(h.attributes)
+		m.metrics[keys[i]] = h
+	}
+	return nil
+}
+
+func (m *exponentialHistogramMetrics) Checkpoint(metric pmetric.Metric) {
+	dps := metric.SetEmptyExponentialHistogram().DataPoints()
+	dps.EnsureCapacity(len(m.metrics))
+	keys := metric.Metadata().PutEmptySlice(stateKeysMetadataKey)
+	keys.EnsureCapacity(len(m.metrics))
+	for k, e := range m.metrics {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(e.startTimestamp)
+		e.copyTo(dp)
+		e.attributes.CopyTo(dp.Attributes())
+		keys.AppendEmpty().SetStr(string(k))
+	}
+}
+
+func (m *exponentialHistogramMetrics) Restore(metric pmetric.Metric) error {
+	if metric.Type() != pmetric.MetricTypeExponentialHistogram {
+		return fmt.Errorf("unexpected metric type %s for exponential histogram state", metric.Type())
+	}
+	dps := metric.ExponentialHistogram().DataPoints()
+	keys, err := stateKeys(metric, dps.Len())
+	if err != nil {
+		return err
+	}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		histogram := new(structure.Histogram[float64])
+		histogram.Init(structure.NewConfig(
+			structure.WithMaxSize(m.maxSize),
+		))
+		restoreExponentialBuckets(histogram, dp)
+
+		e := &exponentialHistogram{
+			attributes:       pcommon.NewMap(),
+			exemplars:        pmetric.NewExemplarSlice(),
+			histogram:        histogram,
+			sum:              dp.Sum(),
+			min:              math.Inf(1),
+			max:              math.Inf(-1),
+			maxExemplarCount: m.maxExemplarCount,
+			startTimestamp:   dp.StartTimestamp(),
+		}
+		if dp.HasMin() {
+			e.min = dp.Min()
+		}
+		if dp.HasMax() {
+			e.max = dp.Max()
+		}
+		dp.Attributes().CopyTo(e.attributes)
+		m.metrics[keys[i]] = e
+	}
+	return nil
+}
+
+// restoreExponentialBuckets records the bucket counts of the data point
+// into the histogram structure. The structure selects the largest scale at
+// which all the recorded values fit, so the outermost buckets are recorded
+// using values close to their outer boundary to ensure that the restored
+// structure settles on the same scale as the checkpointed data point. All
+// other buckets are recorded using their midpoint in log scale.
+func restoreExponentialBuckets(histogram *structure.Histogram[float64], dp pmetric.ExponentialHistogramDataPoint) {
+	if dp.ZeroCount() > 0 {
+		histogram.UpdateByIncr(0, dp.ZeroCount())
+	}
+	// Bucket with index i covers (base^i, base^(i+1)] where base = 2^(2^-scale).
+	inv := math.Exp2(-float64(dp.Scale()))
+	for _, b := range []struct {
+		buckets pmetric.ExponentialHistogramDataPointBuckets
+		sign    float64
+	}{
+		{dp.Positive(), 1},
+		{dp.Negative(), -1},
+	} {
+		counts := b.buckets.BucketCounts()
+		first, last := -1, -1
+		for i := 0; i < counts.Len(); i++ {
+			if counts.At(i) > 0 {
+				if first < 0 {
+					first = i
+				}
+				last = i
+			}
+		}
+		for i := first; first >= 0 && i <= last; i++ {
+			count := counts.At(i)
+			if count == 0 {
+				continue
+			}
+			index := float64(b.buckets.Offset()) + float64(i)
+			lower := math.Exp2(index*inv) * (1 + bucketBoundaryEpsilon)
+			upper := math.Exp2((index+1)*inv) * (1 - bucketBoundaryEpsilon)
+			switch {
+			case i == first && i == last:
+				histogram.UpdateByIncr(b.sign*lower, 1)
+				if count > 1 {
+					histogram.UpdateByIncr(b.sign*upper, count-1)
+				}
+			case i == first:
+				histogram.UpdateByIncr(b.sign*lower, count)
+			case i == last:
+				histogram.UpdateByIncr(b.sign*upper, count)
+			default:
+				histogram.UpdateByIncr(b.sign*math.Exp2((index+0.5)*inv), count)
+			}
+		}
+	}
+}
+
+// stateKeys returns the checkpointed metric keys for the metric.
+func stateKeys(metric pmetric.Metric, expected int) ([]Key, error) {
+	raw, ok := metric.Metadata().Get(stateKeysMetadataKey)
+	if !ok || raw.Type() != pcommon.ValueTypeSlice || raw.Slice().Len() != expected {
+		return nil, errStateMismatch
+	}
+	keys := make([]Key, expected)
+	for i := 0; i < expected; i++ {
+		keys[i] = Key(raw.Slice().At(i).Str())
+	}
+	return keys, nil
+}
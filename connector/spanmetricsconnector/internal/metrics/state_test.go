@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func testAttributesFun(name string) BuildAttributesFun {
+	return func() pcommon.Map {
+		attrs := pcommon.NewMap()
+		attrs.PutStr("span.name", name)
+		attrs.PutInt("int.attr", 42)
+		return attrs
+	}
+}
+
+// buildAll builds the cumulative data points so that the data points of
+// two metrics can be compared using the same timestamp.
+func buildAll(hm HistogramMetrics) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	hm.BuildMetrics(
+		metric,
+		pcommon.Timestamp(100),
+		func(_ Key, start pcommon.Timestamp) pcommon.Timestamp { return start },
+		pmetric.AggregationTemporalityCumulative,
+	)
+	return metric
+}
+
+func TestSumMetrics_CheckpointRestore(t *testing.T) {
+	src := NewSumMetrics(5, 0)
+	s, _ := src.GetOrCreate("key-1", testAttributesFun("span-1"), pcommon.Timestamp(10))
+	s.Add(3)
+	s, _ = src.GetOrCreate("key-2", testAttributesFun("span-2"), pcommon.Timestamp(20))
+	s.Add(7)
+	// Mark key-2 as already exported
+	src.metrics["key-2"].isFirst = false
+
+	checkpoint := pmetric.NewMetric()
+	src.Checkpoint(checkpoint)
+
+	dst := NewSumMetrics(5, 0)
+	require.NoError(t, dst.Restore(checkpoint))
+	require.Len(t, dst.metrics, 2)
+	for k, expected := range src.metrics {
+		actual, ok := dst.metrics[k]
+		require.True(t, ok)
+		assert.Equal(t, expected.count, actual.count)
+		assert.Equal(t, expected.startTimestamp, actual.startTimestamp)
+		assert.Equal(t, expected.isFirst, actual.isFirst)
+		assert.Equal(t, expected.attributes.AsRaw(), actual.attributes.AsRaw())
+		assert.Equal(t, 5, actual.maxExemplarCount)
+	}
+
+	// Restored sums continue to aggregate
+	s, _ = dst.GetOrCreate("key-1", testAttributesFun("span-1"), pcommon.Timestamp(30))
+	s.Add(1)
+	assert.Equal(t, uint64(4), dst.metrics["key-1"].count)
+	assert.Equal(t, pcommon.Timestamp(10), dst.metrics["key-1"].startTimestamp)
+}
+
+func TestSumMetrics_RestoreInvalid(t *testing.T) {
+	sm := NewSumMetrics(5, 0)
+
+	metric := pmetric.NewMetric()
+	metric.SetEmptyGauge()
+	assert.ErrorContains(t, sm.Restore(metric), "unexpected metric type")
+
+	metric = pmetric.NewMetric()
+	metric.SetEmptySum().DataPoints().AppendEmpty()
+	assert.ErrorIs(t, sm.Restore(metric), errStateMismatch)
+	assert.Empty(t, sm.metrics)
+}
+
+func TestExplicitHistogramMetrics_CheckpointRestore(t *testing.T) {
+	bounds := []float64{1, 10, 100}
+	src := NewExplicitHistogramMetrics(bounds, 5, 0)
+	h, _ := src.GetOrCreate("key-1", testAttributesFun("span-1"), pcommon.Timestamp(10))
+	for _, v := range []float64{0.5, 5, 50, 500, 5} {
+		h.Observe(v)
+	}
+	h, _ = src.GetOrCreate("key-2", testAttributesFun("span-2"), pcommon.Timestamp(20))
+	h.Observe(2)
+
+	checkpoint := pmetric.NewMetric()
+	src.Checkpoint(checkpoint)
+
+	dst := NewExplicitHistogramMetrics(bounds, 5, 0)
+	require.NoError(t, dst.Restore(checkpoint))
+	assertHistogramMetricsEqual(t, buildAll(src), buildAll(dst))
+
+	// Restoring with different buckets fails without restoring any state
+	other := NewExplicitHistogramMetrics([]float64{1, 2}, 5, 0)
+	assert.ErrorContains(t, other.Restore(checkpoint), "do not match")
+	assert.Empty(t, other.(*explicitHistogramMetrics).metrics)
+
+	// Restoring from a different histogram type fails
+	expo := NewExponentialHistogramMetrics(160, 5, 0)
+	assert.ErrorContains(t, expo.Restore(checkpoint), "unexpected metric type")
+}
+
+func TestExponentialHistogramMetrics_CheckpointRestore(t *testing.T) {
+	src := NewExponentialHistogramMetrics(10, 5, 0)
+	h, _ := src.GetOrCreate("key-1", testAttributesFun("span-1"), pcommon.Timestamp(10))
+	for _, v := range []float64{0, 0.5, 1.1, 5, 50, 500, 5, -3} {
+		h.Observe(v)
+	}
+	h, _ = src.GetOrCreate("key-2", testAttributesFun("span-2"), pcommon.Timestamp(20))
+	h.Observe(2)
+
+	checkpoint := pmetric.NewMetric()
+	src.Checkpoint(checkpoint)
+
+	dst := NewExponentialHistogramMetrics(10, 5, 0)
+	require.NoError(t, dst.Restore(checkpoint))
+	assertHistogramMetricsEqual(t, buildAll(src), buildAll(dst))
+
+	// Restored histograms continue to aggregate with the original sum, min and max
+	h, _ = dst.GetOrCreate("key-1", testAttributesFun("span-1"), pcommon.Timestamp(30))
+	h.Observe(1000)
+	dp := buildAll(dst).ExponentialHistogram().DataPoints()
+	for i := 0; i < dp.Len(); i++ {
+		if name, _ := dp.At(i).Attributes().Get("span.name"); name.Str() != "span-1" {
+			continue
+		}
+		assert.Equal(t, uint64(9), dp.At(i).Count())
+		assert.InDelta(t, 1558.6, dp.At(i).Sum(), 1e-9)
+		assert.Equal(t, -3.0, dp.At(i).Min())
+		assert.Equal(t, 1000.0, dp.At(i).Max())
+		assert.Equal(t, pcommon.Timestamp(10), dp.At(i).StartTimestamp())
+	}
+}
+
+func assertHistogramMetricsEqual(t *testing.T, expected, actual pmetric.Metric) {
+	t.Helper()
+
+	byName := func(m pmetric.Metric) map[string]any {
+		result := make(map[string]any)
+		switch m.Type() {
+		case pmetric.MetricTypeHistogram:
+			dps := m.Histogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				name, _ := dps.At(i).Attributes().Get("span.name")
+				result[name.Str()] = dps.At(i)
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			dps := m.ExponentialHistogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				name, _ := dps.At(i).Attributes().Get("span.name")
+				result[name.Str()] = dps.At(i)
+			}
+		}
+		return result
+	}
+	assert.Equal(t, expected.Type(), actual.Type())
+	assert.Equal(t, byName(expected), byName(actual))
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// stateStorageKey is the key under which the aggregation state is stored
+// in the storage client. The version suffix allows changing the encoding
+// of the state without misinterpreting older checkpoints.
+const stateStorageKey = "spanmetrics_state_v1"
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindConnector, componentID, "")
+}
+
+// buildState encodes the aggregation state of all resources into a metrics
+// payload. Each resource is encoded as a resource metrics with the calls,
+// duration and events metrics holding the raw aggregation state. The
+// caller must hold the lock.
+func (p *connectorImp) buildState() ([]byte, error) {
+	m := pmetric.NewMetrics()
+	p.resourceMetrics.ForEach(func(_ resourceKey, rawMetrics *resourceMetrics) {
+		rm := m.ResourceMetrics().AppendEmpty()
+		rawMetrics.attributes.CopyTo(rm.Resource().Attributes())
+		sm := rm.ScopeMetrics().AppendEmpty()
+
+		metric := sm.Metrics().AppendEmpty()
+		metric.SetName(metricNameCalls)
+		rawMetrics.sums.Checkpoint(metric)
+
+		if rawMetrics.histograms != nil {
+			metric = sm.Metrics().AppendEmpty()
+			metric.SetName(metricNameDuration)
+			rawMetrics.histograms.Checkpoint(metric)
+		}
+
+		if p.events.Enabled {
+			metric = sm.Metrics().AppendEmpty()
+			metric.SetName(metricNameEvents)
+			rawMetrics.events.Checkpoint(metric)
+		}
+	})
+	return (&pmetric.ProtoMarshaler{}).MarshalMetrics(m)
+}
+
+// restoreState restores the aggregation state from the storage client. A
+// state that cannot be restored, e.g. after a change of the histogram
+// configuration, is discarded with a warning rather than failing start.
+func (p *connectorImp) restoreState(ctx context.Context) error {
+	data, err := p.storageClient.Get(ctx, stateStorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read spanmetrics state: %w", err)
+	}
+	if len(data) == 0 {
+		p.logger.Debug("No spanmetrics state found in storage")
+		return nil
+	}
+	m, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(data)
+	if err != nil {
+		p.logger.Warn("Discarding invalid spanmetrics state", zap.Error(err))
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for i := 0; i < m.ResourceMetrics().Len(); i++ {
+		rm := m.ResourceMetrics().At(i)
+		rawMetrics := p.getOrCreateResourceMetrics(rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			metrics := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				var restoreErr error
+				switch metric.Name() {
+				case metricNameCalls:
+					restoreErr = rawMetrics.sums.Restore(metric)
+				case metricNameDuration:
+					if rawMetrics.histograms != nil {
+						restoreErr = rawMetrics.histograms.Restore(metric)
+					}
+				case metricNameEvents:
+					if p.events.Enabled {
+						restoreErr = rawMetrics.events.Restore(metric)
+					}
+				}
+				if restoreErr != nil {
+					p.logger.Warn(
+						"Discarding spanmetrics state",
+						zap.String("metric", metric.Name()),
+						zap.Error(restoreErr),
+					)
+				}
+			}
+		}
+	}
+	p.logger.Info("Restored spanmetrics state", zap.Int("resources", m.ResourceMetrics().Len()))
+	return nil
+}
+
+// checkpointState persists the aggregation state to the storage client.
+func (p *connectorImp) checkpointState(ctx context.Context) error {
+	p.lock.Lock()
+	data, err := p.buildState()
+	p.lastCheckpoint = p.clock.Now()
+	p.lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode spanmetrics state: %w", err)
+	}
+	if err := p.storageClient.Set(ctx, stateStorageKey, data); err != nil {
+		return fmt.Errorf("failed to write spanmetrics state: %w", err)
+	}
+	return nil
+}
+
+// shouldCheckpoint returns true if a checkpoint is due as per the configured
+// checkpoint interval.
+func (p *connectorImp) shouldCheckpoint() bool {
+	if p.storageClient == nil {
+		return false
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.clock.Since(p.lastCheckpoint) >= p.config.CheckpointInterval
+}
@@ -22,15 +22,17 @@ const (
 	resourceRouting
 	streamIDRouting
 	attrRouting
+	streamIDShardRouting
 )
 
 const (
-	svcRoutingStr        = "service"
-	traceIDRoutingStr    = "traceID"
-	metricNameRoutingStr = "metric"
-	resourceRoutingStr   = "resource"
-	streamIDRoutingStr   = "streamID"
-	attrRoutingStr       = "attributes"
+	svcRoutingStr           = "service"
+	traceIDRoutingStr       = "traceID"
+	metricNameRoutingStr    = "metric"
+	resourceRoutingStr      = "resource"
+	streamIDRoutingStr      = "streamID"
+	attrRoutingStr          = "attributes"
+	streamIDShardRoutingStr = "streamIDShard"
 )
 
 // Config defines configuration for the exporter.
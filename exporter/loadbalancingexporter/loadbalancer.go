@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/shard"
 )
 
 const (
@@ -33,6 +34,8 @@ type loadBalancer struct {
 
 	res  resolver
 	ring *hashRing
+	// endpoints holds the endpoints in the order of the shards they own, used to route by shard
+	endpoints []string
 
 	componentFactory componentFactory
 	exporters        map[string]*wrappedExporter
@@ -157,6 +160,11 @@ func (lb *loadBalancer) onBackendChanges(resolved []string) {
 		defer lb.updateLock.Unlock()
 
 		lb.ring = newRing
+		endpoints := make([]string, len(resolved))
+		for i, endpoint := range resolved {
+			endpoints[i] = endpointWithPort(endpoint)
+		}
+		lb.endpoints = shard.Endpoints(endpoints)
 
 		// TODO: set a timeout?
 		ctx := context.Background()
@@ -247,3 +255,24 @@ func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter
 
 	return exp, endpoint, nil
 }
+
+// shardEndpoints returns the endpoints in the order of the shards they own, see [shard.Endpoints].
+// The owner of a stream is the endpoint at the position returned by [shard.Of] for the number of
+// endpoints, which matches the ownership of stateful components configured with the same endpoints.
+func (lb *loadBalancer) shardEndpoints() []string {
+	lb.updateLock.RLock()
+	defer lb.updateLock.RUnlock()
+	return lb.endpoints
+}
+
+// exporterForEndpoint returns the exporter of the endpoint.
+func (lb *loadBalancer) exporterForEndpoint(endpoint string) (*wrappedExporter, string, error) {
+	lb.updateLock.RLock()
+	defer lb.updateLock.RUnlock()
+	exp, found := lb.exporters[endpoint]
+	if !found {
+		return nil, "", fmt.Errorf("couldn't find the exporter for the endpoint %q", endpoint)
+	}
+
+	return exp, endpoint, nil
+}
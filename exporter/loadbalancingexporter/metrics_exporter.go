@@ -23,6 +23,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/shard"
 )
 
 var _ exporter.Metrics = (*metricExporterImp)(nil)
@@ -72,6 +73,8 @@ func newMetricsExporter(params exporter.Settings, cfg component.Config) (*metric
 		metricExporter.routingKey = metricNameRouting
 	case streamIDRoutingStr:
 		metricExporter.routingKey = streamIDRouting
+	case streamIDShardRoutingStr:
+		metricExporter.routingKey = streamIDShardRouting
 	default:
 		return nil, fmt.Errorf("unsupported routing_key: %q", cfg.(*Config).RoutingKey)
 	}
@@ -109,6 +112,15 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 		batches = splitMetricsByMetricName(md)
 	case streamIDRouting:
 		batches = splitMetricsByStreamID(md)
+	case streamIDShardRouting:
+		// batches are keyed by the endpoint owning their streams
+		endpoints := e.loadBalancer.shardEndpoints()
+		if len(endpoints) == 0 {
+			return errors.New("couldn't find the exporter for the shard, no endpoints are available")
+		}
+		batches = splitMetricsByStream(md, func(id identity.Stream) string {
+			return endpoints[shard.Of(id, len(endpoints))]
+		})
 	}
 
 	// Now assign each batch to an exporter, and merge as we go
@@ -116,7 +128,7 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 	exporterEndpoints := map[*wrappedExporter]string{}
 
 	for routingID, mds := range batches {
-		exp, endpoint, err := e.loadBalancer.exporterAndEndpoint([]byte(routingID))
+		exp, endpoint, err := e.exporterAndEndpoint(routingID)
 		if err != nil {
 			return err
 		}
@@ -152,6 +164,13 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 	return errs
 }
 
+func (e *metricExporterImp) exporterAndEndpoint(routingID string) (*wrappedExporter, string, error) {
+	if e.routingKey == streamIDShardRouting {
+		return e.loadBalancer.exporterForEndpoint(routingID)
+	}
+	return e.loadBalancer.exporterAndEndpoint([]byte(routingID))
+}
+
 func splitMetricsByResourceServiceName(md pmetric.Metrics) (map[string]pmetric.Metrics, error) {
 	results := map[string]pmetric.Metrics{}
 
@@ -231,6 +250,10 @@ func splitMetricsByMetricName(md pmetric.Metrics) map[string]pmetric.Metrics {
 }
 
 func splitMetricsByStreamID(md pmetric.Metrics) map[string]pmetric.Metrics {
+	return splitMetricsByStream(md, identity.Stream.String)
+}
+
+func splitMetricsByStream(md pmetric.Metrics, streamKey func(identity.Stream) string) map[string]pmetric.Metrics {
 	results := map[string]pmetric.Metrics{}
 
 	for i := 0; i < md.ResourceMetrics().Len(); i++ {
@@ -258,7 +281,7 @@ func splitMetricsByStreamID(md pmetric.Metrics) map[string]pmetric.Metrics {
 						dpClone := gaugeClone.DataPoints().AppendEmpty()
 						dp.CopyTo(dpClone)
 
-						key := identity.OfStream(metricID, dp).String()
+						key := streamKey(identity.OfStream(metricID, dp))
 						existing, ok := results[key]
 						if ok {
 							metrics.Merge(existing, newMD)
@@ -280,7 +303,7 @@ func splitMetricsByStreamID(md pmetric.Metrics) map[string]pmetric.Metrics {
 						dpClone := sumClone.DataPoints().AppendEmpty()
 						dp.CopyTo(dpClone)
 
-						key := identity.OfStream(metricID, dp).String()
+						key := streamKey(identity.OfStream(metricID, dp))
 						existing, ok := results[key]
 						if ok {
 							metrics.Merge(existing, newMD)
@@ -301,7 +324,7 @@ func splitMetricsByStreamID(md pmetric.Metrics) map[string]pmetric.Metrics {
 						dpClone := histogramClone.DataPoints().AppendEmpty()
 						dp.CopyTo(dpClone)
 
-						key := identity.OfStream(metricID, dp).String()
+						key := streamKey(identity.OfStream(metricID, dp))
 						existing, ok := results[key]
 						if ok {
 							metrics.Merge(existing, newMD)
@@ -322,7 +345,7 @@ func splitMetricsByStreamID(md pmetric.Metrics) map[string]pmetric.Metrics {
 						dpClone := expHistogramClone.DataPoints().AppendEmpty()
 						dp.CopyTo(dpClone)
 
-						key := identity.OfStream(metricID, dp).String()
+						key := streamKey(identity.OfStream(metricID, dp))
 						existing, ok := results[key]
 						if ok {
 							metrics.Merge(existing, newMD)
@@ -342,7 +365,7 @@ func splitMetricsByStreamID(md pmetric.Metrics) map[string]pmetric.Metrics {
 						dpClone := sumClone.DataPoints().AppendEmpty()
 						dp.CopyTo(dpClone)
 
-						key := identity.OfStream(metricID, dp).String()
+						key := streamKey(identity.OfStream(metricID, dp))
 						existing, ok := results[key]
 						if ok {
 							metrics.Merge(existing, newMD)
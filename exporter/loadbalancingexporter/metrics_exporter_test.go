@@ -30,6 +30,8 @@ import (
 	conventions "go.opentelemetry.io/otel/semconv/v1.27.0"
 	"gopkg.in/yaml.v3"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/shard"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
 )
@@ -536,6 +538,95 @@ func TestConsumeMetrics_TripleEndpoint(t *testing.T) {
 	}
 }
 
+// TestConsumeMetrics_StreamIDShard validates that each stream is routed to the endpoint owning its shard,
+// which is the replica owning it in the deltatocumulative processor configured with the same endpoints.
+func TestConsumeMetrics_StreamIDShard(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	t.Parallel()
+
+	endpoints := []string{"endpoint-3", "endpoint-1", "endpoint-2"}
+	config := &Config{
+		Resolver: ResolverSettings{
+			Static: configoptional.Some(StaticResolver{Hostnames: endpoints}),
+		},
+		RoutingKey: streamIDShardRoutingStr,
+	}
+
+	p, err := newMetricsExporter(ts, config)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+
+	sinks := map[string]*consumertest.MetricsSink{}
+	componentFactory := func(_ context.Context, endpoint string) (component.Component, error) {
+		sink := &consumertest.MetricsSink{}
+		sinks[endpoint] = sink
+		return newMockMetricsExporter(sink.ConsumeMetrics), nil
+	}
+
+	lb, err := newLoadBalancer(ts.Logger, config, componentFactory, tb)
+	require.NoError(t, err)
+	require.NotNil(t, lb)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(_ context.Context) ([]string, error) {
+			return endpoints, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(t.Context(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(t.Context()))
+	}()
+
+	input, err := golden.ReadMetrics(filepath.Join("testdata", "metrics", "consume_metrics", "triple_endpoint", "stream_id", "input.yaml"))
+	require.NoError(t, err)
+	streams := len(splitMetricsByStreamID(input))
+
+	require.NoError(t, p.ConsumeMetrics(t.Context(), input))
+
+	routed := 0
+	for index, endpoint := range []string{"endpoint-1:4317", "endpoint-2:4317", "endpoint-3:4317"} {
+		require.Contains(t, sinks, endpoint)
+		for _, md := range sinks[endpoint].AllMetrics() {
+			routed += len(splitMetricsByStream(md, func(id identity.Stream) string {
+				assert.Equal(t, index, shard.Of(id, 3), "stream %s routed to %s", id, endpoint)
+				return id.String()
+			}))
+		}
+	}
+	assert.Equal(t, streams, routed)
+}
+
+func TestConsumeMetrics_StreamIDShardNoEndpoint(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	config := &Config{
+		Resolver: ResolverSettings{
+			Static: configoptional.Some(StaticResolver{Hostnames: []string{"endpoint-1"}}),
+		},
+		RoutingKey: streamIDShardRoutingStr,
+	}
+
+	lb, err := newLoadBalancer(ts.Logger, config, func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockMetricsExporter(), nil
+	}, tb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(ts, config)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(signal1Name)
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+
+	err = p.ConsumeMetrics(t.Context(), md)
+	require.ErrorContains(t, err, "no endpoints are available")
+}
+
 // this test validates that exporter is can concurrently change the endpoints while consuming metrics.
 func TestConsumeMetrics_ConcurrentResolverChange(t *testing.T) {
 	ts, tb := getTelemetryAssets(t)
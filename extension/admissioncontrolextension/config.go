@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admissioncontrolextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines configuration for the admission control extension.
+type Config struct {
+	// RequestLimitMiB limits the number of request bytes that can be
+	// processed concurrently. Requests larger than this limit are always
+	// rejected.
+	RequestLimitMiB uint64 `mapstructure:"request_limit_mib"`
+
+	// WaitingLimitMiB limits the number of request bytes that can wait for
+	// admission. Requests arriving once it is reached are rejected
+	// immediately. When zero, requests are rejected instead of waiting.
+	WaitingLimitMiB uint64 `mapstructure:"waiting_limit_mib"`
+
+	// RetryAfter is the delay clients are asked to wait before retrying a
+	// rejected request. When zero, no retry hint is given.
+	RetryAfter time.Duration `mapstructure:"retry_after"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.RequestLimitMiB == 0 {
+		return errors.New("request_limit_mib must be greater than zero")
+	}
+	if cfg.RetryAfter < 0 {
+		return errors.New("retry_after must not be negative")
+	}
+	return nil
+}
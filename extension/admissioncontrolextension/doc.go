@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package admissioncontrolextension provides an extension that limits the
+// size of the requests processed concurrently by the receivers referencing it.
+package admissioncontrolextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension"
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admissioncontrolextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensionmiddleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/otelarrow/admission2"
+)
+
+const (
+	oneMiB    = 1 << 20
+	reasonKey = "reason"
+)
+
+// ReleaseFunc is returned by Acquire when the request was admitted. It must
+// be called once the request is finished.
+type ReleaseFunc func()
+
+// Limiter admits requests according to their size, so that the total size of
+// the requests processed concurrently stays within the configured limits.
+type Limiter interface {
+	// Acquire returns when one of the following events occurs:
+	//
+	//   (1) the request is admitted, or
+	//   (2) ctx is canceled, or
+	//   (3) the request is larger than the request limit, or
+	//   (4) too many bytes are already waiting for admission.
+	//
+	// In case (1), the returned ReleaseFunc must be called once the request
+	// is finished. Otherwise, the error is a gRPC status error with code
+	// Canceled, InvalidArgument or ResourceExhausted respectively. In the
+	// last case, the status carries a RetryInfo detail when a retry delay
+	// is configured.
+	Acquire(ctx context.Context, weight uint64) (ReleaseFunc, error)
+}
+
+type admissionControl struct {
+	queue            *admission2.BoundedQueue
+	maxLimitAdmit    uint64
+	retryAfter       time.Duration
+	telemetryBuilder *metadata.TelemetryBuilder
+}
+
+var (
+	_ extension.Extension            = (*admissionControl)(nil)
+	_ extensionmiddleware.HTTPServer = (*admissionControl)(nil)
+	_ extensionmiddleware.GRPCServer = (*admissionControl)(nil)
+	_ Limiter                        = (*admissionControl)(nil)
+)
+
+func newAdmissionControl(set component.TelemetrySettings, cfg *Config) (*admissionControl, error) {
+	return newAdmissionControlWithLimits(set, cfg.RequestLimitMiB*oneMiB, cfg.WaitingLimitMiB*oneMiB, cfg.RetryAfter)
+}
+
+func newAdmissionControlWithLimits(set component.TelemetrySettings, maxLimitAdmit, maxLimitWait uint64, retryAfter time.Duration) (*admissionControl, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	ac := &admissionControl{
+		maxLimitAdmit:    maxLimitAdmit,
+		retryAfter:       retryAfter,
+		telemetryBuilder: telemetryBuilder,
+	}
+	ac.queue = admission2.NewBoundedQueueWithoutMetrics(set, maxLimitAdmit, maxLimitWait,
+		admission2.WithTooMuchWaitingError(newTooMuchWaitingError(retryAfter)),
+		admission2.WithRejectFunc(ac.recordRejected),
+		admission2.WithTracer(metadata.Tracer(set)),
+	)
+
+	err = telemetryBuilder.RegisterExtensionAdmissionControlInFlightBytesCallback(func(_ context.Context, observer metric.Int64Observer) error {
+		observer.Observe(ac.queue.InFlight())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = telemetryBuilder.RegisterExtensionAdmissionControlWaitingBytesCallback(func(_ context.Context, observer metric.Int64Observer) error {
+		observer.Observe(ac.queue.Waiting())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ac, nil
+}
+
+// newTooMuchWaitingError returns the error of the requests rejected because
+// too many bytes are waiting, with a hint on when to retry them.
+func newTooMuchWaitingError(retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "rejecting request, too much pending data")
+	if retryAfter <= 0 {
+		return st.Err()
+	}
+	withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+func (ac *admissionControl) recordRejected(ctx context.Context, reason string) {
+	ac.telemetryBuilder.ExtensionAdmissionControlRejectedRequests.Add(context.WithoutCancel(ctx), 1,
+		metric.WithAttributes(attribute.String(reasonKey, reason)))
+}
+
+func (*admissionControl) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (ac *admissionControl) Shutdown(context.Context) error {
+	ac.telemetryBuilder.Shutdown()
+	return nil
+}
+
+// Acquire implements Limiter.
+func (ac *admissionControl) Acquire(ctx context.Context, weight uint64) (ReleaseFunc, error) {
+	release, err := ac.queue.Acquire(ctx, weight)
+	return ReleaseFunc(release), err
+}
+
+// GetLimiter returns the Limiter of the admission control extension with the
+// given ID, for components that acquire admission themselves rather than
+// through the HTTP or gRPC server middlewares.
+func GetLimiter(host component.Host, id component.ID) (Limiter, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("extension %q not found", id)
+	}
+	limiter, ok := ext.(Limiter)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not an admission control extension", id)
+	}
+	return limiter, nil
+}
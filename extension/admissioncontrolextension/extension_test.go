@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admissioncontrolextension
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/otelarrow/admission2"
+)
+
+func newTestExtension(t *testing.T, maxAdmit, maxWait uint64, retryAfter time.Duration) *admissionControl {
+	ac, err := newAdmissionControlWithLimits(componenttest.NewNopTelemetrySettings(), maxAdmit, maxWait, retryAfter)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ac.Shutdown(context.Background())) })
+	return ac
+}
+
+func TestHTTPHandler(t *testing.T) {
+	ac := newTestExtension(t, 10, 0, 1500*time.Millisecond)
+
+	var served []string
+	handler, err := ac.GetHTTPHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		served = append(served, string(body))
+	}))
+	require.NoError(t, err)
+
+	serve := func(body string, knownLength bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if !knownLength {
+			req.ContentLength = -1
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, serve("0123456789", true).Code)
+	// the body of requests without a content length is measured
+	require.Equal(t, http.StatusOK, serve("abcdef", false).Code)
+	require.Equal(t, http.StatusRequestEntityTooLarge, serve("0123456789a", true).Code)
+	require.Equal(t, http.StatusRequestEntityTooLarge, serve("0123456789a", false).Code)
+	require.Equal(t, []string{"0123456789", "abcdef"}, served)
+
+	// while another request is in flight, there is no room to wait
+	release, err := ac.Acquire(t.Context(), 5)
+	require.NoError(t, err)
+	rec := serve("0123456789", true)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "2", rec.Header().Get(headerRetryAfter))
+	// the body of requests without a content length is only read once admitted
+	rec = serve("abcdef", false)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	release()
+	require.Equal(t, http.StatusOK, serve("0123456789", true).Code)
+	require.Equal(t, []string{"0123456789", "abcdef", "0123456789"}, served)
+}
+
+func TestHTTPHandlerChunkedBody(t *testing.T) {
+	ac := newTestExtension(t, 3*chunkSize, 0, time.Second)
+
+	var served int
+	handler, err := ac.GetHTTPHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		served = len(body)
+		// the admission covers the body read, up to one chunk more
+		assert.GreaterOrEqual(t, ac.queue.InFlight(), int64(len(body)))
+		assert.Less(t, ac.queue.InFlight(), int64(len(body)+chunkSize))
+	}))
+	require.NoError(t, err)
+
+	serve := func(size int) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", size)))
+		req.ContentLength = -1
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, serve(5*chunkSize/2))
+	require.Equal(t, 5*chunkSize/2, served)
+	require.Equal(t, http.StatusOK, serve(3*chunkSize))
+	require.Equal(t, 3*chunkSize, served)
+	require.Equal(t, http.StatusRequestEntityTooLarge, serve(3*chunkSize+1))
+	require.Zero(t, ac.queue.InFlight())
+	require.Zero(t, ac.queue.Waiting())
+}
+
+func TestHTTPHandlerChunkedBodyWaiting(t *testing.T) {
+	ac := newTestExtension(t, 2*chunkSize, chunkSize, time.Second)
+
+	handler, err := ac.GetHTTPHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+	}))
+	require.NoError(t, err)
+
+	release, err := ac.Acquire(t.Context(), chunkSize)
+	require.NoError(t, err)
+
+	done := make(chan int)
+	go func() {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", 3*chunkSize/2)))
+		req.ContentLength = -1
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		done <- rec.Code
+	}()
+
+	// while waiting for the second chunk, the first one stays admitted
+	require.Eventually(t, func() bool { return ac.queue.Waiting() > 0 }, time.Second, time.Millisecond)
+	require.Equal(t, int64(chunkSize), ac.queue.Waiting())
+	require.Equal(t, int64(2*chunkSize), ac.queue.InFlight())
+
+	release()
+	require.Equal(t, http.StatusOK, <-done)
+	require.Zero(t, ac.queue.InFlight())
+	require.Zero(t, ac.queue.Waiting())
+}
+
+func TestGRPCInterceptor(t *testing.T) {
+	ac := newTestExtension(t, 100, 0, time.Second)
+	opts, err := ac.GetGRPCServerOptions()
+	require.NoError(t, err)
+	require.Len(t, opts, 1)
+
+	handled := 0
+	handler := func(context.Context, any) (any, error) {
+		handled++
+		return nil, nil
+	}
+
+	// the encoded size of the message is 2 bytes of tag and length, then the value
+	_, err = ac.unaryServerInterceptor(t.Context(), wrapperspb.Bytes(make([]byte, 98)), &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	_, err = ac.unaryServerInterceptor(t.Context(), wrapperspb.Bytes(make([]byte, 99)), &grpc.UnaryServerInfo{}, handler)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	release, err := ac.Acquire(t.Context(), 1)
+	require.NoError(t, err)
+	_, err = ac.unaryServerInterceptor(t.Context(), wrapperspb.Bytes(make([]byte, 98)), &grpc.UnaryServerInfo{}, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	release()
+	require.Equal(t, 1, handled)
+}
+
+func TestTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.RequestLimitMiB = 1
+	cfg.WaitingLimitMiB = 0
+	ext, err := f.Create(t.Context(), metadatatest.NewSettings(tel), cfg)
+	require.NoError(t, err)
+	limiter := ext.(Limiter)
+
+	release, err := limiter.Acquire(t.Context(), oneMiB/2)
+	require.NoError(t, err)
+	_, err = limiter.Acquire(t.Context(), oneMiB)
+	require.Error(t, err)
+	_, err = limiter.Acquire(t.Context(), oneMiB+1)
+	require.Error(t, err)
+
+	metadatatest.AssertEqualExtensionAdmissionControlInFlightBytes(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: oneMiB / 2}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualExtensionAdmissionControlWaitingBytes(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 0}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualExtensionAdmissionControlRejectedRequests(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 1, Attributes: attribute.NewSet(attribute.String(reasonKey, admission2.ReasonTooMuchWaiting))},
+			{Value: 1, Attributes: attribute.NewSet(attribute.String(reasonKey, admission2.ReasonTooLarge))},
+		},
+		metricdatatest.IgnoreTimestamp())
+
+	release()
+	require.NoError(t, ext.Shutdown(t.Context()))
+}
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestGetLimiter(t *testing.T) {
+	ac := newTestExtension(t, 10, 10, 0)
+	id := component.NewID(metadata.Type)
+	other := component.MustNewID("other")
+	host := testHost{extensions: map[component.ID]component.Component{
+		id: ac,
+		other: struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{},
+	}}
+
+	limiter, err := GetLimiter(host, id)
+	require.NoError(t, err)
+	require.Equal(t, ac, limiter)
+
+	_, err = GetLimiter(host, other)
+	require.ErrorContains(t, err, "is not an admission control extension")
+	_, err = GetLimiter(host, component.NewIDWithName(metadata.Type, "missing"))
+	require.ErrorContains(t, err, "not found")
+}
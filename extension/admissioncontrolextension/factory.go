@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admissioncontrolextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension/internal/metadata"
+)
+
+const (
+	defaultRequestLimitMiB = 128
+	defaultWaitingLimitMiB = 32
+	defaultRetryAfter      = time.Second
+)
+
+// NewFactory creates a factory for the admission control extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		RequestLimitMiB: defaultRequestLimitMiB,
+		WaitingLimitMiB: defaultWaitingLimitMiB,
+		RetryAfter:      defaultRetryAfter,
+	}
+}
+
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newAdmissionControl(set.TelemetrySettings, cfg.(*Config))
+}
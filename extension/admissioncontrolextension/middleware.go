@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admissioncontrolextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/otelarrow/admission2"
+)
+
+const (
+	headerRetryAfter = "Retry-After"
+
+	// chunkSize is the number of bytes by which the admission of a request
+	// body without a content length grows while it is read.
+	chunkSize = 64 << 10
+)
+
+// GetHTTPHandler implements extensionmiddleware.HTTPServer. Requests are
+// weighted by the size of their body as received, before decompression.
+func (ac *admissionControl) GetHTTPHandler(base http.Handler) (http.Handler, error) {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			release ReleaseFunc
+			err     error
+		)
+		if r.ContentLength < 0 && r.Body != nil && r.Body != http.NoBody {
+			release, err = ac.acquireBody(r)
+		} else {
+			release, err = ac.Acquire(r.Context(), uint64(max(r.ContentLength, 0)))
+		}
+		if err != nil {
+			ac.writeHTTPError(w, err)
+			return
+		}
+		defer release()
+		base.ServeHTTP(w, r)
+	}), nil
+}
+
+// acquireBody admits a request body of unknown length while reading it. A
+// chunk of the body is only read once it is admitted along with the bytes
+// read so far, so that the buffered body is always accounted for: each
+// chunk is acquired on top of the admissions of the previous ones, which are
+// held until the whole body is released.
+func (ac *admissionControl) acquireBody(r *http.Request) (ReleaseFunc, error) {
+	var body bytes.Buffer
+	var releases []ReleaseFunc
+	release := ReleaseFunc(func() {
+		for _, release := range releases {
+			release()
+		}
+	})
+	for {
+		read := uint64(body.Len())
+		weight := min(read+chunkSize, ac.maxLimitAdmit)
+		if weight == read {
+			// The body reached the request limit, it is too large if
+			// anything is left of it.
+			n, err := io.CopyN(io.Discard, r.Body, 1)
+			if n > 0 {
+				release()
+				ac.recordRejected(r.Context(), admission2.ReasonTooLarge)
+				return nil, admission2.ErrRequestTooLarge
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				release()
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			break
+		}
+
+		chunk, err := ac.Acquire(r.Context(), weight-read)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, chunk)
+		_, err = io.CopyN(&body, r.Body, int64(weight-read))
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			release()
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	r.Body = io.NopCloser(&body)
+	return release, nil
+}
+
+// writeHTTPError translates the status errors returned by Acquire.
+func (ac *admissionControl) writeHTTPError(w http.ResponseWriter, err error) {
+	code := http.StatusServiceUnavailable
+	switch status.Code(err) {
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+	case codes.InvalidArgument:
+		if errors.Is(err, admission2.ErrRequestTooLarge) {
+			code = http.StatusRequestEntityTooLarge
+		} else {
+			code = http.StatusBadRequest
+		}
+	}
+	if ac.retryAfter > 0 && (code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable) {
+		w.Header().Set(headerRetryAfter, strconv.Itoa(int(math.Ceil(ac.retryAfter.Seconds()))))
+	}
+	http.Error(w, status.Convert(err).Message(), code)
+}
+
+// GetGRPCServerOptions implements extensionmiddleware.GRPCServer. Unary
+// requests are weighted by the size of their decoded message. Streams aren't
+// limited, as their messages can't be weighted before the stream is accepted.
+func (ac *admissionControl) GetGRPCServerOptions() ([]grpc.ServerOption, error) {
+	return []grpc.ServerOption{grpc.ChainUnaryInterceptor(ac.unaryServerInterceptor)}, nil
+}
+
+func (ac *admissionControl) unaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	release, err := ac.queue.Acquire(ctx, messageSize(req))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+// messageSize returns the encoded size of a gRPC request. Requests of an
+// unknown type are admitted without being accounted for.
+func messageSize(req any) uint64 {
+	switch msg := req.(type) {
+	case proto.Message:
+		return uint64(proto.Size(msg))
+	case interface{ Size() int }:
+		// gogoproto generated messages, such as the OTLP requests of pdata
+		return uint64(msg.Size())
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuflogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/protobuflogencodingextension"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	errNoDescriptorSet = errors.New("no descriptor_set provided")
+	errNoMessage       = errors.New("no message provided")
+)
+
+// timestampUnits are the units of integer timestamp fields.
+var timestampUnits = map[string]time.Duration{
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+type Config struct {
+	// DescriptorSet is the path of a binary FileDescriptorSet, as written by
+	// protoc --descriptor_set_out --include_imports.
+	DescriptorSet string `mapstructure:"descriptor_set"`
+
+	// Message is the fully qualified name of the message type of the logs.
+	Message string `mapstructure:"message"`
+
+	// Delimited sets whether messages are prefixed with their varint encoded
+	// length, allowing several messages per payload.
+	Delimited bool `mapstructure:"delimited"`
+
+	// Fields maps fields of the message to the fields of the log record.
+	Fields FieldsConfig `mapstructure:"fields"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// FieldsConfig holds the paths of the message fields mapped to the fields of
+// the log record. Paths are field names, with nested message fields separated
+// by dots.
+type FieldsConfig struct {
+	// Timestamp is a google.protobuf.Timestamp, an integer in TimestampUnit
+	// since the epoch, or an RFC 3339 string.
+	Timestamp     string `mapstructure:"timestamp"`
+	TimestampUnit string `mapstructure:"timestamp_unit"`
+
+	// Severity is an integer severity number, or an enum or string severity
+	// text.
+	Severity string `mapstructure:"severity"`
+
+	// TraceID and SpanID are bytes or hex encoded strings.
+	TraceID string `mapstructure:"trace_id"`
+	SpanID  string `mapstructure:"span_id"`
+
+	// Attributes are copied to the attributes of the log record, keyed by
+	// their path.
+	Attributes []string `mapstructure:"attributes"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (c *Config) Validate() error {
+	if c.DescriptorSet == "" {
+		return errNoDescriptorSet
+	}
+	if c.Message == "" {
+		return errNoMessage
+	}
+	if _, ok := timestampUnits[c.Fields.TimestampUnit]; !ok {
+		return fmt.Errorf("invalid timestamp_unit %q, must be one of s, ms, us or ns", c.Fields.TimestampUnit)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuflogencodingextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.ErrorIs(t, cfg.Validate(), errNoDescriptorSet)
+
+	cfg.DescriptorSet = "testdata/event.pb"
+	assert.ErrorIs(t, cfg.Validate(), errNoMessage)
+
+	cfg.Message = "example.v1.Event"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Fields.TimestampUnit = "m"
+	assert.ErrorContains(t, cfg.Validate(), `invalid timestamp_unit "m"`)
+}
@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuflogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/protobuflogencodingextension"
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageToMap copies the populated fields of a message to a map, keyed by
+// field name. Enums are converted to their value names and nested messages to
+// maps.
+func messageToMap(msg protoreflect.Message, dest pcommon.Map) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fieldToValue(fd, v, dest.PutEmpty(string(fd.Name())))
+		return true
+	})
+}
+
+func fieldToValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, dest pcommon.Value) {
+	switch {
+	case fd.IsList():
+		s := dest.SetEmptySlice()
+		l := v.List()
+		for i := 0; i < l.Len(); i++ {
+			singularToValue(fd, l.Get(i), s.AppendEmpty())
+		}
+	case fd.IsMap():
+		m := dest.SetEmptyMap()
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			singularToValue(fd.MapValue(), v, m.PutEmpty(k.String()))
+			return true
+		})
+	default:
+		singularToValue(fd, v, dest)
+	}
+}
+
+func singularToValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, dest pcommon.Value) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		dest.SetBool(v.Bool())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		dest.SetInt(v.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		dest.SetInt(int64(v.Uint()))
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		dest.SetDouble(v.Float())
+	case protoreflect.StringKind:
+		dest.SetStr(v.String())
+	case protoreflect.BytesKind:
+		dest.SetEmptyBytes().FromRaw(v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			dest.SetStr(string(ev.Name()))
+		} else {
+			dest.SetInt(int64(v.Enum()))
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		messageToMap(v.Message(), dest.SetEmptyMap())
+	}
+}
+
+// mapToMessage sets the fields of a message from a map keyed by field name or
+// JSON name, the reverse of messageToMap.
+func mapToMessage(src pcommon.Map, msg protoreflect.Message) error {
+	fields := msg.Descriptor().Fields()
+	for k, v := range src.All() {
+		fd := fields.ByName(protoreflect.Name(k))
+		if fd == nil {
+			fd = fields.ByJSONName(k)
+		}
+		if fd == nil {
+			return fmt.Errorf("message %s has no field %q", msg.Descriptor().FullName(), k)
+		}
+		if err := setField(msg, fd, v); err != nil {
+			return fmt.Errorf("field %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+func setField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, v pcommon.Value) error {
+	switch {
+	case fd.IsList():
+		if v.Type() != pcommon.ValueTypeSlice {
+			return fmt.Errorf("expected a slice, got %s", v.Type())
+		}
+		l := msg.Mutable(fd).List()
+		for _, elem := range v.Slice().All() {
+			pv, err := valueToSingular(fd, elem, l.NewElement)
+			if err != nil {
+				return err
+			}
+			l.Append(pv)
+		}
+	case fd.IsMap():
+		if v.Type() != pcommon.ValueTypeMap {
+			return fmt.Errorf("expected a map, got %s", v.Type())
+		}
+		m := msg.Mutable(fd).Map()
+		for k, elem := range v.Map().All() {
+			key, err := valueToSingular(fd.MapKey(), pcommon.NewValueStr(k), nil)
+			if err != nil {
+				return fmt.Errorf("key %q: %w", k, err)
+			}
+			pv, err := valueToSingular(fd.MapValue(), elem, m.NewValue)
+			if err != nil {
+				return fmt.Errorf("key %q: %w", k, err)
+			}
+			m.Set(key.MapKey(), pv)
+		}
+	default:
+		pv, err := valueToSingular(fd, v, func() protoreflect.Value { return msg.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, pv)
+	}
+	return nil
+}
+
+// valueToSingular converts a value to the kind of a field, parsing strings
+// where needed. newMessage returns an empty message of message fields.
+func valueToSingular(fd protoreflect.FieldDescriptor, v pcommon.Value, newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		switch v.Type() {
+		case pcommon.ValueTypeBool:
+			return protoreflect.ValueOfBool(v.Bool()), nil
+		case pcommon.ValueTypeStr:
+			b, err := strconv.ParseBool(v.Str())
+			return protoreflect.ValueOfBool(b), err
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := toInt(v, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := toInt(v, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := toUint(v, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := toUint(v, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		var f float64
+		switch v.Type() {
+		case pcommon.ValueTypeDouble:
+			f = v.Double()
+		case pcommon.ValueTypeInt:
+			f = float64(v.Int())
+		case pcommon.ValueTypeStr:
+			var err error
+			if f, err = strconv.ParseFloat(v.Str(), 64); err != nil {
+				return protoreflect.Value{}, err
+			}
+		default:
+			return protoreflect.Value{}, fmt.Errorf("cannot convert %s to %s", v.Type(), fd.Kind())
+		}
+		if fd.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(f)), nil
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(v.AsString()), nil
+	case protoreflect.BytesKind:
+		switch v.Type() {
+		case pcommon.ValueTypeBytes:
+			return protoreflect.ValueOfBytes(v.Bytes().AsRaw()), nil
+		case pcommon.ValueTypeStr:
+			b, err := base64.StdEncoding.DecodeString(v.Str())
+			return protoreflect.ValueOfBytes(b), err
+		}
+	case protoreflect.EnumKind:
+		switch v.Type() {
+		case pcommon.ValueTypeStr:
+			ev := fd.Enum().Values().ByName(protoreflect.Name(v.Str()))
+			if ev == nil {
+				return protoreflect.Value{}, fmt.Errorf("enum %s has no value %q", fd.Enum().FullName(), v.Str())
+			}
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		case pcommon.ValueTypeInt:
+			n, err := toInt(v, 32)
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if v.Type() == pcommon.ValueTypeMap {
+			msg := newMessage()
+			return msg, mapToMessage(v.Map(), msg.Message())
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("cannot convert %s to %s", v.Type(), fd.Kind())
+}
+
+func toInt(v pcommon.Value, bitSize int) (int64, error) {
+	switch v.Type() {
+	case pcommon.ValueTypeInt:
+		n := v.Int()
+		if bitSize == 32 && (n < math.MinInt32 || n > math.MaxInt32) {
+			return 0, fmt.Errorf("%d overflows int32", n)
+		}
+		return n, nil
+	case pcommon.ValueTypeDouble:
+		f := v.Double()
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("%v is not an integer", f)
+		}
+		return toInt(pcommon.NewValueInt(int64(f)), bitSize)
+	case pcommon.ValueTypeStr:
+		return strconv.ParseInt(v.Str(), 10, bitSize)
+	}
+	return 0, fmt.Errorf("cannot convert %s to an integer", v.Type())
+}
+
+func toUint(v pcommon.Value, bitSize int) (uint64, error) {
+	if v.Type() == pcommon.ValueTypeStr {
+		return strconv.ParseUint(v.Str(), 10, bitSize)
+	}
+	// unsigned 64 bits integers are stored as int64 by messageToMap
+	n, err := toInt(v, 64)
+	if err != nil {
+		return 0, err
+	}
+	if bitSize == 32 && (n < 0 || n > math.MaxUint32) {
+		return 0, fmt.Errorf("%d overflows uint32", n)
+	}
+	return uint64(n), nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuflogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/protobuflogencodingextension"
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// loadMessageDescriptor reads a FileDescriptorSet and looks up the
+// descriptor of the named message.
+func loadMessageDescriptor(path, name string) (protoreflect.MessageDescriptor, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(buf, &set); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal descriptor set %q: %w", path, err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set %q: %w", path, err)
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("message %q not found in descriptor set %q: %w", name, path, err)
+	}
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a message", name)
+	}
+	return md, nil
+}
+
+// fieldPath is the chain of fields leading from a message to a nested field.
+type fieldPath []protoreflect.FieldDescriptor
+
+// resolveFieldPath resolves a dot separated path of field names, where all
+// but the last field are singular message fields.
+func resolveFieldPath(md protoreflect.MessageDescriptor, path string) (fieldPath, error) {
+	var fp fieldPath
+	for _, name := range strings.Split(path, ".") {
+		if len(fp) > 0 {
+			prev := fp[len(fp)-1]
+			if prev.Message() == nil || prev.IsList() || prev.IsMap() {
+				return nil, fmt.Errorf("field %q of path %q is not a singular message", prev.Name(), path)
+			}
+			md = prev.Message()
+		}
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("message %s has no field %q", md.FullName(), name)
+		}
+		fp = append(fp, fd)
+	}
+	return fp, nil
+}
+
+func (p fieldPath) field() protoreflect.FieldDescriptor {
+	return p[len(p)-1]
+}
+
+// get returns the value of the field in the message, and whether it is set.
+func (p fieldPath) get(msg protoreflect.Message) (protoreflect.Value, bool) {
+	for _, fd := range p[:len(p)-1] {
+		if !msg.Has(fd) {
+			return protoreflect.Value{}, false
+		}
+		msg = msg.Get(fd).Message()
+	}
+	if !msg.Has(p.field()) {
+		return protoreflect.Value{}, false
+	}
+	return msg.Get(p.field()), true
+}
+
+// parent returns the message holding the field, populating the intermediate
+// messages.
+func (p fieldPath) parent(msg protoreflect.Message) protoreflect.Message {
+	for _, fd := range p[:len(p)-1] {
+		msg = msg.Mutable(fd).Message()
+	}
+	return msg
+}
+
+func (p fieldPath) String() string {
+	names := make([]string, len(p))
+	for i, fd := range p {
+		names[i] = string(fd.Name())
+	}
+	return strings.Join(names, ".")
+}
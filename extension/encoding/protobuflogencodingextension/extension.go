@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuflogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/protobuflogencodingextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.LogsMarshalerExtension   = (*protobufLogExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension = (*protobufLogExtension)(nil)
+)
+
+var errTooManyLogRecords = errors.New("only a single log record can be marshaled unless delimited is enabled")
+
+type protobufLogExtension struct {
+	config *Config
+
+	descriptor protoreflect.MessageDescriptor
+	mapping    *fieldMapping
+}
+
+func (e *protobufLogExtension) Start(context.Context, component.Host) error {
+	md, err := loadMessageDescriptor(e.config.DescriptorSet, e.config.Message)
+	if err != nil {
+		return err
+	}
+	mapping, err := newFieldMapping(md, e.config.Fields)
+	if err != nil {
+		return fmt.Errorf("invalid fields: %w", err)
+	}
+	e.descriptor = md
+	e.mapping = mapping
+	return nil
+}
+
+func (*protobufLogExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *protobufLogExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	p := plog.NewLogs()
+	lrs := p.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	if !e.config.Delimited {
+		return p, e.unmarshalMessage(buf, lrs.AppendEmpty(), now)
+	}
+	for len(buf) > 0 {
+		size, n := protowire.ConsumeVarint(buf)
+		if n < 0 {
+			return p, fmt.Errorf("failed to read message length: %w", protowire.ParseError(n))
+		}
+		if size > uint64(len(buf)-n) {
+			return p, fmt.Errorf("message length %d exceeds the remaining %d bytes", size, len(buf)-n)
+		}
+		if err := e.unmarshalMessage(buf[n:n+int(size)], lrs.AppendEmpty(), now); err != nil {
+			return p, err
+		}
+		buf = buf[n+int(size):]
+	}
+	return p, nil
+}
+
+// unmarshalMessage sets the body of the log record to the fields of the
+// message, then sets the mapped fields of the log record.
+func (e *protobufLogExtension) unmarshalMessage(buf []byte, lr plog.LogRecord, observed pcommon.Timestamp) error {
+	msg := dynamicpb.NewMessage(e.descriptor)
+	if err := proto.Unmarshal(buf, msg); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", e.descriptor.FullName(), err)
+	}
+	lr.SetObservedTimestamp(observed)
+	messageToMap(msg, lr.Body().SetEmptyMap())
+	return e.mapping.toLogRecord(msg, lr)
+}
+
+func (e *protobufLogExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	if !e.config.Delimited && ld.LogRecordCount() > 1 {
+		return nil, errTooManyLogRecords
+	}
+
+	var buf []byte
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				b, err := e.marshalLogRecord(lrs.At(k))
+				if err != nil {
+					return nil, err
+				}
+				if e.config.Delimited {
+					buf = protowire.AppendVarint(buf, uint64(len(b)))
+				}
+				buf = append(buf, b...)
+			}
+		}
+	}
+	return buf, nil
+}
+
+// marshalLogRecord sets the fields of the message from a map body, then from
+// the mapped fields of the log record.
+func (e *protobufLogExtension) marshalLogRecord(lr plog.LogRecord) ([]byte, error) {
+	msg := dynamicpb.NewMessage(e.descriptor)
+	switch lr.Body().Type() {
+	case pcommon.ValueTypeMap:
+		if err := mapToMessage(lr.Body().Map(), msg); err != nil {
+			return nil, err
+		}
+	case pcommon.ValueTypeEmpty:
+	default:
+		return nil, fmt.Errorf("cannot marshal a %s body to %s, expected a map", lr.Body().Type(), e.descriptor.FullName())
+	}
+	if err := e.mapping.fromLogRecord(lr, msg); err != nil {
+		return nil, err
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+}
@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuflogencodingextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var (
+	testTraceID = pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	testSpanID  = pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+)
+
+func newTestExtension(t *testing.T, configure func(*Config)) *protobufLogExtension {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DescriptorSet = "testdata/event.pb"
+	cfg.Message = "example.v1.Event"
+	if configure != nil {
+		configure(cfg)
+	}
+	e := &protobufLogExtension{config: cfg}
+	require.NoError(t, e.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, e.Shutdown(t.Context()))
+	})
+	return e
+}
+
+func mapFields(cfg *Config) {
+	cfg.Fields.Timestamp = "time"
+	cfg.Fields.Severity = "level"
+	cfg.Fields.TraceID = "trace.trace_id"
+	cfg.Fields.SpanID = "trace.span_id"
+	cfg.Fields.Attributes = []string{"service", "labels"}
+}
+
+// testEvent encodes an example.v1.Event, as defined in testdata/event.proto.
+func testEvent() []byte {
+	var ts []byte
+	ts = protowire.AppendTag(ts, 1, protowire.VarintType)
+	ts = protowire.AppendVarint(ts, 1700000000)
+	ts = protowire.AppendTag(ts, 2, protowire.VarintType)
+	ts = protowire.AppendVarint(ts, 500)
+
+	var trace []byte
+	trace = protowire.AppendTag(trace, 1, protowire.BytesType)
+	trace = protowire.AppendBytes(trace, testTraceID[:])
+	trace = protowire.AppendTag(trace, 2, protowire.BytesType)
+	trace = protowire.AppendString(trace, "0102030405060708")
+
+	var entry []byte
+	entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+	entry = protowire.AppendString(entry, "env")
+	entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+	entry = protowire.AppendString(entry, "prod")
+
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, ts)
+	b = protowire.AppendTag(b, 2, protowire.VarintType)
+	b = protowire.AppendVarint(b, 3)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	b = protowire.AppendString(b, "disk full")
+	b = protowire.AppendTag(b, 4, protowire.BytesType)
+	b = protowire.AppendBytes(b, trace)
+	b = protowire.AppendTag(b, 5, protowire.BytesType)
+	b = protowire.AppendBytes(b, entry)
+	b = protowire.AppendTag(b, 6, protowire.BytesType)
+	b = protowire.AppendString(b, "a")
+	b = protowire.AppendTag(b, 6, protowire.BytesType)
+	b = protowire.AppendString(b, "b")
+	b = protowire.AppendTag(b, 7, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1500)
+	b = protowire.AppendTag(b, 8, protowire.BytesType)
+	b = protowire.AppendBytes(b, []byte{0xff})
+	b = protowire.AppendTag(b, 9, protowire.BytesType)
+	b = protowire.AppendString(b, "checkout")
+	return b
+}
+
+func TestUnmarshalLogs(t *testing.T) {
+	e := newTestExtension(t, nil)
+
+	ld, err := e.UnmarshalLogs(testEvent())
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{
+		"time":    map[string]any{"seconds": int64(1700000000), "nanos": int64(500)},
+		"level":   "LEVEL_ERROR",
+		"message": "disk full",
+		"trace": map[string]any{
+			"trace_id": testTraceID[:],
+			"span_id":  "0102030405060708",
+		},
+		"labels":      map[string]any{"env": "prod"},
+		"tags":        []any{"a", "b"},
+		"duration_ms": int64(1500),
+		"payload":     []byte{0xff},
+		"service":     "checkout",
+	}, lr.Body().Map().AsRaw())
+	assert.NotZero(t, lr.ObservedTimestamp())
+	assert.Zero(t, lr.Timestamp())
+	assert.Zero(t, lr.Attributes().Len())
+}
+
+func TestUnmarshalLogsMappedFields(t *testing.T) {
+	e := newTestExtension(t, mapFields)
+
+	ld, err := e.UnmarshalLogs(testEvent())
+	require.NoError(t, err)
+
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Unix(1700000000, 500).UTC(), lr.Timestamp().AsTime())
+	assert.Equal(t, "LEVEL_ERROR", lr.SeverityText())
+	assert.Equal(t, plog.SeverityNumberError, lr.SeverityNumber())
+	assert.Equal(t, testTraceID, lr.TraceID())
+	assert.Equal(t, testSpanID, lr.SpanID())
+	assert.Equal(t, map[string]any{
+		"service": "checkout",
+		"labels":  map[string]any{"env": "prod"},
+	}, lr.Attributes().AsRaw())
+}
+
+func TestUnmarshalLogsIntegerTimestamp(t *testing.T) {
+	e := newTestExtension(t, func(cfg *Config) {
+		cfg.Fields.Timestamp = "duration_ms"
+		cfg.Fields.TimestampUnit = "ms"
+	})
+
+	ld, err := e.UnmarshalLogs(testEvent())
+	require.NoError(t, err)
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.Timestamp(1500*time.Millisecond), lr.Timestamp())
+}
+
+func TestUnmarshalLogsInvalid(t *testing.T) {
+	e := newTestExtension(t, nil)
+	_, err := e.UnmarshalLogs([]byte{0xff})
+	assert.ErrorContains(t, err, "failed to unmarshal example.v1.Event")
+
+	e = newTestExtension(t, func(cfg *Config) {
+		cfg.Delimited = true
+	})
+	_, err = e.UnmarshalLogs([]byte{10, 1})
+	assert.ErrorContains(t, err, "message length 10 exceeds the remaining 1 bytes")
+}
+
+func TestMarshalLogsRoundTrip(t *testing.T) {
+	e := newTestExtension(t, mapFields)
+
+	ld, err := e.UnmarshalLogs(testEvent())
+	require.NoError(t, err)
+	buf, err := e.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, testEvent(), buf)
+}
+
+func TestMarshalLogsMappedFields(t *testing.T) {
+	e := newTestExtension(t, mapFields)
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000000, 500)))
+	lr.SetSeverityText("LEVEL_WARN")
+	lr.SetTraceID(testTraceID)
+	lr.SetSpanID(testSpanID)
+	lr.Attributes().PutStr("service", "checkout")
+	lr.Attributes().PutStr("ignored", "value")
+	lr.Body().SetEmptyMap().PutStr("message", "disk full")
+
+	buf, err := e.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	ld, err = e.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	lr = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{
+		"time":    map[string]any{"seconds": int64(1700000000), "nanos": int64(500)},
+		"level":   "LEVEL_WARN",
+		"message": "disk full",
+		"trace": map[string]any{
+			"trace_id": testTraceID[:],
+			"span_id":  "0102030405060708",
+		},
+		"service": "checkout",
+	}, lr.Body().Map().AsRaw())
+}
+
+func TestMarshalLogsDelimited(t *testing.T) {
+	e := newTestExtension(t, func(cfg *Config) {
+		cfg.Delimited = true
+	})
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetEmptyMap().PutStr("message", "first")
+	lrs.AppendEmpty().Body().SetEmptyMap().PutStr("message", "second")
+	lrs.AppendEmpty()
+
+	buf, err := e.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	ld, err = e.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	lrs = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, lrs.Len())
+	assert.Equal(t, map[string]any{"message": "first"}, lrs.At(0).Body().Map().AsRaw())
+	assert.Equal(t, map[string]any{"message": "second"}, lrs.At(1).Body().Map().AsRaw())
+	assert.Equal(t, map[string]any{}, lrs.At(2).Body().Map().AsRaw())
+}
+
+func TestMarshalLogsErrors(t *testing.T) {
+	e := newTestExtension(t, nil)
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetStr("disk full")
+	_, err := e.MarshalLogs(ld)
+	assert.ErrorContains(t, err, "cannot marshal a Str body to example.v1.Event, expected a map")
+
+	lrs.At(0).Body().SetEmptyMap().PutStr("unknown", "value")
+	_, err = e.MarshalLogs(ld)
+	assert.ErrorContains(t, err, `message example.v1.Event has no field "unknown"`)
+
+	lrs.At(0).Body().SetEmptyMap().PutStr("level", "LEVEL_DEBUG")
+	_, err = e.MarshalLogs(ld)
+	assert.ErrorContains(t, err, `enum example.v1.Level has no value "LEVEL_DEBUG"`)
+
+	lrs.AppendEmpty()
+	_, err = e.MarshalLogs(ld)
+	assert.ErrorIs(t, err, errTooManyLogRecords)
+}
+
+func TestStartErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*Config)
+		expected  string
+	}{
+		{
+			name:      "missing descriptor set",
+			configure: func(cfg *Config) { cfg.DescriptorSet = "testdata/missing.pb" },
+			expected:  "failed to read descriptor set",
+		},
+		{
+			name:      "unknown message",
+			configure: func(cfg *Config) { cfg.Message = "example.v1.Unknown" },
+			expected:  `message "example.v1.Unknown" not found`,
+		},
+		{
+			name:      "not a message",
+			configure: func(cfg *Config) { cfg.Message = "example.v1.Level" },
+			expected:  `"example.v1.Level" is not a message`,
+		},
+		{
+			name:      "unknown field",
+			configure: func(cfg *Config) { cfg.Fields.TraceID = "trace.id" },
+			expected:  `trace_id: message example.v1.Trace has no field "id"`,
+		},
+		{
+			name:      "unsupported field type",
+			configure: func(cfg *Config) { cfg.Fields.Severity = "trace" },
+			expected:  `severity: unsupported type of field "trace"`,
+		},
+		{
+			name:      "field of a repeated field",
+			configure: func(cfg *Config) { cfg.Fields.Attributes = []string{"tags.value"} },
+			expected:  `attributes: field "tags" of path "tags.value" is not a singular message`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.DescriptorSet = "testdata/event.pb"
+			cfg.Message = "example.v1.Event"
+			tt.configure(cfg)
+			e := &protobufLogExtension{config: cfg}
+			assert.ErrorContains(t, e.Start(t.Context(), componenttest.NewNopHost()), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuflogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/protobuflogencodingextension"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const timestampMessage protoreflect.FullName = "google.protobuf.Timestamp"
+
+// severityNames maps the usual severity texts, and the suffixes of enum
+// values such as LEVEL_ERROR, to severity numbers.
+var severityNames = map[string]plog.SeverityNumber{
+	"TRACE":    plog.SeverityNumberTrace,
+	"DEBUG":    plog.SeverityNumberDebug,
+	"INFO":     plog.SeverityNumberInfo,
+	"WARN":     plog.SeverityNumberWarn,
+	"WARNING":  plog.SeverityNumberWarn,
+	"ERROR":    plog.SeverityNumberError,
+	"FATAL":    plog.SeverityNumberFatal,
+	"CRITICAL": plog.SeverityNumberFatal,
+}
+
+// fieldMapping maps fields of the message to the fields of the log record.
+type fieldMapping struct {
+	timestamp     fieldPath
+	timestampUnit time.Duration
+	severity      fieldPath
+	traceID       fieldPath
+	spanID        fieldPath
+	attributes    []fieldPath
+}
+
+func newFieldMapping(md protoreflect.MessageDescriptor, cfg FieldsConfig) (*fieldMapping, error) {
+	m := &fieldMapping{timestampUnit: timestampUnits[cfg.TimestampUnit]}
+
+	var err error
+	if m.timestamp, err = resolveMappedField(md, cfg.Timestamp, "timestamp", isTimestampField); err != nil {
+		return nil, err
+	}
+	if m.severity, err = resolveMappedField(md, cfg.Severity, "severity", isSeverityField); err != nil {
+		return nil, err
+	}
+	if m.traceID, err = resolveMappedField(md, cfg.TraceID, "trace_id", isIDField); err != nil {
+		return nil, err
+	}
+	if m.spanID, err = resolveMappedField(md, cfg.SpanID, "span_id", isIDField); err != nil {
+		return nil, err
+	}
+	for _, path := range cfg.Attributes {
+		fp, err := resolveFieldPath(md, path)
+		if err != nil {
+			return nil, fmt.Errorf("attributes: %w", err)
+		}
+		m.attributes = append(m.attributes, fp)
+	}
+	return m, nil
+}
+
+func resolveMappedField(md protoreflect.MessageDescriptor, path, name string, supported func(protoreflect.FieldDescriptor) bool) (fieldPath, error) {
+	if path == "" {
+		return nil, nil
+	}
+	fp, err := resolveFieldPath(md, path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if fd := fp.field(); fd.IsList() || fd.IsMap() || !supported(fd) {
+		return nil, fmt.Errorf("%s: unsupported type of field %q", name, path)
+	}
+	return fp, nil
+}
+
+func isTimestampField(fd protoreflect.FieldDescriptor) bool {
+	return isInteger(fd.Kind()) || fd.Kind() == protoreflect.StringKind ||
+		(fd.Message() != nil && fd.Message().FullName() == timestampMessage)
+}
+
+func isSeverityField(fd protoreflect.FieldDescriptor) bool {
+	return isInteger(fd.Kind()) || fd.Kind() == protoreflect.EnumKind || fd.Kind() == protoreflect.StringKind
+}
+
+func isIDField(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.BytesKind || fd.Kind() == protoreflect.StringKind
+}
+
+func isInteger(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	}
+	return false
+}
+
+// toLogRecord sets the fields of the log record from the mapped fields of the
+// message.
+func (m *fieldMapping) toLogRecord(msg protoreflect.Message, lr plog.LogRecord) error {
+	if v, ok := m.get(m.timestamp, msg); ok {
+		ts, err := m.toTimestamp(m.timestamp.field(), v)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp: %w", err)
+		}
+		lr.SetTimestamp(ts)
+	}
+	if v, ok := m.get(m.severity, msg); ok {
+		fd := m.severity.field()
+		switch {
+		case isInteger(fd.Kind()):
+			lr.SetSeverityNumber(severityNumberFromInt(integerValue(fd, v)))
+		default:
+			text := v.String()
+			if fd.Kind() == protoreflect.EnumKind {
+				if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+					text = string(ev.Name())
+				}
+			}
+			lr.SetSeverityText(text)
+			lr.SetSeverityNumber(severityNumber(text))
+		}
+	}
+	if v, ok := m.get(m.traceID, msg); ok {
+		var id pcommon.TraceID
+		if err := decodeID(m.traceID.field(), v, id[:]); err != nil {
+			return fmt.Errorf("invalid trace ID: %w", err)
+		}
+		lr.SetTraceID(id)
+	}
+	if v, ok := m.get(m.spanID, msg); ok {
+		var id pcommon.SpanID
+		if err := decodeID(m.spanID.field(), v, id[:]); err != nil {
+			return fmt.Errorf("invalid span ID: %w", err)
+		}
+		lr.SetSpanID(id)
+	}
+	for _, fp := range m.attributes {
+		if v, ok := fp.get(msg); ok {
+			fieldToValue(fp.field(), v, lr.Attributes().PutEmpty(fp.String()))
+		}
+	}
+	return nil
+}
+
+func (*fieldMapping) get(fp fieldPath, msg protoreflect.Message) (protoreflect.Value, bool) {
+	if fp == nil {
+		return protoreflect.Value{}, false
+	}
+	return fp.get(msg)
+}
+
+func (m *fieldMapping) toTimestamp(fd protoreflect.FieldDescriptor, v protoreflect.Value) (pcommon.Timestamp, error) {
+	switch {
+	case fd.Kind() == protoreflect.StringKind:
+		t, err := time.Parse(time.RFC3339Nano, v.String())
+		return pcommon.NewTimestampFromTime(t), err
+	case fd.Message() != nil:
+		ts := v.Message()
+		fields := ts.Descriptor().Fields()
+		seconds := ts.Get(fields.ByName("seconds")).Int()
+		nanos := ts.Get(fields.ByName("nanos")).Int()
+		return pcommon.NewTimestampFromTime(time.Unix(seconds, nanos)), nil
+	default:
+		return pcommon.Timestamp(integerValue(fd, v) * int64(m.timestampUnit)), nil
+	}
+}
+
+// integerValue returns the value of a signed or unsigned integer field.
+func integerValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) int64 {
+	switch fd.Kind() {
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(v.Uint())
+	}
+	return v.Int()
+}
+
+// severityNumberFromInt returns the severity number, or an unspecified one
+// when the number is outside of the range defined by OpenTelemetry.
+func severityNumberFromInt(n int64) plog.SeverityNumber {
+	if n < int64(plog.SeverityNumberTrace) || n > int64(plog.SeverityNumberFatal4) {
+		return plog.SeverityNumberUnspecified
+	}
+	return plog.SeverityNumber(n)
+}
+
+func severityNumber(text string) plog.SeverityNumber {
+	text = strings.ToUpper(text)
+	if i := strings.LastIndexByte(text, '_'); i >= 0 {
+		text = text[i+1:]
+	}
+	return severityNames[text]
+}
+
+func decodeID(fd protoreflect.FieldDescriptor, v protoreflect.Value, dest []byte) error {
+	b := v.Bytes()
+	if fd.Kind() == protoreflect.StringKind {
+		var err error
+		if b, err = hex.DecodeString(v.String()); err != nil {
+			return err
+		}
+	}
+	if len(b) != len(dest) {
+		return fmt.Errorf("expected %d bytes, got %d", len(dest), len(b))
+	}
+	copy(dest, b)
+	return nil
+}
+
+// fromLogRecord sets the mapped fields of the message from the fields of the
+// log record that are set.
+func (m *fieldMapping) fromLogRecord(lr plog.LogRecord, msg protoreflect.Message) error {
+	if m.timestamp != nil && lr.Timestamp() != 0 {
+		if err := m.fromTimestamp(lr.Timestamp(), msg); err != nil {
+			return fmt.Errorf("timestamp: %w", err)
+		}
+	}
+	if m.severity != nil {
+		fd := m.severity.field()
+		switch {
+		case isInteger(fd.Kind()):
+			if lr.SeverityNumber() != plog.SeverityNumberUnspecified {
+				if err := setField(m.severity.parent(msg), fd, pcommon.NewValueInt(int64(lr.SeverityNumber()))); err != nil {
+					return fmt.Errorf("severity: %w", err)
+				}
+			}
+		case fd.Kind() == protoreflect.EnumKind && fd.Enum().Values().ByName(protoreflect.Name(lr.SeverityText())) == nil:
+			// the severity text is not a value of the enum
+		case lr.SeverityText() != "":
+			if err := setField(m.severity.parent(msg), fd, pcommon.NewValueStr(lr.SeverityText())); err != nil {
+				return fmt.Errorf("severity: %w", err)
+			}
+		}
+	}
+	if m.traceID != nil && !lr.TraceID().IsEmpty() {
+		id := lr.TraceID()
+		setID(m.traceID, msg, id[:])
+	}
+	if m.spanID != nil && !lr.SpanID().IsEmpty() {
+		id := lr.SpanID()
+		setID(m.spanID, msg, id[:])
+	}
+	for _, fp := range m.attributes {
+		if v, ok := lr.Attributes().Get(fp.String()); ok {
+			if err := setField(fp.parent(msg), fp.field(), v); err != nil {
+				return fmt.Errorf("attribute %q: %w", fp.String(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *fieldMapping) fromTimestamp(ts pcommon.Timestamp, msg protoreflect.Message) error {
+	parent := m.timestamp.parent(msg)
+	fd := m.timestamp.field()
+	switch {
+	case fd.Kind() == protoreflect.StringKind:
+		return setField(parent, fd, pcommon.NewValueStr(ts.AsTime().Format(time.RFC3339Nano)))
+	case fd.Message() != nil:
+		t := ts.AsTime()
+		dest := parent.Mutable(fd).Message()
+		fields := dest.Descriptor().Fields()
+		dest.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(t.Unix()))
+		dest.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+		return nil
+	default:
+		return setField(parent, fd, pcommon.NewValueInt(int64(ts)/int64(m.timestampUnit)))
+	}
+}
+
+func setID(fp fieldPath, msg protoreflect.Message, id []byte) {
+	v := protoreflect.ValueOfBytes(id)
+	if fp.field().Kind() == protoreflect.StringKind {
+		v = protoreflect.ValueOfString(hex.EncodeToString(id))
+	}
+	fp.parent(msg).Set(fp.field(), v)
+}
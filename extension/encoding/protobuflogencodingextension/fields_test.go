@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuflogencodingextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestSeverityNumber(t *testing.T) {
+	for text, expected := range map[string]plog.SeverityNumber{
+		"debug":             plog.SeverityNumberDebug,
+		"Warning":           plog.SeverityNumberWarn,
+		"LEVEL_ERROR":       plog.SeverityNumberError,
+		"SEVERITY_CRITICAL": plog.SeverityNumberFatal,
+		"LEVEL_UNSPECIFIED": plog.SeverityNumberUnspecified,
+		"":                  plog.SeverityNumberUnspecified,
+	} {
+		assert.Equal(t, expected, severityNumber(text), text)
+	}
+}
+
+func TestSeverityNumberFromInt(t *testing.T) {
+	for n, expected := range map[int64]plog.SeverityNumber{
+		1:       plog.SeverityNumberTrace,
+		13:      plog.SeverityNumberWarn,
+		24:      plog.SeverityNumberFatal4,
+		0:       plog.SeverityNumberUnspecified,
+		25:      plog.SeverityNumberUnspecified,
+		-1:      plog.SeverityNumberUnspecified,
+		1 << 40: plog.SeverityNumberUnspecified,
+	} {
+		assert.Equal(t, expected, severityNumberFromInt(n), n)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/securitylogencodingextension"
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	cefPrefix = "CEF:"
+
+	cefAttributePrefix        = "cef."
+	cefVersionAttribute       = "cef.version"
+	cefDeviceVendorAttribute  = "cef.device_vendor"
+	cefDeviceProductAttribute = "cef.device_product"
+	cefDeviceVersionAttribute = "cef.device_version"
+	cefSignatureIDAttribute   = "cef.signature_id"
+	cefNameAttribute          = "cef.name"
+	cefSeverityAttribute      = "cef.severity"
+	cefExtensionsAttribute    = "cef.extensions"
+
+	// cefReceiptTime is the extension key of the time the event was received.
+	cefReceiptTime = "rt"
+
+	cefHeaderFields = 7
+)
+
+var (
+	errInvalidCEFHeader = errors.New("invalid CEF header, expected 7 fields delimited by '|'")
+
+	cefSeverityNames = map[string]plog.SeverityNumber{
+		"low":       plog.SeverityNumberInfo,
+		"medium":    plog.SeverityNumberWarn,
+		"high":      plog.SeverityNumberError,
+		"very-high": plog.SeverityNumberFatal,
+	}
+
+	cefValueEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+// unmarshalCEF sets the attributes of the log record from a CEF event, which
+// starts with the CEF: prefix.
+func unmarshalCEF(event string, lr plog.LogRecord) error {
+	fields, extension, ok := splitHeader(strings.TrimPrefix(event, cefPrefix), cefHeaderFields)
+	if !ok {
+		return errInvalidCEFHeader
+	}
+
+	attrs := lr.Attributes()
+	attrs.PutStr(cefVersionAttribute, fields[0])
+	attrs.PutStr(cefDeviceVendorAttribute, fields[1])
+	attrs.PutStr(cefDeviceProductAttribute, fields[2])
+	attrs.PutStr(cefDeviceVersionAttribute, fields[3])
+	attrs.PutStr(cefSignatureIDAttribute, fields[4])
+	attrs.PutStr(cefNameAttribute, fields[5])
+	attrs.PutStr(cefSeverityAttribute, fields[6])
+
+	severity := strings.TrimSpace(fields[6])
+	lr.SetSeverityText(severity)
+	if n, err := strconv.Atoi(severity); err == nil {
+		lr.SetSeverityNumber(severityNumber(n))
+	} else if number, ok := cefSeverityNames[strings.ToLower(severity)]; ok {
+		lr.SetSeverityNumber(number)
+	}
+
+	extensions := attrs.PutEmptyMap(cefExtensionsAttribute)
+	parseCEFExtension(extension, extensions)
+	if rt, ok := extensions.Get(cefReceiptTime); ok {
+		if t, ok := parseTime(rt.Str()); ok {
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(t))
+		}
+	}
+	return nil
+}
+
+// parseCEFExtension parses the space delimited key=value pairs of a CEF
+// extension. Values may contain spaces, so a value ends at the last space
+// before the next unescaped equal sign.
+func parseCEFExtension(s string, dest pcommon.Map) {
+	type pair struct{ keyStart, eq int }
+	var pairs []pair
+	boundary := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '=':
+			segment := s[boundary:i]
+			space := strings.LastIndexByte(segment, ' ')
+			if space < 0 && len(pairs) > 0 {
+				// an unescaped equal sign within a value
+				continue
+			}
+			keyStart := boundary + space + 1
+			if keyStart == i {
+				continue
+			}
+			pairs = append(pairs, pair{keyStart: keyStart, eq: i})
+			boundary = i + 1
+		}
+	}
+
+	for j, p := range pairs {
+		end := len(s)
+		if j+1 < len(pairs) {
+			end = pairs[j+1].keyStart
+		}
+		value := strings.TrimRight(s[p.eq+1:end], " ")
+		dest.PutStr(s[p.keyStart:p.eq], unescapeCEFValue(value))
+	}
+}
+
+func unescapeCEFValue(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case '\\', '=':
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// marshalCEF formats a log record as a CEF event. The header fields and
+// extension are taken from the attributes set when unmarshaling, falling back
+// to the configured header fields and the other attributes of the record.
+func marshalCEF(lr plog.LogRecord, config *Config) string {
+	attrs := lr.Attributes()
+
+	name := ""
+	if lr.Body().Type() == pcommon.ValueTypeStr {
+		name = lr.Body().Str()
+	}
+	header := []string{
+		stringAttribute(attrs, cefVersionAttribute, "0"),
+		stringAttribute(attrs, cefDeviceVendorAttribute, config.Vendor),
+		stringAttribute(attrs, cefDeviceProductAttribute, config.Product),
+		stringAttribute(attrs, cefDeviceVersionAttribute, config.ProductVersion),
+		stringAttribute(attrs, cefSignatureIDAttribute, "0"),
+		stringAttribute(attrs, cefNameAttribute, name),
+		stringAttribute(attrs, cefSeverityAttribute, strconv.Itoa(severityScale(lr.SeverityNumber()))),
+	}
+
+	var b strings.Builder
+	b.WriteString(cefPrefix)
+	for _, field := range header {
+		b.WriteString(headerEscaper.Replace(field))
+		b.WriteByte('|')
+	}
+
+	extensions := extensionAttributes(attrs, cefExtensionsAttribute, cefAttributePrefix)
+	first := true
+	for k, v := range extensions.All() {
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		b.WriteString(sanitizeKey(k))
+		b.WriteByte('=')
+		b.WriteString(cefValueEscaper.Replace(v.AsString()))
+	}
+	if _, ok := extensions.Get(cefReceiptTime); !ok && lr.Timestamp() != 0 {
+		if !first {
+			b.WriteByte(' ')
+		}
+		b.WriteString(cefReceiptTime)
+		b.WriteByte('=')
+		b.WriteString(strconv.FormatInt(lr.Timestamp().AsTime().UnixMilli(), 10))
+	}
+	return b.String()
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestUnmarshalCEF(t *testing.T) {
+	lr := plog.NewLogRecord()
+	err := unmarshalCEF(`CEF:0|Security|threat\|manager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 msg=Detected a threat. No action needed\= rt=1700000000000`, lr)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"cef.version":        "0",
+		"cef.device_vendor":  "Security",
+		"cef.device_product": "threat|manager",
+		"cef.device_version": "1.0",
+		"cef.signature_id":   "100",
+		"cef.name":           "worm successfully stopped",
+		"cef.severity":       "10",
+		"cef.extensions": map[string]any{
+			"src": "10.0.0.1",
+			"dst": "2.1.2.2",
+			"msg": "Detected a threat. No action needed=",
+			"rt":  "1700000000000",
+		},
+	}, lr.Attributes().AsRaw())
+	assert.Equal(t, plog.SeverityNumberFatal, lr.SeverityNumber())
+	assert.Equal(t, "10", lr.SeverityText())
+	assert.Equal(t, time.UnixMilli(1700000000000).UTC(), lr.Timestamp().AsTime())
+}
+
+func TestUnmarshalCEFSeverityName(t *testing.T) {
+	lr := plog.NewLogRecord()
+	require.NoError(t, unmarshalCEF("CEF:1|Vendor|Product|2.0|login|Login failed|Medium|", lr))
+	assert.Equal(t, plog.SeverityNumberWarn, lr.SeverityNumber())
+	assert.Equal(t, "Medium", lr.SeverityText())
+	assert.Zero(t, lr.Timestamp())
+}
+
+func TestUnmarshalCEFInvalidHeader(t *testing.T) {
+	err := unmarshalCEF("CEF:0|Vendor|Product|1.0", plog.NewLogRecord())
+	assert.ErrorIs(t, err, errInvalidCEFHeader)
+}
+
+func TestParseCEFExtension(t *testing.T) {
+	tests := []struct {
+		name      string
+		extension string
+		expected  map[string]any
+	}{
+		{
+			name:      "empty",
+			extension: "",
+			expected:  map[string]any{},
+		},
+		{
+			name:      "values with spaces",
+			extension: "act=blocked a file suser=John Doe",
+			expected:  map[string]any{"act": "blocked a file", "suser": "John Doe"},
+		},
+		{
+			name:      "escaped characters",
+			extension: `msg=line one\nline two\r path=C:\\temp\=x`,
+			expected:  map[string]any{"msg": "line one\nline two\r", "path": `C:\temp=x`},
+		},
+		{
+			name:      "unescaped equal sign within a value",
+			extension: "cs1=a=b cs2=c",
+			expected:  map[string]any{"cs1": "a=b", "cs2": "c"},
+		},
+		{
+			name:      "leading and trailing spaces",
+			extension: "  src=10.0.0.1  ",
+			expected:  map[string]any{"src": "10.0.0.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pcommon.NewMap()
+			parseCEFExtension(tt.extension, m)
+			assert.Equal(t, tt.expected, m.AsRaw())
+		})
+	}
+}
+
+func TestMarshalCEF(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	lr := plog.NewLogRecord()
+	lr.Body().SetStr("user|login")
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(1700000000000)))
+	lr.Attributes().PutStr("user.name", "John Doe")
+	lr.Attributes().PutStr("query", `a=b\c`)
+	assert.Equal(t,
+		`CEF:0|OpenTelemetry|Collector|1.0|0|user\|login|7|user.name=John Doe query=a\=b\\c rt=1700000000000`,
+		marshalCEF(lr, cfg))
+}
+
+func TestMarshalCEFRoundTrip(t *testing.T) {
+	event := `CEF:0|Security|threat\|manager|1.0|100|worm successfully stopped|10|src=10.0.0.1 msg=a\=b\nc rt=1700000000000`
+	lr := plog.NewLogRecord()
+	require.NoError(t, unmarshalCEF(event, lr))
+	assert.Equal(t, event, marshalCEF(lr, createDefaultConfig().(*Config)))
+}
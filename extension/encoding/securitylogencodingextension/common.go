@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/securitylogencodingextension"
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// timeLayouts are the layouts of the timestamps found in CEF and LEEF events,
+// besides milliseconds since the epoch.
+var timeLayouts = []string{
+	"Jan 02 2006 15:04:05.000 MST",
+	"Jan 02 2006 15:04:05 MST",
+	"Jan 02 2006 15:04:05.000",
+	"Jan 02 2006 15:04:05",
+	time.RFC3339Nano,
+}
+
+func parseTime(value string) (time.Time, bool) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis), true
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// severityNumber maps the 0 to 10 severity scale of CEF and LEEF to the
+// OpenTelemetry severity numbers, following the Low, Medium, High and
+// Very-High ranges of CEF.
+func severityNumber(severity int) plog.SeverityNumber {
+	switch {
+	case severity < 0:
+		return plog.SeverityNumberUnspecified
+	case severity <= 3:
+		return plog.SeverityNumberInfo
+	case severity <= 6:
+		return plog.SeverityNumberWarn
+	case severity <= 8:
+		return plog.SeverityNumberError
+	default:
+		return plog.SeverityNumberFatal
+	}
+}
+
+// severityScale maps an OpenTelemetry severity number to the 0 to 10
+// severity scale of CEF and LEEF.
+func severityScale(number plog.SeverityNumber) int {
+	switch {
+	case number >= plog.SeverityNumberFatal:
+		return 10
+	case number >= plog.SeverityNumberError:
+		return 7
+	case number >= plog.SeverityNumberWarn:
+		return 5
+	case number >= plog.SeverityNumberInfo:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// splitHeader splits the first n fields of a header delimited by unescaped
+// pipes, unescaping the pipes and backslashes of the fields, and returns the
+// remainder following the last delimiter.
+func splitHeader(s string, n int) ([]string, string, bool) {
+	fields := make([]string, 0, n)
+	var field strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && (s[i+1] == '|' || s[i+1] == '\\'):
+			i++
+			field.WriteByte(s[i])
+		case c == '|':
+			fields = append(fields, field.String())
+			field.Reset()
+			if len(fields) == n {
+				return fields, s[i+1:], true
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	// tolerate a missing delimiter after the last header field
+	if len(fields) == n-1 {
+		return append(fields, field.String()), "", true
+	}
+	return nil, "", false
+}
+
+var headerEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+
+// stringAttribute returns the string value of an attribute, or the given
+// default if the attribute is not set.
+func stringAttribute(attrs pcommon.Map, key, def string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return def
+}
+
+// extensionAttributes returns the attributes marshaled as key-value pairs of
+// an event: the given map attribute if present, the other attributes of the
+// record otherwise, excluding those of the given header prefix.
+func extensionAttributes(attrs pcommon.Map, mapKey, headerPrefix string) pcommon.Map {
+	if v, ok := attrs.Get(mapKey); ok && v.Type() == pcommon.ValueTypeMap {
+		return v.Map()
+	}
+	m := pcommon.NewMap()
+	for k, v := range attrs.All() {
+		if strings.HasPrefix(k, headerPrefix) {
+			continue
+		}
+		v.CopyTo(m.PutEmpty(k))
+	}
+	return m
+}
+
+// sanitizeKey replaces the characters that cannot appear in the key of a
+// key-value pair.
+func sanitizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '=', '\t', '\n', '\r', '|':
+			return '_'
+		}
+		return r
+	}, key)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/securitylogencodingextension"
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+type Format string
+
+const (
+	// FormatCEF is the ArcSight Common Event Format.
+	FormatCEF Format = "cef"
+	// FormatLEEF is the IBM QRadar Log Event Extended Format.
+	FormatLEEF Format = "leef"
+)
+
+var errInvalidLEEFDelimiter = errors.New("leef_delimiter must be a single character other than '=' and '|'")
+
+type Config struct {
+	// Format is the security log format, either cef or leef.
+	Format Format `mapstructure:"format"`
+
+	// LEEFDelimiter separates the attributes of marshaled LEEF events.
+	LEEFDelimiter string `mapstructure:"leef_delimiter"`
+
+	// Vendor, Product and ProductVersion are the header fields of marshaled
+	// events when the log records do not carry them as attributes.
+	Vendor         string `mapstructure:"vendor"`
+	Product        string `mapstructure:"product"`
+	ProductVersion string `mapstructure:"product_version"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (c *Config) Validate() error {
+	switch c.Format {
+	case FormatCEF, FormatLEEF:
+	default:
+		return fmt.Errorf("unsupported format %q, must be one of %q or %q", c.Format, FormatCEF, FormatLEEF)
+	}
+	if c.Format == FormatLEEF {
+		r, size := utf8.DecodeRuneInString(c.LEEFDelimiter)
+		if size == 0 || size != len(c.LEEFDelimiter) || r == '=' || r == '|' {
+			return errInvalidLEEFDelimiter
+		}
+	}
+	return nil
+}
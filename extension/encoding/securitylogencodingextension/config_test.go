@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Format = "syslog"
+	assert.ErrorContains(t, cfg.Validate(), `unsupported format "syslog"`)
+
+	cfg.Format = FormatLEEF
+	assert.NoError(t, cfg.Validate())
+
+	for _, delimiter := range []string{"", "^^", "=", "|"} {
+		cfg.LEEFDelimiter = delimiter
+		assert.ErrorIs(t, cfg.Validate(), errInvalidLEEFDelimiter, delimiter)
+	}
+
+	cfg.LEEFDelimiter = "^"
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package securitylogencodingextension implements an encoding extension for
+// the CEF and LEEF security log formats.
+package securitylogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/securitylogencodingextension"
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/securitylogencodingextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.LogsMarshalerExtension   = (*securityLogExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension = (*securityLogExtension)(nil)
+)
+
+var errMissingHeader = errors.New("no CEF or LEEF header found")
+
+type securityLogExtension struct {
+	config *Config
+}
+
+func (e *securityLogExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	var lines []string
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if e.config.Format == FormatLEEF {
+					lines = append(lines, marshalLEEF(lrs.At(k), e.config))
+				} else {
+					lines = append(lines, marshalCEF(lrs.At(k), e.config))
+				}
+			}
+		}
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// UnmarshalLogs parses newline delimited CEF or LEEF events, which may be
+// preceded by a syslog header, into log records. The body of each record is
+// the original line.
+func (*securityLogExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	p := plog.NewLogs()
+	lrs := p.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i, line := range strings.Split(string(buf), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lr := lrs.AppendEmpty()
+		lr.Body().SetStr(line)
+		if err := unmarshalEvent(line, lr); err != nil {
+			return plog.Logs{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+	return p, nil
+}
+
+func unmarshalEvent(line string, lr plog.LogRecord) error {
+	cef := strings.Index(line, cefPrefix)
+	leef := strings.Index(line, leefPrefix)
+	switch {
+	case cef >= 0 && (leef < 0 || cef < leef):
+		return unmarshalCEF(line[cef:], lr)
+	case leef >= 0:
+		return unmarshalLEEF(line[leef:], lr)
+	default:
+		return errMissingHeader
+	}
+}
+
+func (*securityLogExtension) Start(_ context.Context, _ component.Host) error {
+	return nil
+}
+
+func (*securityLogExtension) Shutdown(_ context.Context) error {
+	return nil
+}
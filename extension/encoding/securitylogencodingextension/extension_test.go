@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestExtension_Start_Shutdown(t *testing.T) {
+	e := &securityLogExtension{}
+	err := e.Start(t.Context(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	err = e.Shutdown(t.Context())
+	require.NoError(t, err)
+}
+
+func TestUnmarshalLogs(t *testing.T) {
+	e := &securityLogExtension{config: createDefaultConfig().(*Config)}
+
+	lines := []string{
+		"<134>Nov 14 22:13:20 host CEF:0|Security|threat manager|1.0|100|worm stopped|10|src=10.0.0.1",
+		"LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0",
+	}
+	ld, err := e.UnmarshalLogs([]byte(lines[0] + "\r\n\n" + lines[1] + "\n"))
+	require.NoError(t, err)
+
+	lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, lrs.Len())
+	for i, line := range lines {
+		assert.Equal(t, line, lrs.At(i).Body().Str())
+	}
+	name, ok := lrs.At(0).Attributes().Get("cef.name")
+	require.True(t, ok)
+	assert.Equal(t, "worm stopped", name.Str())
+	vendor, ok := lrs.At(1).Attributes().Get("leef.vendor")
+	require.True(t, ok)
+	assert.Equal(t, "Microsoft", vendor.Str())
+}
+
+func TestUnmarshalLogsMissingHeader(t *testing.T) {
+	e := &securityLogExtension{config: createDefaultConfig().(*Config)}
+	_, err := e.UnmarshalLogs([]byte("CEF:0|a|b|c|d|e|1|\nnot a security event"))
+	assert.ErrorIs(t, err, errMissingHeader)
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestMarshalLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetStr("first")
+	lrs.AppendEmpty().Body().SetStr("second")
+
+	cfg := createDefaultConfig().(*Config)
+	e := &securityLogExtension{config: cfg}
+	buf, err := e.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"CEF:0|OpenTelemetry|Collector|1.0|0|first|0|\nCEF:0|OpenTelemetry|Collector|1.0|0|second|0|",
+		string(buf))
+
+	cfg.Format = FormatLEEF
+	buf, err = e.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"LEEF:2.0|OpenTelemetry|Collector|1.0|0|x09|\nLEEF:2.0|OpenTelemetry|Collector|1.0|0|x09|",
+		string(buf))
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/securitylogencodingextension"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	leefPrefix = "LEEF:"
+
+	leefAttributePrefix         = "leef."
+	leefVersionAttribute        = "leef.version"
+	leefVendorAttribute         = "leef.vendor"
+	leefProductAttribute        = "leef.product"
+	leefProductVersionAttribute = "leef.product_version"
+	leefEventIDAttribute        = "leef.event_id"
+	leefAttributesAttribute     = "leef.attributes"
+
+	// leefSeverity and leefDeviceTime are the predefined attribute keys of
+	// the severity and time of the event.
+	leefSeverity   = "sev"
+	leefDeviceTime = "devTime"
+
+	leefVersion1       = "1.0"
+	leefVersion2       = "2.0"
+	leefHeaderFieldsV1 = 5
+	leefHeaderFieldsV2 = 6
+
+	leefDefaultDelimiter = '\t'
+)
+
+var errInvalidLEEFHeader = errors.New("invalid LEEF header, expected 5 fields for version 1.0 or 6 fields for version 2.0 delimited by '|'")
+
+// unmarshalLEEF sets the attributes of the log record from a LEEF event,
+// which starts with the LEEF: prefix.
+func unmarshalLEEF(event string, lr plog.LogRecord) error {
+	s := strings.TrimPrefix(event, leefPrefix)
+	n := leefHeaderFieldsV1
+	if strings.HasPrefix(s, leefVersion2) {
+		n = leefHeaderFieldsV2
+	}
+	fields, attributes, ok := splitHeader(s, n)
+	if !ok {
+		return errInvalidLEEFHeader
+	}
+
+	delimiter := string(leefDefaultDelimiter)
+	if n == leefHeaderFieldsV2 {
+		var err error
+		if delimiter, err = parseLEEFDelimiter(fields[5]); err != nil {
+			return err
+		}
+	}
+
+	attrs := lr.Attributes()
+	attrs.PutStr(leefVersionAttribute, fields[0])
+	attrs.PutStr(leefVendorAttribute, fields[1])
+	attrs.PutStr(leefProductAttribute, fields[2])
+	attrs.PutStr(leefProductVersionAttribute, fields[3])
+	attrs.PutStr(leefEventIDAttribute, fields[4])
+
+	m := attrs.PutEmptyMap(leefAttributesAttribute)
+	for _, pair := range strings.Split(attributes, delimiter) {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			continue
+		}
+		m.PutStr(key, value)
+	}
+
+	if sev, ok := m.Get(leefSeverity); ok {
+		lr.SetSeverityText(sev.Str())
+		if n, err := strconv.Atoi(strings.TrimSpace(sev.Str())); err == nil {
+			lr.SetSeverityNumber(severityNumber(n))
+		}
+	}
+	if devTime, ok := m.Get(leefDeviceTime); ok {
+		if t, ok := parseTime(devTime.Str()); ok {
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(t))
+		}
+	}
+	return nil
+}
+
+// parseLEEFDelimiter parses the delimiter header field of LEEF 2.0, which is
+// either a single character or its hex code prefixed by x or 0x. The default
+// delimiter is used when the field is empty.
+func parseLEEFDelimiter(field string) (string, error) {
+	if field == "" {
+		return string(leefDefaultDelimiter), nil
+	}
+	if utf8.RuneCountInString(field) == 1 {
+		return field, nil
+	}
+	hex, ok := strings.CutPrefix(strings.ToLower(field), "0x")
+	if !ok {
+		if hex, ok = strings.CutPrefix(strings.ToLower(field), "x"); !ok {
+			return "", fmt.Errorf("invalid LEEF delimiter %q", field)
+		}
+	}
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return "", fmt.Errorf("invalid LEEF delimiter %q", field)
+	}
+	return string(rune(code)), nil
+}
+
+// marshalLEEF formats a log record as a LEEF 2.0 event. The header fields and
+// attributes are taken from the attributes set when unmarshaling, falling back
+// to the configured header fields and the other attributes of the record.
+func marshalLEEF(lr plog.LogRecord, config *Config) string {
+	attrs := lr.Attributes()
+
+	header := []string{
+		leefVersion2,
+		stringAttribute(attrs, leefVendorAttribute, config.Vendor),
+		stringAttribute(attrs, leefProductAttribute, config.Product),
+		stringAttribute(attrs, leefProductVersionAttribute, config.ProductVersion),
+		stringAttribute(attrs, leefEventIDAttribute, "0"),
+		formatLEEFDelimiter(config.LEEFDelimiter),
+	}
+
+	var b strings.Builder
+	b.WriteString(leefPrefix)
+	for _, field := range header {
+		b.WriteString(headerEscaper.Replace(field))
+		b.WriteByte('|')
+	}
+
+	valueEscaper := strings.NewReplacer(config.LEEFDelimiter, " ", "\n", " ", "\r", " ")
+	// the delimiter cannot be escaped in keys either, it is replaced like the
+	// other characters that cannot appear in them
+	keyEscaper := strings.NewReplacer(config.LEEFDelimiter, "_")
+	attributes := extensionAttributes(attrs, leefAttributesAttribute, leefAttributePrefix)
+	first := true
+	write := func(key, value string) {
+		if !first {
+			b.WriteString(config.LEEFDelimiter)
+		}
+		first = false
+		b.WriteString(keyEscaper.Replace(sanitizeKey(key)))
+		b.WriteByte('=')
+		b.WriteString(valueEscaper.Replace(value))
+	}
+	for k, v := range attributes.All() {
+		write(k, v.AsString())
+	}
+	if _, ok := attributes.Get(leefSeverity); !ok && lr.SeverityNumber() != plog.SeverityNumberUnspecified {
+		write(leefSeverity, strconv.Itoa(severityScale(lr.SeverityNumber())))
+	}
+	if _, ok := attributes.Get(leefDeviceTime); !ok && lr.Timestamp() != 0 {
+		write(leefDeviceTime, strconv.FormatInt(lr.Timestamp().AsTime().UnixMilli(), 10))
+	}
+	return b.String()
+}
+
+// formatLEEFDelimiter formats the delimiter header field, using the hex code
+// of delimiters that are not printable.
+func formatLEEFDelimiter(delimiter string) string {
+	r, _ := utf8.DecodeRuneInString(delimiter)
+	if unicode.IsPrint(r) && !unicode.IsSpace(r) {
+		return delimiter
+	}
+	return fmt.Sprintf("x%02X", r)
+}
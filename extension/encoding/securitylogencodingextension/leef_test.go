@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package securitylogencodingextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestUnmarshalLEEF(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    string
+		expected map[string]any
+	}{
+		{
+			name:  "version 1.0",
+			event: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tdevTime=1700000000000",
+			expected: map[string]any{
+				"leef.version":         "1.0",
+				"leef.vendor":          "Microsoft",
+				"leef.product":         "MSExchange",
+				"leef.product_version": "4.0 SP1",
+				"leef.event_id":        "15345",
+				"leef.attributes": map[string]any{
+					"src":     "192.0.2.0",
+					"dst":     "172.50.123.1",
+					"sev":     "5",
+					"devTime": "1700000000000",
+				},
+			},
+		},
+		{
+			name:  "version 2.0 with character delimiter",
+			event: "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^devTime=1700000000000",
+			expected: map[string]any{
+				"leef.version":         "2.0",
+				"leef.vendor":          "Lancope",
+				"leef.product":         "StealthWatch",
+				"leef.product_version": "1.0",
+				"leef.event_id":        "41",
+				"leef.attributes": map[string]any{
+					"src":     "10.0.1.8",
+					"dst":     "10.0.0.5",
+					"sev":     "5",
+					"devTime": "1700000000000",
+				},
+			},
+		},
+		{
+			name:  "version 2.0 with hex delimiter",
+			event: "LEEF:2.0|Lancope|StealthWatch|1.0|41|0x5E|src=10.0.1.8^dst=10.0.0.5^sev=5^devTime=1700000000000",
+			expected: map[string]any{
+				"leef.version":         "2.0",
+				"leef.vendor":          "Lancope",
+				"leef.product":         "StealthWatch",
+				"leef.product_version": "1.0",
+				"leef.event_id":        "41",
+				"leef.attributes": map[string]any{
+					"src":     "10.0.1.8",
+					"dst":     "10.0.0.5",
+					"sev":     "5",
+					"devTime": "1700000000000",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			require.NoError(t, unmarshalLEEF(tt.event, lr))
+			assert.Equal(t, tt.expected, lr.Attributes().AsRaw())
+			assert.Equal(t, plog.SeverityNumberWarn, lr.SeverityNumber())
+			assert.Equal(t, "5", lr.SeverityText())
+			assert.Equal(t, time.UnixMilli(1700000000000).UTC(), lr.Timestamp().AsTime())
+		})
+	}
+}
+
+func TestUnmarshalLEEFInvalid(t *testing.T) {
+	err := unmarshalLEEF("LEEF:1.0|Vendor|Product", plog.NewLogRecord())
+	assert.ErrorIs(t, err, errInvalidLEEFHeader)
+
+	err = unmarshalLEEF("LEEF:2.0|Vendor|Product|1.0|41|^^|src=10.0.1.8", plog.NewLogRecord())
+	assert.ErrorContains(t, err, `invalid LEEF delimiter "^^"`)
+}
+
+func TestParseLEEFDelimiter(t *testing.T) {
+	for field, expected := range map[string]string{
+		"":     "\t",
+		"^":    "^",
+		"x09":  "\t",
+		"0x5E": "^",
+		"x7c":  "|",
+	} {
+		delimiter, err := parseLEEFDelimiter(field)
+		require.NoError(t, err, field)
+		assert.Equal(t, expected, delimiter, field)
+	}
+
+	for _, field := range []string{"ab", "xZZ", "0y09"} {
+		_, err := parseLEEFDelimiter(field)
+		assert.Error(t, err, field)
+	}
+}
+
+func TestMarshalLEEF(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Format = FormatLEEF
+
+	lr := plog.NewLogRecord()
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(1700000000000)))
+	lr.Attributes().PutStr("src", "10.0.1.8")
+	lr.Attributes().PutStr("msg", "line one\tline two\nline three")
+	assert.Equal(t,
+		"LEEF:2.0|OpenTelemetry|Collector|1.0|0|x09|src=10.0.1.8\tmsg=line one line two line three\tsev=5\tdevTime=1700000000000",
+		marshalLEEF(lr, cfg))
+
+	cfg.LEEFDelimiter = "^"
+	assert.Equal(t,
+		"LEEF:2.0|OpenTelemetry|Collector|1.0|0|^|src=10.0.1.8^msg=line one\tline two line three^sev=5^devTime=1700000000000",
+		marshalLEEF(lr, cfg))
+
+	// the delimiter is replaced in the keys
+	lr.Attributes().PutStr("user^name", "alice")
+	assert.Equal(t,
+		"LEEF:2.0|OpenTelemetry|Collector|1.0|0|^|src=10.0.1.8^msg=line one\tline two line three^user_name=alice^sev=5^devTime=1700000000000",
+		marshalLEEF(lr, cfg))
+}
+
+func TestMarshalLEEFRoundTrip(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LEEFDelimiter = "^"
+
+	event := "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^devTime=1700000000000"
+	lr := plog.NewLogRecord()
+	require.NoError(t, unmarshalLEEF(event, lr))
+	assert.Equal(t, event, marshalLEEF(lr, cfg))
+}
@@ -12,24 +12,34 @@ import (
 var (
 	errMissingHeader        = errors.New("missing header name")
 	errMissingHeadersConfig = errors.New("missing headers configuration")
-	errMissingSource        = errors.New("missing header source, must be 'from_context', 'from_attribute' or 'value'")
-	errConflictingSources   = errors.New("invalid header source, must either 'from_context', 'from_attribute' or 'value'")
+	errMissingSource        = errors.New("missing header source, must be 'from_context', 'from_attribute', 'from_expression', 'from_file' or 'value'")
+	errConflictingSources   = errors.New("invalid header source, must either 'from_context', 'from_attribute', 'from_expression', 'from_file' or 'value'")
 )
 
 type Config struct {
 	HeadersConfig []HeaderConfig `mapstructure:"headers"`
 
+	// MetadataKeys are the request metadata keys available as resource
+	// attributes to the from_expression sources.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
 
 type HeaderConfig struct {
-	Action        ActionValue          `mapstructure:"action"`
-	Key           *string              `mapstructure:"key"`
-	Value         *string              `mapstructure:"value"`
-	FromContext   *string              `mapstructure:"from_context"`
-	FromAttribute *string              `mapstructure:"from_attribute"`
-	DefaultValue  *configopaque.String `mapstructure:"default_value"`
+	Action        ActionValue `mapstructure:"action"`
+	Key           *string     `mapstructure:"key"`
+	Value         *string     `mapstructure:"value"`
+	FromContext   *string     `mapstructure:"from_context"`
+	FromAttribute *string     `mapstructure:"from_attribute"`
+	// FromExpression is an OTTL value expression evaluated in the resource
+	// context.
+	FromExpression *string `mapstructure:"from_expression"`
+	// FromFile is the path of a file holding the value, which is reloaded
+	// when it changes.
+	FromFile     *string              `mapstructure:"from_file"`
+	DefaultValue *configopaque.String `mapstructure:"default_value"`
 }
 
 // ActionValue is the enum to capture the four types of actions to perform on a header
@@ -61,12 +71,16 @@ func (cfg *Config) Validate() error {
 		}
 
 		if header.Action != DELETE {
-			if header.FromContext == nil && header.FromAttribute == nil && header.Value == nil {
+			sources := 0
+			for _, source := range []*string{header.Value, header.FromContext, header.FromAttribute, header.FromExpression, header.FromFile} {
+				if source != nil {
+					sources++
+				}
+			}
+			if sources == 0 {
 				return errMissingSource
 			}
-			if (header.FromContext != nil && header.FromAttribute != nil) ||
-				(header.FromContext != nil && header.Value != nil) ||
-				(header.Value != nil && header.FromAttribute != nil) {
+			if sources > 1 {
 				return errConflictingSources
 			}
 		}
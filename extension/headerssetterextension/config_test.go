@@ -64,6 +64,25 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "2"),
+			expected: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:            stringp("X-Tenant"),
+						Action:         UPSERT,
+						FromExpression: stringp(`Concat(["tenant", resource.attributes["tenant_id"]], "-")`),
+						DefaultValue:   opaquep("default"),
+					},
+					{
+						Key:      stringp("Authorization"),
+						Action:   UPSERT,
+						FromFile: stringp("/var/run/secrets/token"),
+					},
+				},
+				MetadataKeys: []string{"tenant_id"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -199,6 +218,40 @@ func TestValidateConfig(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"header value from expression",
+			[]HeaderConfig{
+				{
+					Key:            stringp("name"),
+					Action:         INSERT,
+					FromExpression: stringp(`resource.attributes["tenant"]`),
+				},
+			},
+			nil,
+		},
+		{
+			"header value from file",
+			[]HeaderConfig{
+				{
+					Key:      stringp("name"),
+					Action:   UPSERT,
+					FromFile: stringp("/var/run/secrets/token"),
+				},
+			},
+			nil,
+		},
+		{
+			"header value from file and expression",
+			[]HeaderConfig{
+				{
+					Key:            stringp("name"),
+					Action:         UPSERT,
+					FromFile:       stringp("/var/run/secrets/token"),
+					FromExpression: stringp(`resource.attributes["tenant"]`),
+				},
+			},
+			errConflictingSources,
+		},
 		{
 			"headers configuration is missing",
 			nil,
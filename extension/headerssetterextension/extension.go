@@ -12,7 +12,6 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/extensionauth"
-	"go.uber.org/zap"
 	"google.golang.org/grpc/credentials"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/action"
@@ -31,12 +30,31 @@ var (
 )
 
 type headerSetterExtension struct {
-	component.StartFunc
-	component.ShutdownFunc
-
 	headers []header
 }
 
+// Start starts the sources that need it, such as the file sources.
+func (h *headerSetterExtension) Start(ctx context.Context, host component.Host) error {
+	for _, header := range h.headers {
+		if c, ok := header.source.(component.Component); ok {
+			if err := c.Start(ctx, host); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *headerSetterExtension) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, header := range h.headers {
+		if c, ok := header.source.(component.Component); ok {
+			errs = append(errs, c.Shutdown(ctx))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // PerRPCCredentials implements extensionauth.GRPCClient.
 func (h *headerSetterExtension) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
 	return &headersPerRPC{headers: h.headers}, nil
@@ -50,7 +68,7 @@ func (h *headerSetterExtension) RoundTripper(base http.RoundTripper) (http.Round
 	}, nil
 }
 
-func newHeadersSetterExtension(cfg *Config, logger *zap.Logger) (*headerSetterExtension, error) {
+func newHeadersSetterExtension(cfg *Config, settings component.TelemetrySettings) (*headerSetterExtension, error) {
 	if cfg == nil {
 		return nil, errors.New("extension configuration is not provided")
 	}
@@ -81,6 +99,18 @@ func newHeadersSetterExtension(cfg *Config, logger *zap.Logger) (*headerSetterEx
 				Key:          *h.FromContext,
 				DefaultValue: defaultValue,
 			}
+		case h.FromExpression != nil:
+			defaultValue := ""
+			if h.DefaultValue != nil {
+				defaultValue = string(*h.DefaultValue)
+			}
+			var err error
+			s, err = source.NewExpressionSource(*h.FromExpression, cfg.MetadataKeys, defaultValue, settings)
+			if err != nil {
+				return nil, err
+			}
+		case h.FromFile != nil:
+			s = source.NewFileSource(*h.FromFile, settings.Logger)
 		}
 
 		var a action.Action
@@ -95,7 +125,7 @@ func newHeadersSetterExtension(cfg *Config, logger *zap.Logger) (*headerSetterEx
 			a = action.Delete{Key: *h.Key}
 		default:
 			a = action.Upsert{Key: *h.Key}
-			logger.Warn("The action was not provided, using 'upsert'." +
+			settings.Logger.Warn("The action was not provided, using 'upsert'." +
 				" In future versions, we'll require this to be explicitly set")
 		}
 		headers = append(headers, header{action: a, source: s})
@@ -5,10 +5,15 @@ package headerssetterextension
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configopaque"
 )
 
@@ -25,7 +30,7 @@ func (*mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 func TestRoundTripper(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("round_tripper", func(t *testing.T) {
-			ext, err := newHeadersSetterExtension(tt.cfg, nil)
+			ext, err := newHeadersSetterExtension(tt.cfg, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 			assert.NotNil(t, ext)
 
@@ -60,7 +65,7 @@ func TestRoundTripper(t *testing.T) {
 func TestPerRPCCredentials(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
-			ext, err := newHeadersSetterExtension(tt.cfg, nil)
+			ext, err := newHeadersSetterExtension(tt.cfg, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 			assert.NotNil(t, ext)
 
@@ -254,9 +259,108 @@ var (
 				"header_name": "acme",
 			},
 		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:            &headername,
+						Action:         INSERT,
+						FromExpression: stringp(`Concat(["tenant", resource.attributes["tenant"]], "-")`),
+					},
+				},
+				MetadataKeys: []string{"tenant"},
+			},
+			metadata: client.NewMetadata(
+				map[string][]string{"tenant": {"acme"}},
+			),
+			expectedHeaders: map[string]string{
+				"header_name": "tenant-acme",
+			},
+		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:            &headername,
+						Action:         INSERT,
+						FromExpression: stringp(`resource.attributes["tenant"]`),
+						DefaultValue:   opaquep("default_tenant"),
+					},
+				},
+				MetadataKeys: []string{"tenant"},
+			},
+			metadata: client.NewMetadata(
+				map[string][]string{},
+			),
+			expectedHeaders: map[string]string{
+				"header_name": "default_tenant",
+			},
+		},
 	}
 )
 
+func TestFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+
+	ext, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{
+				Key:      &headername,
+				Action:   UPSERT,
+				FromFile: &path,
+			},
+		},
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(t.Context()))
+	}()
+
+	perRPC, err := ext.PerRPCCredentials()
+	require.NoError(t, err)
+	metadata, err := perRPC.GetRequestMetadata(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "first", metadata[headername])
+
+	require.NoError(t, os.WriteFile(path, []byte("second\n"), 0o600))
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		metadata, err := perRPC.GetRequestMetadata(t.Context())
+		assert.NoError(tt, err)
+		assert.Equal(tt, "second", metadata[headername])
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFromFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing")
+	ext, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{
+				Key:      &headername,
+				Action:   UPSERT,
+				FromFile: &path,
+			},
+		},
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.ErrorContains(t, ext.Start(t.Context(), componenttest.NewNopHost()), "failed to read header value file")
+	assert.NoError(t, ext.Shutdown(t.Context()))
+}
+
+func TestFromExpressionInvalid(t *testing.T) {
+	_, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{
+				Key:            &headername,
+				Action:         UPSERT,
+				FromExpression: stringp(`resource.attributes[`),
+			},
+		},
+	}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, "failed to parse expression")
+}
+
 func stringp(str string) *string {
 	return &str
 }
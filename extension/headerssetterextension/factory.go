@@ -31,5 +31,5 @@ func createExtension(
 	settings extension.Settings,
 	cfg component.Config,
 ) (extension.Extension, error) {
-	return newHeadersSetterExtension(cfg.(*Config), settings.Logger)
+	return newHeadersSetterExtension(cfg.(*Config), settings.TelemetrySettings)
 }
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+var _ Source = (*ExpressionSource)(nil)
+
+// ExpressionSource evaluates an OTTL value expression in the resource
+// context. The attributes of the resource are the request metadata of the
+// configured keys: a string for a single value, a slice otherwise.
+type ExpressionSource struct {
+	expression   *ottl.ValueExpression[ottlresource.TransformContext]
+	metadataKeys []string
+	defaultValue string
+}
+
+func NewExpressionSource(expression string, metadataKeys []string, defaultValue string, settings component.TelemetrySettings) (*ExpressionSource, error) {
+	parser, err := ottlresource.NewParser(ottlfuncs.StandardConverters[ottlresource.TransformContext](), settings)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parser.ParseValueExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expression %q: %w", expression, err)
+	}
+	return &ExpressionSource{
+		expression:   parsed,
+		metadataKeys: metadataKeys,
+		defaultValue: defaultValue,
+	}, nil
+}
+
+func (es *ExpressionSource) Get(ctx context.Context) (string, error) {
+	cl := client.FromContext(ctx)
+	resource := pcommon.NewResource()
+	for _, key := range es.metadataKeys {
+		values := cl.Metadata.Get(key)
+		switch len(values) {
+		case 0:
+		case 1:
+			resource.Attributes().PutStr(key, values[0])
+		default:
+			s := resource.Attributes().PutEmptySlice(key)
+			for _, v := range values {
+				s.AppendEmpty().SetStr(v)
+			}
+		}
+	}
+
+	result, err := es.expression.Eval(ctx, ottlresource.NewTransformContext(resource, plog.NewResourceLogs()))
+	if err != nil {
+		return "", err
+	}
+
+	value := pcommon.NewValueEmpty()
+	switch r := result.(type) {
+	case nil:
+		return es.defaultValue, nil
+	case string:
+		if r == "" {
+			return es.defaultValue, nil
+		}
+		return r, nil
+	case pcommon.Value:
+		r.CopyTo(value)
+	case pcommon.Map:
+		r.CopyTo(value.SetEmptyMap())
+	case pcommon.Slice:
+		r.CopyTo(value.SetEmptySlice())
+	default:
+		if err := value.FromRaw(r); err != nil {
+			return "", fmt.Errorf("unsupported expression result: %w", err)
+		}
+	}
+	return value.AsString(), nil
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestExpressionSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		metadata   map[string][]string
+		expected   string
+	}{
+		{
+			name:       "single value",
+			expression: `resource.attributes["tenant"]`,
+			metadata:   map[string][]string{"tenant": {"acme"}},
+			expected:   "acme",
+		},
+		{
+			name:       "multiple values",
+			expression: `resource.attributes["tenant"]`,
+			metadata:   map[string][]string{"tenant": {"acme", "globex"}},
+			expected:   `["acme","globex"]`,
+		},
+		{
+			name:       "converter",
+			expression: `ToUpperCase(resource.attributes["tenant"])`,
+			metadata:   map[string][]string{"tenant": {"acme"}},
+			expected:   "ACME",
+		},
+		{
+			name:       "integer result",
+			expression: `Len(resource.attributes["tenant"])`,
+			metadata:   map[string][]string{"tenant": {"acme"}},
+			expected:   "4",
+		},
+		{
+			name:       "key not in metadata keys",
+			expression: `resource.attributes["user"]`,
+			metadata:   map[string][]string{"user": {"alice"}},
+			expected:   "default",
+		},
+		{
+			name:       "missing metadata",
+			expression: `resource.attributes["tenant"]`,
+			expected:   "default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es, err := NewExpressionSource(tt.expression, []string{"tenant"}, "default", componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			ctx := client.NewContext(t.Context(), client.Info{Metadata: client.NewMetadata(tt.metadata)})
+			val, err := es.Get(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, val)
+		})
+	}
+}
+
+func TestExpressionSourceInvalid(t *testing.T) {
+	_, err := NewExpressionSource(`UnknownFunc(resource.attributes["tenant"])`, nil, "", componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, "failed to parse expression")
+}
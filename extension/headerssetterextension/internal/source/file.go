@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+)
+
+var (
+	_ Source              = (*FileSource)(nil)
+	_ component.Component = (*FileSource)(nil)
+)
+
+// FileSource reads the value from a file, which is watched and reloaded when
+// it changes, such as a rotated token. Surrounding whitespace is trimmed.
+type FileSource struct {
+	path   string
+	logger *zap.Logger
+
+	value   atomic.Pointer[string]
+	watcher *filewatcher.Watcher
+}
+
+func NewFileSource(path string, logger *zap.Logger) *FileSource {
+	return &FileSource{
+		path:   path,
+		logger: logger,
+	}
+}
+
+func (fs *FileSource) Start(context.Context, component.Host) error {
+	if err := fs.load(); err != nil {
+		return err
+	}
+
+	watcher, err := filewatcher.New(fs.path, fs.logger, fs.load)
+	if err != nil {
+		return err
+	}
+	fs.watcher = watcher
+	return nil
+}
+
+func (fs *FileSource) load() error {
+	b, err := os.ReadFile(fs.path)
+	if err != nil {
+		return fmt.Errorf("failed to read header value file: %w", err)
+	}
+	value := strings.TrimSpace(string(b))
+	fs.value.Store(&value)
+	return nil
+}
+
+func (fs *FileSource) Shutdown(context.Context) error {
+	if fs.watcher == nil {
+		return nil
+	}
+	err := fs.watcher.Close()
+	fs.watcher = nil
+	return err
+}
+
+func (fs *FileSource) Get(context.Context) (string, error) {
+	value := fs.value.Load()
+	if value == nil {
+		return "", fmt.Errorf("header value file %q is not loaded", fs.path)
+	}
+	return *value, nil
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("  first\n"), 0o600))
+
+	fs := NewFileSource(path, zap.NewNop())
+	_, err := fs.Get(t.Context())
+	assert.ErrorContains(t, err, "is not loaded")
+
+	require.NoError(t, fs.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, fs.Shutdown(t.Context()))
+	}()
+
+	val, err := fs.Get(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "first", val)
+
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		val, err := fs.Get(t.Context())
+		assert.NoError(tt, err)
+		assert.Equal(tt, "second", val)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileSourceReplaced(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+
+	fs := NewFileSource(path, zap.NewNop())
+	require.NoError(t, fs.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, fs.Shutdown(t.Context()))
+	}()
+
+	// replace the file as done when rotating mounted secrets
+	tmp := filepath.Join(dir, "token.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("second"), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		val, err := fs.Get(t.Context())
+		assert.NoError(tt, err)
+		assert.Equal(tt, "second", val)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileSourceMissing(t *testing.T) {
+	fs := NewFileSource(filepath.Join(t.TempDir(), "missing"), zap.NewNop())
+	assert.ErrorContains(t, fs.Start(t.Context(), componenttest.NewNopHost()), "failed to read header value file")
+	assert.NoError(t, fs.Shutdown(t.Context()))
+}
@@ -38,6 +38,7 @@ var (
 	_ component.Component                   = (*healthCheckExtension)(nil)
 	_ extensioncapabilities.ConfigWatcher   = (*healthCheckExtension)(nil)
 	_ extensioncapabilities.PipelineWatcher = (*healthCheckExtension)(nil)
+	_ status.Subscriber                     = (*healthCheckExtension)(nil)
 )
 
 func newExtension(
@@ -145,6 +146,12 @@ func (hc *healthCheckExtension) ComponentStatusChanged(
 	hc.eventCh <- &eventSourcePair{source: source, event: event}
 }
 
+// Subscribe implements the status.Subscriber interface, so that other components, such as the
+// failover connector, can follow the aggregated status of the pipelines.
+func (hc *healthCheckExtension) Subscribe(scope status.Scope, verbosity status.Verbosity) (<-chan *status.AggregateStatus, status.UnsubscribeFunc) {
+	return hc.aggregator.Subscribe(scope, verbosity)
+}
+
 // NotifyConfig implements the extensioncapabilities.ConfigWatcher interface.
 func (hc *healthCheckExtension) NotifyConfig(ctx context.Context, conf *confmap.Conf) error {
 	var err error
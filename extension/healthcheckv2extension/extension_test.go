@@ -92,6 +92,33 @@ func TestComponentStatus(t *testing.T) {
 	assert.Equal(t, componentstatus.StatusStopping, st.Status())
 }
 
+func TestSubscribe(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPConfig.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.GRPCConfig.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.UseV2 = true
+	ext := newExtension(t.Context(), *cfg, extensiontest.NewNopSettings(extensiontest.NopType))
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, ext.Ready())
+
+	traces := testhelpers.NewPipelineMetadata(pipeline.SignalTraces)
+	statusCh, unsubscribe := ext.Subscribe(status.Scope(traces.PipelineID.String()), status.Concise)
+	defer unsubscribe()
+
+	// The pipeline did not report yet
+	assert.Nil(t, <-statusCh)
+
+	ext.ComponentStatusChanged(traces.ExporterID, componentstatus.NewRecoverableErrorEvent(assert.AnError))
+	select {
+	case st := <-statusCh:
+		assert.Equal(t, componentstatus.StatusRecoverableError, st.Status())
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for the pipeline status")
+	}
+
+	require.NoError(t, ext.Shutdown(t.Context()))
+}
+
 func TestNotifyConfig(t *testing.T) {
 	confMap, err := confmaptest.LoadConf(
 		filepath.Join("internal", "http", "testdata", "config.yaml"),
@@ -10,12 +10,22 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
 )
 
 var (
-	errTooManySources     = errors.New("too many sources specified, has to be either 'file' or 'remote'")
-	errNoSources          = errors.New("no sources specified, has to be either 'file' or 'remote'")
+	errTooManySources     = errors.New("too many sources specified, has to be one of 'file', 'remote' or 'adaptive'")
+	errNoSources          = errors.New("no sources specified, has to be one of 'file', 'remote' or 'adaptive'")
 	errAtLeastOneProtocol = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+
+	errInvalidTargetSamplesPerSecond = errors.New("'target_samples_per_second' must be positive")
+	errInvalidSamplingProbability    = errors.New("sampling probabilities must be within (0, 1] and 'min_sampling_probability' must not exceed 'initial_sampling_probability'")
+	errInvalidMinSamplesPerSecond    = errors.New("'min_samples_per_second' must not be negative")
+	errInvalidCalculationInterval    = errors.New("'calculation_interval' must be positive")
+	errInvalidAggregationBuckets     = errors.New("'aggregation_buckets' must be positive")
+	errInvalidDeltaTolerance         = errors.New("'delta_tolerance' must be within [0, 1)")
+	errInvalidMaxOperations          = errors.New("'max_operations_per_service' must not be negative")
+	errInvalidOperationTTL           = errors.New("'operation_ttl' must not be negative")
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -24,7 +34,7 @@ type Config struct {
 	HTTPServerConfig *confighttp.ServerConfig `mapstructure:"http"`
 	GRPCServerConfig *configgrpc.ServerConfig `mapstructure:"grpc"`
 
-	// Source configures the source for the strategies file. One of `remote` or `file` has to be specified.
+	// Source configures the source for the strategies. One of `remote`, `file` or `adaptive` has to be specified.
 	Source Source `mapstructure:"source"`
 }
 
@@ -37,6 +47,51 @@ type Source struct {
 
 	// ReloadInterval determines the periodicity to refresh the strategies
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// Adaptive calculates per-operation strategies from the observed throughput
+	Adaptive configoptional.Optional[AdaptiveSource] `mapstructure:"adaptive"`
+}
+
+// AdaptiveSource configures the calculation of sampling probabilities for each
+// service and operation, based on the throughput of the root spans observed by
+// the extension.
+type AdaptiveSource struct {
+	// TargetSamplesPerSecond is the number of traces per second each operation
+	// should be sampled at.
+	TargetSamplesPerSecond float64 `mapstructure:"target_samples_per_second"`
+
+	// InitialSamplingProbability is served for operations without observed throughput.
+	InitialSamplingProbability float64 `mapstructure:"initial_sampling_probability"`
+
+	// MinSamplingProbability is the lowest probability that will be calculated.
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+
+	// MinSamplesPerSecond is the lower bound of traces per second sampled by the
+	// clients for each operation, regardless of the probability.
+	MinSamplesPerSecond float64 `mapstructure:"min_samples_per_second"`
+
+	// CalculationInterval determines how often the probabilities are recalculated.
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
+
+	// AggregationBuckets is the number of calculation intervals the throughput
+	// is averaged over.
+	AggregationBuckets int `mapstructure:"aggregation_buckets"`
+
+	// DeltaTolerance is the relative deviation from the target throughput within
+	// which the probabilities are left unchanged.
+	DeltaTolerance float64 `mapstructure:"delta_tolerance"`
+
+	// MaxOperationsPerService limits the number of operations tracked for each
+	// service. Zero means no limit.
+	MaxOperationsPerService int `mapstructure:"max_operations_per_service"`
+
+	// OperationTTL is how long an operation keeps its probability after it was
+	// last observed. Zero keeps the operations forever.
+	OperationTTL time.Duration `mapstructure:"operation_ttl"`
+
+	// StorageID is the storage extension used to persist the probabilities
+	// across restarts.
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -47,13 +102,59 @@ func (cfg *Config) Validate() error {
 		return errAtLeastOneProtocol
 	}
 
-	if cfg.Source.File != "" && cfg.Source.Remote != nil {
+	sources := 0
+	if cfg.Source.File != "" {
+		sources++
+	}
+	if cfg.Source.Remote != nil {
+		sources++
+	}
+	if cfg.Source.Adaptive.HasValue() {
+		sources++
+	}
+
+	if sources > 1 {
 		return errTooManySources
 	}
 
-	if cfg.Source.File == "" && cfg.Source.Remote == nil {
+	if sources == 0 {
 		return errNoSources
 	}
 
+	if cfg.Source.Adaptive.HasValue() {
+		return cfg.Source.Adaptive.Get().Validate()
+	}
+
 	return nil
 }
+
+// Validate checks if the adaptive source configuration is valid
+func (cfg *AdaptiveSource) Validate() error {
+	var errs []error
+	if cfg.TargetSamplesPerSecond <= 0 {
+		errs = append(errs, errInvalidTargetSamplesPerSecond)
+	}
+	if cfg.MinSamplingProbability <= 0 || cfg.InitialSamplingProbability > 1 ||
+		cfg.MinSamplingProbability > cfg.InitialSamplingProbability {
+		errs = append(errs, errInvalidSamplingProbability)
+	}
+	if cfg.MinSamplesPerSecond < 0 {
+		errs = append(errs, errInvalidMinSamplesPerSecond)
+	}
+	if cfg.CalculationInterval <= 0 {
+		errs = append(errs, errInvalidCalculationInterval)
+	}
+	if cfg.AggregationBuckets <= 0 {
+		errs = append(errs, errInvalidAggregationBuckets)
+	}
+	if cfg.DeltaTolerance < 0 || cfg.DeltaTolerance >= 1 {
+		errs = append(errs, errInvalidDeltaTolerance)
+	}
+	if cfg.MaxOperationsPerService < 0 {
+		errs = append(errs, errInvalidMaxOperations)
+	}
+	if cfg.OperationTTL < 0 {
+		errs = append(errs, errInvalidOperationTTL)
+	}
+	return errors.Join(errs...)
+}
@@ -4,6 +4,7 @@
 package jaegerremotesampling
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 
@@ -39,6 +41,7 @@ func TestLoadConfig(t *testing.T) {
 					Remote: &configgrpc.ClientConfig{
 						Endpoint: "jaeger-collector:14250",
 					},
+					Adaptive: configoptional.Default(defaultAdaptiveSource()),
 				},
 			},
 		},
@@ -53,6 +56,27 @@ func TestLoadConfig(t *testing.T) {
 				Source: Source{
 					ReloadInterval: time.Second,
 					File:           "/etc/otelcol/sampling_strategies.json",
+					Adaptive:       configoptional.Default(defaultAdaptiveSource()),
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "2"),
+			expected: &Config{
+				HTTPServerConfig: &confighttp.ServerConfig{Endpoint: "localhost:5778"},
+				GRPCServerConfig: &configgrpc.ServerConfig{NetAddr: confignet.AddrConfig{
+					Endpoint:  "localhost:14250",
+					Transport: confignet.TransportTypeTCP,
+				}},
+				Source: Source{
+					Adaptive: func() configoptional.Optional[AdaptiveSource] {
+						adaptive := defaultAdaptiveSource()
+						adaptive.TargetSamplesPerSecond = 2
+						adaptive.CalculationInterval = 30 * time.Second
+						storageID := component.MustNewID("file_storage")
+						adaptive.StorageID = &storageID
+						return configoptional.Some(adaptive)
+					}(),
 				},
 			},
 		},
@@ -101,6 +125,44 @@ func TestValidate(t *testing.T) {
 			},
 			expected: errTooManySources,
 		},
+		{
+			desc: "file and adaptive sources",
+			cfg: Config{
+				GRPCServerConfig: &configgrpc.ServerConfig{},
+				Source: Source{
+					File:     "/tmp/some-file",
+					Adaptive: configoptional.Some(defaultAdaptiveSource()),
+				},
+			},
+			expected: errTooManySources,
+		},
+		{
+			desc: "adaptive source",
+			cfg: Config{
+				GRPCServerConfig: &configgrpc.ServerConfig{},
+				Source: Source{
+					Adaptive: configoptional.Some(defaultAdaptiveSource()),
+				},
+			},
+		},
+		{
+			desc: "invalid adaptive source",
+			cfg: Config{
+				GRPCServerConfig: &configgrpc.ServerConfig{},
+				Source: Source{
+					Adaptive: configoptional.Some(AdaptiveSource{
+						TargetSamplesPerSecond:     1,
+						InitialSamplingProbability: 0.001,
+						MinSamplingProbability:     0.01,
+						CalculationInterval:        time.Minute,
+						AggregationBuckets:         10,
+						DeltaTolerance:             1,
+						OperationTTL:               -time.Minute,
+					}),
+				},
+			},
+			expected: errors.Join(errInvalidSamplingProbability, errInvalidDeltaTolerance, errInvalidOperationTTL),
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -9,31 +9,50 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/server/grpc"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/server/http"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/source"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/source/adaptivesource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/source/filesource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/source/remotesource"
 )
 
-var _ extension.Extension = (*jrsExtension)(nil)
+var (
+	_ extension.Extension             = (*jrsExtension)(nil)
+	_ extensioncapabilities.Dependent = (*jrsExtension)(nil)
+	_ TracesObserver                  = (*jrsExtension)(nil)
+)
+
+// TracesObserver is implemented by the extension to receive the traces the
+// adaptive source calculates the sampling strategies from. The traces are
+// passed by the jaegeradaptivesampling processor, which looks the extension up
+// on the host. Traces are ignored unless the adaptive source is used.
+type TracesObserver interface {
+	ObserveTraces(ctx context.Context, td ptrace.Traces)
+}
 
 type jrsExtension struct {
 	cfg       *Config
+	id        component.ID
 	telemetry component.TelemetrySettings
 
-	httpServer    component.Component
-	grpcServer    component.Component
-	samplingStore source.Source
+	httpServer     component.Component
+	grpcServer     component.Component
+	samplingStore  source.Source
+	adaptiveSource *adaptivesource.Source
 
 	closers []func() error
 }
 
-func newExtension(cfg *Config, telemetry component.TelemetrySettings) *jrsExtension {
+func newExtension(cfg *Config, id component.ID, telemetry component.TelemetrySettings) *jrsExtension {
 	jrse := &jrsExtension{
 		cfg:       cfg,
+		id:        id,
 		telemetry: telemetry,
 	}
 	return jrse
@@ -75,6 +94,39 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 		jrse.samplingStore = remoteStore
 	}
 
+	if jrse.cfg.Source.Adaptive.HasValue() {
+		adaptiveCfg := jrse.cfg.Source.Adaptive.Get()
+		var client storage.Client
+		if adaptiveCfg.StorageID != nil {
+			var err error
+			client, err = jrse.getStorageClient(ctx, host, *adaptiveCfg.StorageID)
+			if err != nil {
+				return fmt.Errorf("failed to get the storage client of the adaptive strategy store: %w", err)
+			}
+		}
+		opts := adaptivesource.Options{
+			TargetSamplesPerSecond:     adaptiveCfg.TargetSamplesPerSecond,
+			InitialSamplingProbability: adaptiveCfg.InitialSamplingProbability,
+			MinSamplingProbability:     adaptiveCfg.MinSamplingProbability,
+			MinSamplesPerSecond:        adaptiveCfg.MinSamplesPerSecond,
+			CalculationInterval:        adaptiveCfg.CalculationInterval,
+			AggregationBuckets:         adaptiveCfg.AggregationBuckets,
+			DeltaTolerance:             adaptiveCfg.DeltaTolerance,
+			MaxOperationsPerService:    adaptiveCfg.MaxOperationsPerService,
+			OperationTTL:               adaptiveCfg.OperationTTL,
+		}
+		as, err := adaptivesource.NewAdaptiveSource(ctx, opts, client, jrse.telemetry.Logger)
+		if err != nil {
+			if client != nil {
+				_ = client.Close(ctx)
+			}
+			return fmt.Errorf("failed to create the adaptive strategy store: %w", err)
+		}
+		jrse.closers = append(jrse.closers, as.Close)
+		jrse.adaptiveSource = as
+		jrse.samplingStore = as
+	}
+
 	if jrse.cfg.HTTPServerConfig != nil {
 		httpServer, err := http.NewHTTP(jrse.telemetry, *jrse.cfg.HTTPServerConfig, jrse.samplingStore)
 		if err != nil {
@@ -102,6 +154,36 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	return nil
 }
 
+// Dependencies implements extensioncapabilities.Dependent, so that the storage
+// extension of the adaptive source is started before this extension.
+func (jrse *jrsExtension) Dependencies() []component.ID {
+	if !jrse.cfg.Source.Adaptive.HasValue() || jrse.cfg.Source.Adaptive.Get().StorageID == nil {
+		return nil
+	}
+	return []component.ID{*jrse.cfg.Source.Adaptive.Get().StorageID}
+}
+
+// ObserveTraces implements TracesObserver.
+func (jrse *jrsExtension) ObserveTraces(_ context.Context, td ptrace.Traces) {
+	if jrse.adaptiveSource != nil {
+		jrse.adaptiveSource.ObserveTraces(td)
+	}
+}
+
+func (jrse *jrsExtension) getStorageClient(ctx context.Context, host component.Host, storageID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindExtension, jrse.id, "")
+}
+
 func (jrse *jrsExtension) Shutdown(ctx context.Context) error {
 	// we probably don't want to break whenever an error occurs, we want to continue and close the other resources
 	if jrse.httpServer != nil {
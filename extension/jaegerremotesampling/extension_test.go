@@ -6,6 +6,7 @@ package jaegerremotesampling
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"path/filepath"
@@ -15,10 +16,13 @@ import (
 	"github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -27,7 +31,7 @@ func TestNewExtension(t *testing.T) {
 	// test
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, component.MustNewID("jaegerremotesampling"), componenttest.NewNopTelemetrySettings())
 
 	// verify
 	assert.NotNil(t, e)
@@ -38,7 +42,7 @@ func TestStartAndShutdownLocalFile(t *testing.T) {
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
 
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, component.MustNewID("jaegerremotesampling"), componenttest.NewNopTelemetrySettings())
 	require.NotNil(t, e)
 	require.NoError(t, e.Start(t.Context(), componenttest.NewNopHost()))
 
@@ -46,6 +50,62 @@ func TestStartAndShutdownLocalFile(t *testing.T) {
 	assert.NoError(t, e.Shutdown(t.Context()))
 }
 
+func TestStartAndShutdownAdaptive(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	cfg.Source.Adaptive = configoptional.Some(defaultAdaptiveSource())
+
+	e := newExtension(cfg, component.MustNewID("jaegerremotesampling"), componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, e)
+	require.NoError(t, e.Start(t.Context(), componenttest.NewNopHost()))
+
+	// test
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "foo")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /")
+	e.ObserveTraces(t.Context(), td)
+
+	// verify, without a calculation the initial probability is served
+	resp, err := http.Get("http://127.0.0.1:5778/sampling?service=foo")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Contains(t, string(body), "0.001")
+
+	assert.NoError(t, e.Shutdown(t.Context()))
+}
+
+func TestStartAdaptiveMissingStorage(t *testing.T) {
+	cfg := testConfig()
+	adaptive := defaultAdaptiveSource()
+	storageID := component.MustNewID("file_storage")
+	adaptive.StorageID = &storageID
+	cfg.Source.Adaptive = configoptional.Some(adaptive)
+
+	e := newExtension(cfg, component.MustNewID("jaegerremotesampling"), componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, e)
+	assert.ErrorContains(t, e.Start(t.Context(), componenttest.NewNopHost()), "storage extension 'file_storage' not found")
+	assert.NoError(t, e.Shutdown(t.Context()))
+}
+
+func TestDependencies(t *testing.T) {
+	cfg := testConfig()
+	cfg.Source.File = filepath.Join("testdata", "strategy.json")
+	e := newExtension(cfg, component.MustNewID("jaegerremotesampling"), componenttest.NewNopTelemetrySettings())
+	assert.Empty(t, e.Dependencies())
+
+	cfg = testConfig()
+	adaptive := defaultAdaptiveSource()
+	storageID := component.MustNewID("file_storage")
+	adaptive.StorageID = &storageID
+	cfg.Source.Adaptive = configoptional.Some(adaptive)
+	e = newExtension(cfg, component.MustNewID("jaegerremotesampling"), componenttest.NewNopTelemetrySettings())
+	assert.Equal(t, []component.ID{storageID}, e.Dependencies())
+}
+
 func TestRemote(t *testing.T) {
 	for _, tc := range []struct {
 		name                          string
@@ -110,7 +170,7 @@ func TestRemote(t *testing.T) {
 			}
 
 			// create the extension
-			e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+			e := newExtension(cfg, component.MustNewID("jaegerremotesampling"), componenttest.NewNopTelemetrySettings())
 			require.NotNil(t, e)
 
 			// start the server
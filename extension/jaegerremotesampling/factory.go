@@ -6,11 +6,13 @@ package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-
 import (
 	"context"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.uber.org/zap"
@@ -40,7 +42,23 @@ func createDefaultConfig() component.Config {
 				Transport: confignet.TransportTypeTCP,
 			},
 		},
-		Source: Source{},
+		Source: Source{
+			Adaptive: configoptional.Default(defaultAdaptiveSource()),
+		},
+	}
+}
+
+func defaultAdaptiveSource() AdaptiveSource {
+	return AdaptiveSource{
+		TargetSamplesPerSecond:     1,
+		InitialSamplingProbability: 0.001,
+		MinSamplingProbability:     1e-5,
+		MinSamplesPerSecond:        1.0 / 60,
+		CalculationInterval:        time.Minute,
+		AggregationBuckets:         10,
+		DeltaTolerance:             0.3,
+		MaxOperationsPerService:    500,
+		OperationTTL:               24 * time.Hour,
 	}
 }
 
@@ -64,5 +82,5 @@ var protoGate = featuregate.GlobalRegistry().MustRegister(
 
 func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
 	logDeprecation(set.Logger)
-	return newExtension(cfg.(*Config), set.TelemetrySettings), nil
+	return newExtension(cfg.(*Config), set.ID, set.TelemetrySettings), nil
 }
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 )
 
@@ -22,6 +23,9 @@ func TestCreateDefaultConfig(t *testing.T) {
 			Endpoint:  "localhost:14250",
 			Transport: confignet.TransportTypeTCP,
 		}},
+		Source: Source{
+			Adaptive: configoptional.Default(defaultAdaptiveSource()),
+		},
 	}
 
 	// test
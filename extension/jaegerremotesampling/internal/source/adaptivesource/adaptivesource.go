@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adaptivesource // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/source/adaptivesource"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/source"
+)
+
+const (
+	// stateStorageKey is the key under which the calculated probabilities are
+	// stored in the storage client. The version suffix allows changing the
+	// encoding of the state without misinterpreting older checkpoints.
+	stateStorageKey = "adaptive_sampling_state_v1"
+
+	serviceNameAttribute = "service.name"
+
+	// samplerTypeAttribute is set by the Jaeger clients on root spans. Only
+	// traces sampled by the probabilistic sampler are counted, as the traces
+	// sampled by the lower bound sampler do not depend on the probability.
+	samplerTypeAttribute     = "sampler.type"
+	samplerTypeProbabilistic = "probabilistic"
+)
+
+var _ source.Source = (*Source)(nil)
+
+// throughput holds the number of sampled root spans per service and operation.
+type throughput map[string]map[string]int64
+
+type state struct {
+	Probabilities map[string]map[string]float64 `json:"probabilities"`
+	// LastSeen is missing from the states persisted before operations expired.
+	LastSeen map[string]map[string]time.Time `json:"last_seen,omitempty"`
+}
+
+// Source calculates sampling strategies from the throughput of the root spans
+// it observes, aiming at the configured number of sampled traces per second
+// for each service and operation.
+type Source struct {
+	logger  *zap.Logger
+	options Options
+	client  storage.Client
+
+	mu            sync.Mutex
+	current       throughput
+	buckets       []throughput
+	probabilities map[string]map[string]float64
+	// lastSeen holds when each operation with a probability was last observed.
+	lastSeen map[string]map[string]time.Time
+	now      func() time.Time
+
+	strategies atomic.Pointer[map[string]*api_v2.SamplingStrategyResponse]
+
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// NewAdaptiveSource creates a source calculating the sampling strategies from
+// the observed throughput. When a storage client is provided, the calculated
+// probabilities are restored from it and persisted after every calculation.
+func NewAdaptiveSource(ctx context.Context, options Options, client storage.Client, logger *zap.Logger) (*Source, error) {
+	s := &Source{
+		logger:        logger,
+		options:       options,
+		client:        client,
+		current:       throughput{},
+		probabilities: map[string]map[string]float64{},
+		lastSeen:      map[string]map[string]time.Time{},
+		now:           time.Now,
+	}
+	if client != nil {
+		if err := s.restoreState(ctx); err != nil {
+			return nil, err
+		}
+	}
+	s.publish(s.probabilities)
+
+	loopCtx, cancelFunc := context.WithCancel(context.Background())
+	s.cancelFunc = cancelFunc
+	s.wg.Add(1)
+	go s.runCalculationLoop(loopCtx)
+	return s, nil
+}
+
+// ObserveTraces counts the root spans of the given traces towards the
+// throughput of their service and operation.
+func (s *Source) ObserveTraces(td ptrace.Traces) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		serviceName, ok := rs.Resource().Attributes().Get(serviceNameAttribute)
+		if !ok || serviceName.Str() == "" {
+			continue
+		}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if !span.ParentSpanID().IsEmpty() {
+					continue
+				}
+				if samplerType, ok := span.Attributes().Get(samplerTypeAttribute); ok && samplerType.Str() != samplerTypeProbabilistic {
+					continue
+				}
+				s.countLocked(serviceName.Str(), span.Name())
+			}
+		}
+	}
+}
+
+func (s *Source) countLocked(serviceName, operation string) {
+	operations, ok := s.current[serviceName]
+	if !ok {
+		operations = map[string]int64{}
+		s.current[serviceName] = operations
+	}
+	if _, ok := operations[operation]; !ok && s.options.MaxOperationsPerService > 0 && len(operations) >= s.options.MaxOperationsPerService {
+		return
+	}
+	operations[operation]++
+}
+
+// GetSamplingStrategy implements source.Source.
+func (s *Source) GetSamplingStrategy(_ context.Context, serviceName string) (*api_v2.SamplingStrategyResponse, error) {
+	if strategy, ok := (*s.strategies.Load())[serviceName]; ok {
+		return strategy, nil
+	}
+	return s.strategyResponse(nil), nil
+}
+
+// Close stops calculating the probabilities and persists the last calculated
+// ones.
+func (s *Source) Close() error {
+	s.cancelFunc()
+	s.wg.Wait()
+	if s.client == nil {
+		return nil
+	}
+	ctx := context.Background()
+	return errors.Join(s.checkpointState(ctx), s.client.Close(ctx))
+}
+
+func (s *Source) runCalculationLoop(ctx context.Context) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.options.CalculationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.calculate()
+			if s.client == nil {
+				continue
+			}
+			if err := s.checkpointState(ctx); err != nil {
+				s.logger.Error("failed to persist the adaptive sampling state", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// calculate closes the current throughput bucket and recalculates the
+// probabilities of all operations seen within the aggregation buckets.
+// Operations that were not seen keep their last calculated probability, so
+// that an operation with sparse traffic is not reset to the initial
+// probability whenever its traces fall out of the buckets, until they were
+// not seen for the operation TTL.
+func (s *Source) calculate() {
+	now := s.now()
+	s.mu.Lock()
+	seen := s.current
+	s.buckets = append(s.buckets, s.current)
+	if len(s.buckets) > s.options.AggregationBuckets {
+		s.buckets = s.buckets[len(s.buckets)-s.options.AggregationBuckets:]
+	}
+	s.current = throughput{}
+
+	totals := throughput{}
+	for _, bucket := range s.buckets {
+		for serviceName, operations := range bucket {
+			if totals[serviceName] == nil {
+				totals[serviceName] = map[string]int64{}
+			}
+			for operation, count := range operations {
+				totals[serviceName][operation] += count
+			}
+		}
+	}
+
+	probabilities := make(map[string]map[string]float64, len(s.probabilities)+len(totals))
+	for serviceName, operations := range s.probabilities {
+		kept := make(map[string]float64, len(operations))
+		for operation, probability := range operations {
+			if _, ok := totals[serviceName][operation]; !ok && s.expiredLocked(serviceName, operation, now) {
+				delete(s.lastSeen[serviceName], operation)
+				continue
+			}
+			kept[operation] = probability
+		}
+		if len(kept) == 0 {
+			delete(s.lastSeen, serviceName)
+			continue
+		}
+		probabilities[serviceName] = kept
+	}
+
+	seconds := float64(len(s.buckets)) * s.options.CalculationInterval.Seconds()
+	for serviceName, operations := range totals {
+		if probabilities[serviceName] == nil {
+			probabilities[serviceName] = make(map[string]float64, len(operations))
+		}
+		for operation, count := range operations {
+			current, ok := probabilities[serviceName][operation]
+			if !ok {
+				if s.options.MaxOperationsPerService > 0 && len(probabilities[serviceName]) >= s.options.MaxOperationsPerService {
+					// the operations carried forward fill the limit
+					continue
+				}
+				current = s.options.InitialSamplingProbability
+			}
+			probabilities[serviceName][operation] = calculateProbability(current, float64(count)/seconds, s.options)
+			if _, ok := seen[serviceName][operation]; ok {
+				s.markSeenLocked(serviceName, operation, now)
+			}
+		}
+	}
+	s.probabilities = probabilities
+	s.mu.Unlock()
+
+	s.publish(probabilities)
+}
+
+// expiredLocked reports whether the operation was not seen for the operation TTL.
+func (s *Source) expiredLocked(serviceName, operation string, now time.Time) bool {
+	if s.options.OperationTTL <= 0 {
+		return false
+	}
+	lastSeen, ok := s.lastSeen[serviceName][operation]
+	return ok && now.Sub(lastSeen) > s.options.OperationTTL
+}
+
+func (s *Source) markSeenLocked(serviceName, operation string, now time.Time) {
+	operations, ok := s.lastSeen[serviceName]
+	if !ok {
+		operations = map[string]time.Time{}
+		s.lastSeen[serviceName] = operations
+	}
+	operations[operation] = now
+}
+
+// publish builds the strategies served to the clients from the given
+// probabilities, which must not be modified afterwards.
+func (s *Source) publish(probabilities map[string]map[string]float64) {
+	strategies := make(map[string]*api_v2.SamplingStrategyResponse, len(probabilities))
+	for serviceName, operations := range probabilities {
+		strategies[serviceName] = s.strategyResponse(operations)
+	}
+	s.strategies.Store(&strategies)
+}
+
+func (s *Source) strategyResponse(operations map[string]float64) *api_v2.SamplingStrategyResponse {
+	perOperation := make([]*api_v2.OperationSamplingStrategy, 0, len(operations))
+	for operation, probability := range operations {
+		perOperation = append(perOperation, &api_v2.OperationSamplingStrategy{
+			Operation: operation,
+			ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{
+				SamplingRate: probability,
+			},
+		})
+	}
+	sort.Slice(perOperation, func(i, j int) bool {
+		return perOperation[i].Operation < perOperation[j].Operation
+	})
+
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType: api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{
+			SamplingRate: s.options.InitialSamplingProbability,
+		},
+		OperationSampling: &api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       s.options.InitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: s.options.MinSamplesPerSecond,
+			PerOperationStrategies:           perOperation,
+		},
+	}
+}
+
+// restoreState restores the probabilities from the storage client. A state
+// that cannot be decoded is discarded with a warning rather than failing start.
+func (s *Source) restoreState(ctx context.Context) error {
+	data, err := s.client.Get(ctx, stateStorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read the adaptive sampling state: %w", err)
+	}
+	if len(data) == 0 {
+		s.logger.Debug("No adaptive sampling state found in storage")
+		return nil
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		s.logger.Warn("Discarding invalid adaptive sampling state", zap.Error(err))
+		return nil
+	}
+	if st.Probabilities != nil {
+		s.probabilities = st.Probabilities
+	}
+	// the operations restored without a last seen time expire after the
+	// operation TTL from now
+	now := s.now()
+	for serviceName, operations := range s.probabilities {
+		for operation := range operations {
+			if lastSeen, ok := st.LastSeen[serviceName][operation]; ok {
+				s.markSeenLocked(serviceName, operation, lastSeen)
+			} else {
+				s.markSeenLocked(serviceName, operation, now)
+			}
+		}
+	}
+	s.logger.Info("Restored adaptive sampling state", zap.Int("services", len(s.probabilities)))
+	return nil
+}
+
+// checkpointState persists the probabilities to the storage client.
+func (s *Source) checkpointState(ctx context.Context) error {
+	s.mu.Lock()
+	data, err := json.Marshal(state{Probabilities: s.probabilities, LastSeen: s.lastSeen})
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode the adaptive sampling state: %w", err)
+	}
+	if err := s.client.Set(ctx, stateStorageKey, data); err != nil {
+		return fmt.Errorf("failed to write the adaptive sampling state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adaptivesource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func testOptions() Options {
+	return Options{
+		TargetSamplesPerSecond:     1,
+		InitialSamplingProbability: 0.5,
+		MinSamplingProbability:     1e-5,
+		MinSamplesPerSecond:        1.0 / 60,
+		CalculationInterval:        time.Minute,
+		AggregationBuckets:         2,
+		DeltaTolerance:             0.3,
+		MaxOperationsPerService:    2,
+	}
+}
+
+func TestCalculateProbability(t *testing.T) {
+	opts := testOptions()
+	tests := []struct {
+		name     string
+		current  float64
+		qps      float64
+		expected float64
+	}{
+		{name: "within tolerance", current: 0.5, qps: 1.2, expected: 0.5},
+		{name: "decrease", current: 0.5, qps: 10, expected: 0.05},
+		{name: "increase", current: 0.1, qps: 0.68, expected: 0.1 / 0.68},
+		{name: "increase capped", current: 0.1, qps: 0.1, expected: 0.15},
+		{name: "no throughput", current: 0.1, qps: 0, expected: 0.15},
+		{name: "at most one", current: 0.9, qps: 0.1, expected: 1},
+		{name: "at least the minimum", current: 1e-5, qps: 1000, expected: 1e-5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, calculateProbability(tt.current, tt.qps, opts), 1e-9)
+		})
+	}
+}
+
+func TestAdaptiveSource(t *testing.T) {
+	s, err := NewAdaptiveSource(t.Context(), testOptions(), nil, zap.NewNop())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, s.Close()) }()
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "foo")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	appendRootSpans(spans, "a", 120)
+	appendRootSpans(spans, "b", 60)
+	// the operation limit is reached, spans of further operations are not counted
+	appendRootSpans(spans, "c", 60)
+	// child spans are not counted
+	child := spans.AppendEmpty()
+	child.SetName("a")
+	child.SetParentSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	// traces sampled by the lower bound sampler are not counted
+	lowerBound := spans.AppendEmpty()
+	lowerBound.SetName("b")
+	lowerBound.Attributes().PutStr("sampler.type", "lowerbound")
+	// resources without a service name are ignored
+	appendRootSpans(td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans(), "a", 10)
+
+	s.ObserveTraces(td)
+
+	// before the first calculation, all services get the default strategy
+	assertOperationProbabilities(t, s, "foo", map[string]float64{})
+
+	// a: 2 traces/s at 0.5 -> 0.25, b: 1 trace/s within tolerance
+	s.calculate()
+	assertOperationProbabilities(t, s, "foo", map[string]float64{"a": 0.25, "b": 0.5})
+
+	// averaged over two buckets, a: 1 trace/s within tolerance, b: 0.5 trace/s,
+	// capped increase from 0.5 to 0.75
+	s.calculate()
+	assertOperationProbabilities(t, s, "foo", map[string]float64{"a": 0.25, "b": 0.75})
+
+	// no throughput left in the buckets, operations keep their last probability
+	s.calculate()
+	assertOperationProbabilities(t, s, "foo", map[string]float64{"a": 0.25, "b": 0.75})
+
+	// operations carried forward count towards the limit of operations per service
+	td = ptrace.NewTraces()
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "foo")
+	appendRootSpans(rs.ScopeSpans().AppendEmpty().Spans(), "c", 60)
+	s.ObserveTraces(td)
+	s.calculate()
+	assertOperationProbabilities(t, s, "foo", map[string]float64{"a": 0.25, "b": 0.75})
+
+	strategy, err := s.GetSamplingStrategy(t.Context(), "bar")
+	require.NoError(t, err)
+	assert.Equal(t, api_v2.SamplingStrategyType_PROBABILISTIC, strategy.StrategyType)
+	assert.Equal(t, 0.5, strategy.ProbabilisticSampling.SamplingRate)
+	assert.Equal(t, 0.5, strategy.OperationSampling.DefaultSamplingProbability)
+	assert.Equal(t, 1.0/60, strategy.OperationSampling.DefaultLowerBoundTracesPerSecond)
+}
+
+func TestAdaptiveSourceOperationTTL(t *testing.T) {
+	opts := testOptions()
+	opts.OperationTTL = 3 * time.Minute
+	s, err := NewAdaptiveSource(t.Context(), opts, nil, zap.NewNop())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, s.Close()) }()
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+
+	observe := func(operations ...string) {
+		td := ptrace.NewTraces()
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", "foo")
+		for _, operation := range operations {
+			appendRootSpans(rs.ScopeSpans().AppendEmpty().Spans(), operation, 60)
+		}
+		s.ObserveTraces(td)
+	}
+
+	observe("a", "b")
+	s.calculate()
+	for range 3 {
+		now = now.Add(time.Minute)
+		observe("b")
+		s.calculate()
+	}
+	assertOperationProbabilities(t, s, "foo", map[string]float64{"a": 0.75, "b": 0.5})
+
+	// a was not seen for longer than the TTL
+	now = now.Add(time.Minute)
+	observe("b")
+	s.calculate()
+	assertOperationProbabilities(t, s, "foo", map[string]float64{"b": 0.5})
+
+	// services without operations left are removed
+	now = now.Add(5 * time.Minute)
+	s.calculate()
+	s.calculate()
+	assertOperationProbabilities(t, s, "foo", map[string]float64{})
+	assert.Empty(t, s.lastSeen)
+	assert.NotContains(t, *s.strategies.Load(), "foo")
+}
+
+func TestAdaptiveSourcePersistence(t *testing.T) {
+	client := newMemoryClient()
+	s, err := NewAdaptiveSource(t.Context(), testOptions(), client, zap.NewNop())
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "foo")
+	appendRootSpans(rs.ScopeSpans().AppendEmpty().Spans(), "a", 600)
+	s.ObserveTraces(td)
+	s.calculate()
+	assertOperationProbabilities(t, s, "foo", map[string]float64{"a": 0.05})
+	require.NoError(t, s.Close())
+	assert.True(t, client.closed)
+
+	client.closed = false
+	restored, err := NewAdaptiveSource(t.Context(), testOptions(), client, zap.NewNop())
+	require.NoError(t, err)
+	assertOperationProbabilities(t, restored, "foo", map[string]float64{"a": 0.05})
+	// the operations keep when they were last seen
+	assert.True(t, s.lastSeen["foo"]["a"].Equal(restored.lastSeen["foo"]["a"]))
+
+	// the restored probability is the base of the next calculation:
+	// 2 traces/s at 0.05 -> 0.025
+	rs.ScopeSpans().At(0).Spans().RemoveIf(func(ptrace.Span) bool { return true })
+	appendRootSpans(rs.ScopeSpans().At(0).Spans(), "a", 120)
+	restored.ObserveTraces(td)
+	restored.calculate()
+	assertOperationProbabilities(t, restored, "foo", map[string]float64{"a": 0.025})
+	require.NoError(t, restored.Close())
+}
+
+func TestAdaptiveSourceInvalidState(t *testing.T) {
+	client := newMemoryClient()
+	require.NoError(t, client.Set(t.Context(), stateStorageKey, []byte("{")))
+	s, err := NewAdaptiveSource(t.Context(), testOptions(), client, zap.NewNop())
+	require.NoError(t, err)
+	assertOperationProbabilities(t, s, "foo", map[string]float64{})
+	require.NoError(t, s.Close())
+}
+
+func TestAdaptiveSourceStorageError(t *testing.T) {
+	client := newMemoryClient()
+	client.err = errors.New("storage unavailable")
+	_, err := NewAdaptiveSource(t.Context(), testOptions(), client, zap.NewNop())
+	assert.ErrorContains(t, err, "storage unavailable")
+}
+
+func appendRootSpans(spans ptrace.SpanSlice, name string, n int) {
+	for range n {
+		spans.AppendEmpty().SetName(name)
+	}
+}
+
+func assertOperationProbabilities(t *testing.T, s *Source, serviceName string, expected map[string]float64) {
+	t.Helper()
+	strategy, err := s.GetSamplingStrategy(t.Context(), serviceName)
+	require.NoError(t, err)
+	actual := map[string]float64{}
+	for _, operation := range strategy.OperationSampling.PerOperationStrategies {
+		actual[operation.Operation] = operation.ProbabilisticSampling.SamplingRate
+	}
+	require.Len(t, actual, len(expected))
+	for operation, probability := range expected {
+		assert.InDelta(t, probability, actual[operation], 1e-9, operation)
+	}
+}
+
+type memoryClient struct {
+	data   map[string][]byte
+	err    error
+	closed bool
+}
+
+var _ storage.Client = (*memoryClient)(nil)
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{data: map[string][]byte{}}
+}
+
+func (c *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	return c.data[key], c.err
+}
+
+func (c *memoryClient) Set(_ context.Context, key string, value []byte) error {
+	c.data[key] = value
+	return c.err
+}
+
+func (c *memoryClient) Delete(_ context.Context, key string) error {
+	delete(c.data, key)
+	return c.err
+}
+
+func (c *memoryClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value, _ = c.Get(ctx, op.Key)
+		case storage.Set:
+			_ = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			_ = c.Delete(ctx, op.Key)
+		}
+	}
+	return c.err
+}
+
+func (c *memoryClient) Close(context.Context) error {
+	c.closed = true
+	return nil
+}
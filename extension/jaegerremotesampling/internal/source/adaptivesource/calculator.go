@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adaptivesource // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/source/adaptivesource"
+
+import (
+	"math"
+)
+
+// maxProbabilityIncrease caps how much a probability may grow in a single
+// calculation, as a fraction of the current probability. Decreases are not
+// capped, so that a traffic spike is brought under control quickly.
+const maxProbabilityIncrease = 0.5
+
+// calculateProbability returns the probability that brings the observed
+// throughput, sampled at the current probability, to the target.
+func calculateProbability(current, observedQPS float64, opts Options) float64 {
+	target := opts.TargetSamplesPerSecond
+	if math.Abs(observedQPS-target)/target <= opts.DeltaTolerance {
+		return current
+	}
+
+	var next float64
+	if observedQPS == 0 {
+		next = math.Inf(1)
+	} else {
+		next = current * target / observedQPS
+	}
+	if next > current {
+		next = math.Min(next, current*(1+maxProbabilityIncrease))
+	}
+	return math.Max(opts.MinSamplingProbability, math.Min(1, next))
+}
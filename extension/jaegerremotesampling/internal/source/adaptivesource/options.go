@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adaptivesource // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/source/adaptivesource"
+
+import (
+	"time"
+)
+
+// Options holds the configuration for the adaptive sampling source.
+type Options struct {
+	// TargetSamplesPerSecond is the number of traces per second each operation
+	// should be sampled at.
+	TargetSamplesPerSecond float64
+
+	// InitialSamplingProbability is the probability served for services and
+	// operations without enough observed throughput.
+	InitialSamplingProbability float64
+
+	// MinSamplingProbability is the lowest probability the source will calculate.
+	MinSamplingProbability float64
+
+	// MinSamplesPerSecond is the lower bound of traces per second served to the
+	// clients for each operation, regardless of the probability.
+	MinSamplesPerSecond float64
+
+	// CalculationInterval determines how often the probabilities are recalculated.
+	CalculationInterval time.Duration
+
+	// AggregationBuckets is the number of calculation intervals the throughput
+	// is averaged over.
+	AggregationBuckets int
+
+	// DeltaTolerance is the relative deviation of the observed throughput from
+	// the target within which the probability is left unchanged.
+	DeltaTolerance float64
+
+	// MaxOperationsPerService limits the number of operations tracked for each
+	// service. Root spans of further operations are not counted.
+	MaxOperationsPerService int
+
+	// OperationTTL is how long an operation keeps its probability after it was
+	// last observed. Zero keeps the operations forever.
+	OperationTTL time.Duration
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jwtauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension"
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+var _ client.AuthData = (*authData)(nil)
+
+// reservedAttributes are always set on the auth data and cannot be mapped from claims.
+var reservedAttributes = []string{"subject", "issuer", "raw"}
+
+type authData struct {
+	raw        string
+	subject    string
+	issuer     string
+	attributes map[string]any
+}
+
+func (a *authData) GetAttribute(name string) any {
+	switch name {
+	case "subject":
+		return a.subject
+	case "issuer":
+		return a.issuer
+	case "raw":
+		return a.raw
+	default:
+		if v, ok := a.attributes[name]; ok {
+			return v
+		}
+		return nil
+	}
+}
+
+func (a *authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(reservedAttributes)+len(a.attributes))
+	names = append(names, reservedAttributes...)
+	for name := range a.attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names[len(reservedAttributes):])
+	return names
+}
+
+// lookupClaim returns the claim at the given path, where nested claims are separated by dots.
+// A claim whose name contains dots takes precedence over the nested lookup.
+func lookupClaim(claims map[string]any, path string) (any, bool) {
+	if v, ok := claims[path]; ok {
+		return v, true
+	}
+	head, rest, found := strings.Cut(path, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := claims[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return lookupClaim(nested, rest)
+}
+
+// claimValue converts a claim to an auth data attribute value. Processors reading
+// auth data handle strings and string slices, so claims are converted to either.
+func claimValue(v any) any {
+	switch v := v.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, claimString(item))
+		}
+		return values
+	default:
+		return claimString(v)
+	}
+}
+
+func claimString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
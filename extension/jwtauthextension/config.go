@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jwtauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+var (
+	errNoKeys                 = errors.New("at least one of 'jwks_file' or 'static_keys' must be set")
+	errNegativeLeeway         = errors.New("'leeway' must not be negative")
+	errMissingKeyAlgorithm    = errors.New("'algorithm' must be set for a static key")
+	errMissingKeyMaterial     = errors.New("exactly one of 'public_key' or 'secret' must be set for a static key")
+	errSecretAlgorithm        = errors.New("'secret' can only be used with the HS256, HS384 or HS512 algorithms")
+	errPublicKeyAlgorithm     = errors.New("'public_key' cannot be used with the HS256, HS384 or HS512 algorithms")
+	errEmptyClaimAttribute    = errors.New("'claim_attributes' entries must have a non-empty attribute and claim")
+	errReservedClaimAttribute = errors.New("'claim_attributes' must not override the 'subject', 'issuer' or 'raw' attributes")
+)
+
+// Config has the configuration for the JWT Authenticator extension.
+type Config struct {
+	// Attribute is the header holding the token, in the "<scheme> <token>" form.
+	// Optional, default value: "authorization".
+	Attribute string `mapstructure:"attribute"`
+
+	// JWKSFile is the path to a JSON Web Key Set. The file is reloaded when it changes.
+	JWKSFile string `mapstructure:"jwks_file"`
+
+	// StaticKeys are keys configured inline, used together with the keys of JWKSFile.
+	StaticKeys []StaticKeyConfig `mapstructure:"static_keys"`
+
+	// Algorithms restricts the accepted signature algorithms. When empty, the asymmetric
+	// algorithms and the algorithms of the static keys are accepted.
+	Algorithms []string `mapstructure:"algorithms"`
+
+	// Issuer is the expected value of the "iss" claim. Optional.
+	Issuer string `mapstructure:"issuer"`
+
+	// Audiences are the accepted values of the "aud" claim. A token is accepted
+	// when it has at least one of them. Optional.
+	Audiences []string `mapstructure:"audiences"`
+
+	// RequiredClaims are claims that must be present on the token. Nested
+	// claims are referenced with dots, like "tenant.id".
+	RequiredClaims []string `mapstructure:"required_claims"`
+
+	// ClaimAttributes maps auth data attribute names to the claims providing their values.
+	// Nested claims are referenced with dots, like "tenant.id".
+	ClaimAttributes map[string]string `mapstructure:"claim_attributes"`
+
+	// Leeway is the clock skew tolerated when checking the "exp", "nbf" and "iat" claims.
+	Leeway time.Duration `mapstructure:"leeway"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// StaticKeyConfig is a verification key configured inline.
+type StaticKeyConfig struct {
+	// KeyID matches the "kid" header of the tokens. Optional.
+	KeyID string `mapstructure:"key_id"`
+
+	// Algorithm is the signature algorithm the key is used with, like "RS256" or "HS256".
+	Algorithm string `mapstructure:"algorithm"`
+
+	// PublicKey is a PEM encoded public key or certificate, for asymmetric algorithms.
+	PublicKey string `mapstructure:"public_key"`
+
+	// Secret is the shared secret, for the HMAC algorithms.
+	Secret configopaque.String `mapstructure:"secret"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.JWKSFile == "" && len(cfg.StaticKeys) == 0 {
+		errs = append(errs, errNoKeys)
+	}
+	if cfg.Leeway < 0 {
+		errs = append(errs, errNegativeLeeway)
+	}
+	for _, alg := range cfg.Algorithms {
+		if !isSupportedAlgorithm(alg) {
+			errs = append(errs, fmt.Errorf("unsupported algorithm %q", alg))
+		}
+	}
+	for i, key := range cfg.StaticKeys {
+		if err := key.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("static_keys[%d]: %w", i, err))
+		}
+	}
+	for attribute, claim := range cfg.ClaimAttributes {
+		if attribute == "" || claim == "" {
+			errs = append(errs, errEmptyClaimAttribute)
+			continue
+		}
+		if slices.Contains(reservedAttributes, attribute) {
+			errs = append(errs, errReservedClaimAttribute)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (k *StaticKeyConfig) Validate() error {
+	if k.Algorithm == "" {
+		return errMissingKeyAlgorithm
+	}
+	if !isSupportedAlgorithm(k.Algorithm) {
+		return fmt.Errorf("unsupported algorithm %q", k.Algorithm)
+	}
+	if (k.PublicKey == "") == (k.Secret == "") {
+		return errMissingKeyMaterial
+	}
+	if k.Secret != "" && !isHMACAlgorithm(k.Algorithm) {
+		return errSecretAlgorithm
+	}
+	if k.PublicKey != "" && isHMACAlgorithm(k.Algorithm) {
+		return errPublicKeyAlgorithm
+	}
+	return nil
+}
+
+// asymmetricAlgorithms are accepted by default, as verifying them only requires public keys.
+var asymmetricAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+var hmacAlgorithms = []jose.SignatureAlgorithm{jose.HS256, jose.HS384, jose.HS512}
+
+func isSupportedAlgorithm(alg string) bool {
+	return slices.Contains(asymmetricAlgorithms, jose.SignatureAlgorithm(alg)) || isHMACAlgorithm(alg)
+}
+
+func isHMACAlgorithm(alg string) bool {
+	return slices.Contains(hmacAlgorithms, jose.SignatureAlgorithm(alg))
+}
+
+// acceptedAlgorithms returns the signature algorithms tokens may be signed with.
+func (cfg *Config) acceptedAlgorithms() []jose.SignatureAlgorithm {
+	if len(cfg.Algorithms) > 0 {
+		algs := make([]jose.SignatureAlgorithm, 0, len(cfg.Algorithms))
+		for _, alg := range cfg.Algorithms {
+			algs = append(algs, jose.SignatureAlgorithm(alg))
+		}
+		return algs
+	}
+	algs := slices.Clone(asymmetricAlgorithms)
+	for _, key := range cfg.StaticKeys {
+		if alg := jose.SignatureAlgorithm(key.Algorithm); !slices.Contains(algs, alg) {
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jwtauthextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr error
+	}{
+		{
+			id:          component.NewID(metadata.Type),
+			expectedErr: errNoKeys,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "jwks"),
+			expected: &Config{
+				Attribute:      defaultAttribute,
+				JWKSFile:       "/etc/otelcol/jwks.json",
+				Issuer:         "https://issuer.example.com",
+				Audiences:      []string{"collector"},
+				RequiredClaims: []string{"tenant.id"},
+				ClaimAttributes: map[string]string{
+					"tenant": "tenant.id",
+					"groups": "groups",
+				},
+				Leeway: 30 * time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "static"),
+			expected: &Config{
+				Attribute:  "x-token",
+				Algorithms: []string{"HS256"},
+				StaticKeys: []StaticKeyConfig{
+					{
+						KeyID:     "shared",
+						Algorithm: "HS256",
+						Secret:    "a-shared-secret-of-at-least-32-bytes",
+					},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidkey"),
+			expectedErr: errSecretAlgorithm,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, xconfmap.Validate(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name: "negative leeway",
+			cfg: &Config{
+				JWKSFile: "jwks.json",
+				Leeway:   -time.Second,
+			},
+			expectedErr: errNegativeLeeway.Error(),
+		},
+		{
+			name: "unsupported algorithm",
+			cfg: &Config{
+				JWKSFile:   "jwks.json",
+				Algorithms: []string{"none"},
+			},
+			expectedErr: `unsupported algorithm "none"`,
+		},
+		{
+			name: "static key without algorithm",
+			cfg: &Config{
+				StaticKeys: []StaticKeyConfig{{Secret: "secret"}},
+			},
+			expectedErr: errMissingKeyAlgorithm.Error(),
+		},
+		{
+			name: "static key with both public key and secret",
+			cfg: &Config{
+				StaticKeys: []StaticKeyConfig{{Algorithm: "HS256", Secret: "secret", PublicKey: "key"}},
+			},
+			expectedErr: errMissingKeyMaterial.Error(),
+		},
+		{
+			name: "static public key with HMAC algorithm",
+			cfg: &Config{
+				StaticKeys: []StaticKeyConfig{{Algorithm: "HS256", PublicKey: "key"}},
+			},
+			expectedErr: errPublicKeyAlgorithm.Error(),
+		},
+		{
+			name: "empty claim attribute",
+			cfg: &Config{
+				JWKSFile:        "jwks.json",
+				ClaimAttributes: map[string]string{"tenant": ""},
+			},
+			expectedErr: errEmptyClaimAttribute.Error(),
+		},
+		{
+			name: "reserved claim attribute",
+			cfg: &Config{
+				JWKSFile:        "jwks.json",
+				ClaimAttributes: map[string]string{"subject": "email"},
+			},
+			expectedErr: errReservedClaimAttribute.Error(),
+		},
+		{
+			name: "valid",
+			cfg: &Config{
+				JWKSFile:        "jwks.json",
+				Algorithms:      []string{"RS256", "ES256"},
+				ClaimAttributes: map[string]string{"tenant": "tenant_id"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestAcceptedAlgorithms(t *testing.T) {
+	cfg := &Config{
+		StaticKeys: []StaticKeyConfig{{Algorithm: "HS256"}, {Algorithm: "RS256"}},
+	}
+	algs := cfg.acceptedAlgorithms()
+	assert.Contains(t, algs, jose.HS256)
+	assert.Contains(t, algs, jose.ES256)
+	assert.NotContains(t, algs, jose.HS512)
+
+	cfg.Algorithms = []string{"ES256"}
+	assert.Equal(t, []jose.SignatureAlgorithm{jose.ES256}, cfg.acceptedAlgorithms())
+}
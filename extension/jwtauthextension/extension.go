@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jwtauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.uber.org/zap"
+)
+
+var (
+	_ extension.Extension  = (*jwtExtension)(nil)
+	_ extensionauth.Server = (*jwtExtension)(nil)
+)
+
+var (
+	errNotAuthenticated                  = errors.New("authentication didn't succeed")
+	errInvalidAuthenticationHeaderFormat = errors.New("invalid authorization header format")
+	errNoMatchingKey                     = errors.New("no key matches the token's key ID and algorithm")
+	errInvalidSignature                  = errors.New("the token's signature doesn't match any of the keys")
+	errMissingExpiry                     = errors.New("the token has no expiration time")
+)
+
+type jwtExtension struct {
+	cfg        *Config
+	algorithms []jose.SignatureAlgorithm
+	keys       *keyStore
+	now        func() time.Time
+}
+
+func newExtension(cfg *Config, logger *zap.Logger) (*jwtExtension, error) {
+	if cfg.Attribute == "" {
+		cfg.Attribute = defaultAttribute
+	}
+
+	keys, err := newKeyStore(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &jwtExtension{
+		cfg:        cfg,
+		algorithms: cfg.acceptedAlgorithms(),
+		keys:       keys,
+		now:        time.Now,
+	}, nil
+}
+
+func (e *jwtExtension) Start(context.Context, component.Host) error {
+	return e.keys.start()
+}
+
+func (e *jwtExtension) Shutdown(context.Context) error {
+	return e.keys.shutdown()
+}
+
+// Authenticate checks whether the given headers contain a valid token. Successfully authenticated calls
+// return a context with the auth data holding the subject, issuer and mapped claims of the token.
+func (e *jwtExtension) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	var authHeaders []string
+	for k, v := range headers {
+		if strings.EqualFold(k, e.cfg.Attribute) {
+			authHeaders = v
+			break
+		}
+	}
+	if len(authHeaders) == 0 {
+		return ctx, errNotAuthenticated
+	}
+
+	// we only use the first header, if multiple values exist
+	parts := strings.Split(authHeaders[0], " ")
+	if len(parts) != 2 {
+		return ctx, errInvalidAuthenticationHeaderFormat
+	}
+	raw := parts[1]
+
+	token, err := jwt.ParseSigned(raw, e.algorithms)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to parse the token: %w", err)
+	}
+	registered, claims, err := e.verify(token)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to verify token: %w", err)
+	}
+	if err := e.validate(registered, claims); err != nil {
+		return ctx, fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	attributes := make(map[string]any, len(e.cfg.ClaimAttributes))
+	for attribute, claim := range e.cfg.ClaimAttributes {
+		if v, ok := lookupClaim(claims, claim); ok {
+			attributes[attribute] = claimValue(v)
+		}
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{
+		raw:        raw,
+		subject:    registered.Subject,
+		issuer:     registered.Issuer,
+		attributes: attributes,
+	}
+	return client.NewContext(ctx, cl), nil
+}
+
+// verify checks the token's signature against the candidate keys and returns its claims.
+func (e *jwtExtension) verify(token *jwt.JSONWebToken) (jwt.Claims, map[string]any, error) {
+	// ParseSigned only returns tokens with exactly one signature.
+	header := token.Headers[0]
+	keys := e.keys.candidates(header.KeyID, header.Algorithm)
+	if len(keys) == 0 {
+		return jwt.Claims{}, nil, errNoMatchingKey
+	}
+	for _, key := range keys {
+		var registered jwt.Claims
+		claims := map[string]any{}
+		if err := token.Claims(key.Key, &registered, &claims); err == nil {
+			return registered, claims, nil
+		}
+	}
+	return jwt.Claims{}, nil, errInvalidSignature
+}
+
+func (e *jwtExtension) validate(registered jwt.Claims, claims map[string]any) error {
+	if registered.Expiry == nil {
+		return errMissingExpiry
+	}
+	expected := jwt.Expected{
+		Issuer:      e.cfg.Issuer,
+		AnyAudience: e.cfg.Audiences,
+		Time:        e.now(),
+	}
+	if err := registered.ValidateWithLeeway(expected, e.cfg.Leeway); err != nil {
+		return err
+	}
+	for _, claim := range e.cfg.RequiredClaims {
+		if _, ok := lookupClaim(claims, claim); !ok {
+			return fmt.Errorf("the required claim %q is missing", claim)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jwtauthextension
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+const testSecret = "a-shared-secret-of-at-least-32-bytes"
+
+func signToken(t *testing.T, key jose.JSONWebKey, alg jose.SignatureAlgorithm, claims map[string]any) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, (&jose.SignerOptions{}).WithType("JWT"))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func writeJWKS(t *testing.T, path string, keys ...jose.JSONWebKey) {
+	t.Helper()
+	b, err := json.Marshal(jose.JSONWebKeySet{Keys: keys})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub": "jdoe@example.com",
+		"iss": "https://issuer.example.com",
+		"aud": []string{"collector"},
+		"exp": time.Now().Add(time.Minute).Unix(),
+		"tenant": map[string]any{
+			"id": "acme",
+		},
+		"groups": []string{"dev", "ops"},
+		"level":  3,
+	}
+}
+
+func authenticate(t *testing.T, ext *jwtExtension, token string) (client.AuthData, error) {
+	t.Helper()
+	ctx, err := ext.Authenticate(t.Context(), map[string][]string{"Authorization": {"Bearer " + token}})
+	if err != nil {
+		return nil, err
+	}
+	return client.FromContext(ctx).Auth, nil
+}
+
+func TestAuthenticateJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path,
+		jose.JSONWebKey{Key: &rsaKey.PublicKey, KeyID: "rsa", Algorithm: "RS256", Use: "sig"},
+		// private keys in the set are reduced to their public part
+		jose.JSONWebKey{Key: ecKey, KeyID: "ec", Algorithm: "ES256"},
+	)
+
+	ext, err := newExtension(&Config{
+		JWKSFile:       path,
+		Issuer:         "https://issuer.example.com",
+		Audiences:      []string{"collector", "gateway"},
+		RequiredClaims: []string{"tenant.id"},
+		ClaimAttributes: map[string]string{
+			"tenant": "tenant.id",
+			"groups": "groups",
+			"level":  "level",
+			"region": "region",
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(t.Context()))
+	}()
+
+	for _, tc := range []struct {
+		name string
+		key  jose.JSONWebKey
+		alg  jose.SignatureAlgorithm
+	}{
+		{name: "rsa", key: jose.JSONWebKey{Key: rsaKey, KeyID: "rsa"}, alg: jose.RS256},
+		{name: "ecdsa", key: jose.JSONWebKey{Key: ecKey, KeyID: "ec"}, alg: jose.ES256},
+		{name: "no key id", key: jose.JSONWebKey{Key: rsaKey}, alg: jose.RS256},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			auth, err := authenticate(t, ext, signToken(t, tc.key, tc.alg, validClaims()))
+			require.NoError(t, err)
+			assert.Equal(t, "jdoe@example.com", auth.GetAttribute("subject"))
+			assert.Equal(t, "https://issuer.example.com", auth.GetAttribute("issuer"))
+			assert.Equal(t, "acme", auth.GetAttribute("tenant"))
+			assert.Equal(t, []string{"dev", "ops"}, auth.GetAttribute("groups"))
+			assert.Equal(t, "3", auth.GetAttribute("level"))
+			assert.Nil(t, auth.GetAttribute("region"))
+			assert.Equal(t, []string{"subject", "issuer", "raw", "groups", "level", "tenant"}, auth.GetAttributeNames())
+		})
+	}
+}
+
+func TestAuthenticateRejected(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, jose.JSONWebKey{Key: &rsaKey.PublicKey, KeyID: "rsa", Algorithm: "RS256", Use: "sig"})
+
+	ext, err := newExtension(&Config{
+		JWKSFile:       path,
+		Issuer:         "https://issuer.example.com",
+		Audiences:      []string{"collector"},
+		RequiredClaims: []string{"tenant.id"},
+		StaticKeys:     []StaticKeyConfig{{KeyID: "hmac", Algorithm: "HS256", Secret: testSecret}},
+		Algorithms:     []string{"RS256"},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(t.Context()))
+	}()
+
+	signingKey := jose.JSONWebKey{Key: rsaKey, KeyID: "rsa"}
+	withClaim := func(name string, value any) map[string]any {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	for _, tc := range []struct {
+		name        string
+		token       string
+		expectedErr string
+	}{
+		{
+			name:        "wrong issuer",
+			token:       signToken(t, signingKey, jose.RS256, withClaim("iss", "https://other.example.com")),
+			expectedErr: jwt.ErrInvalidIssuer.Error(),
+		},
+		{
+			name:        "wrong audience",
+			token:       signToken(t, signingKey, jose.RS256, withClaim("aud", []string{"other"})),
+			expectedErr: jwt.ErrInvalidAudience.Error(),
+		},
+		{
+			name:        "expired",
+			token:       signToken(t, signingKey, jose.RS256, withClaim("exp", time.Now().Add(-time.Minute).Unix())),
+			expectedErr: jwt.ErrExpired.Error(),
+		},
+		{
+			name:        "no expiry",
+			token:       signToken(t, signingKey, jose.RS256, withClaim("exp", nil)),
+			expectedErr: errMissingExpiry.Error(),
+		},
+		{
+			name:        "missing required claim",
+			token:       signToken(t, signingKey, jose.RS256, withClaim("tenant", nil)),
+			expectedErr: `the required claim "tenant.id" is missing`,
+		},
+		{
+			name:        "unknown key",
+			token:       signToken(t, jose.JSONWebKey{Key: otherKey, KeyID: "other"}, jose.RS256, validClaims()),
+			expectedErr: errNoMatchingKey.Error(),
+		},
+		{
+			name:        "wrong signature",
+			token:       signToken(t, jose.JSONWebKey{Key: otherKey, KeyID: "rsa"}, jose.RS256, validClaims()),
+			expectedErr: errInvalidSignature.Error(),
+		},
+		{
+			name:        "algorithm not accepted",
+			token:       signToken(t, jose.JSONWebKey{Key: []byte(testSecret), KeyID: "hmac"}, jose.HS256, validClaims()),
+			expectedErr: "failed to parse the token",
+		},
+		{
+			name:        "malformed",
+			token:       "not-a-token",
+			expectedErr: "failed to parse the token",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := authenticate(t, ext, tc.token)
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+
+	_, err = ext.Authenticate(t.Context(), map[string][]string{})
+	assert.ErrorIs(t, err, errNotAuthenticated)
+	_, err = ext.Authenticate(t.Context(), map[string][]string{"authorization": {"token"}})
+	assert.ErrorIs(t, err, errInvalidAuthenticationHeaderFormat)
+}
+
+func TestAuthenticateStaticKey(t *testing.T) {
+	ext, err := newExtension(&Config{
+		Attribute:  "x-token",
+		StaticKeys: []StaticKeyConfig{{KeyID: "shared", Algorithm: "HS256", Secret: testSecret}},
+		Leeway:     time.Minute,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(t.Context()))
+	}()
+
+	claims := validClaims()
+	// expired, but within the leeway
+	claims["exp"] = time.Now().Add(-30 * time.Second).Unix()
+	token := signToken(t, jose.JSONWebKey{Key: []byte(testSecret), KeyID: "shared"}, jose.HS256, claims)
+
+	ctx, err := ext.Authenticate(t.Context(), map[string][]string{"X-Token": {"Bearer " + token}})
+	require.NoError(t, err)
+	auth := client.FromContext(ctx).Auth
+	assert.Equal(t, "jdoe@example.com", auth.GetAttribute("subject"))
+	assert.Equal(t, token, auth.GetAttribute("raw"))
+}
+
+func TestJWKSReload(t *testing.T) {
+	firstKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	secondKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, jose.JSONWebKey{Key: &firstKey.PublicKey, KeyID: "first", Algorithm: "RS256"})
+
+	ext, err := newExtension(&Config{JWKSFile: path}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ext.Shutdown(t.Context()))
+	}()
+
+	secondToken := signToken(t, jose.JSONWebKey{Key: secondKey, KeyID: "second"}, jose.RS256, validClaims())
+	_, err = authenticate(t, ext, secondToken)
+	require.ErrorIs(t, err, errNoMatchingKey)
+
+	writeJWKS(t, path, jose.JSONWebKey{Key: &secondKey.PublicKey, KeyID: "second", Algorithm: "RS256"})
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		_, err := authenticate(t, ext, secondToken)
+		assert.NoError(tt, err)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// an invalid set keeps the previous keys
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	time.Sleep(100 * time.Millisecond)
+	_, err = authenticate(t, ext, secondToken)
+	assert.NoError(t, err)
+}
+
+func TestJWKSMissing(t *testing.T) {
+	ext, err := newExtension(&Config{JWKSFile: filepath.Join(t.TempDir(), "missing.json")}, zap.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, ext.Start(t.Context(), componenttest.NewNopHost()), "failed to read JWKS file")
+	assert.NoError(t, ext.Shutdown(t.Context()))
+}
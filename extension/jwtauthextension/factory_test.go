@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jwtauthextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{Attribute: defaultAttribute}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestFactory_Create(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.StaticKeys = []StaticKeyConfig{{Algorithm: "HS256", Secret: "a-shared-secret-of-at-least-32-bytes"}}
+	ext, err := createExtension(t.Context(), extensiontest.NewNopSettings(extensiontest.NopType), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
+
+func TestFactory_CreateInvalidKey(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.StaticKeys = []StaticKeyConfig{{Algorithm: "RS256", PublicKey: "not a PEM key"}}
+	_, err := createExtension(t.Context(), extensiontest.NewNopSettings(extensiontest.NopType), cfg)
+	assert.ErrorContains(t, err, "static_keys[0]")
+}
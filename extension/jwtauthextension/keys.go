@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jwtauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension"
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/go-jose/go-jose/v4"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+)
+
+// keyStore holds the verification keys: the static keys from the configuration
+// and the keys of the JWKS file, which is watched and reloaded when it changes.
+type keyStore struct {
+	path   string
+	logger *zap.Logger
+
+	static  []jose.JSONWebKey
+	jwks    atomic.Pointer[jose.JSONWebKeySet]
+	watcher *filewatcher.Watcher
+}
+
+func newKeyStore(cfg *Config, logger *zap.Logger) (*keyStore, error) {
+	ks := &keyStore{
+		path:   cfg.JWKSFile,
+		logger: logger,
+	}
+	for i, key := range cfg.StaticKeys {
+		jwk, err := parseStaticKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("static_keys[%d]: %w", i, err)
+		}
+		ks.static = append(ks.static, jwk)
+	}
+	return ks, nil
+}
+
+func parseStaticKey(cfg StaticKeyConfig) (jose.JSONWebKey, error) {
+	jwk := jose.JSONWebKey{
+		KeyID:     cfg.KeyID,
+		Algorithm: cfg.Algorithm,
+		Use:       "sig",
+	}
+	if cfg.Secret != "" {
+		jwk.Key = []byte(cfg.Secret)
+		return jwk, nil
+	}
+
+	block, _ := pem.Decode([]byte(cfg.PublicKey))
+	if block == nil {
+		return jwk, errors.New("failed to decode the PEM encoded public key")
+	}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return jwk, fmt.Errorf("failed to parse the certificate: %w", err)
+		}
+		jwk.Key = cert.PublicKey
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return jwk, fmt.Errorf("failed to parse the public key: %w", err)
+		}
+		jwk.Key = key
+	default:
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return jwk, fmt.Errorf("failed to parse the public key: %w", err)
+		}
+		jwk.Key = key
+	}
+	return jwk, nil
+}
+
+func (ks *keyStore) start() error {
+	if ks.path == "" {
+		return nil
+	}
+	if err := ks.load(); err != nil {
+		return err
+	}
+
+	watcher, err := filewatcher.New(ks.path, ks.logger, ks.load)
+	if err != nil {
+		return err
+	}
+	ks.watcher = watcher
+	return nil
+}
+
+func (ks *keyStore) load() error {
+	b, err := os.ReadFile(ks.path)
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal(b, &jwks); err != nil {
+		return fmt.Errorf("failed to parse JWKS file: %w", err)
+	}
+	for i, key := range jwks.Keys {
+		// Only the public part of asymmetric keys is needed for verification.
+		if !key.IsPublic() {
+			if public := key.Public(); public.Valid() {
+				jwks.Keys[i] = public
+			}
+		}
+	}
+	ks.jwks.Store(&jwks)
+	return nil
+}
+
+func (ks *keyStore) shutdown() error {
+	if ks.watcher == nil {
+		return nil
+	}
+	err := ks.watcher.Close()
+	ks.watcher = nil
+	return err
+}
+
+// candidates returns the signing keys that may have signed a token with the
+// given "kid" and "alg" headers. A token without "kid" is tried against every key.
+func (ks *keyStore) candidates(keyID, algorithm string) []jose.JSONWebKey {
+	keys := ks.static
+	if jwks := ks.jwks.Load(); jwks != nil {
+		keys = append(keys[:len(keys):len(keys)], jwks.Keys...)
+	}
+
+	var candidates []jose.JSONWebKey
+	for _, key := range keys {
+		if keyID != "" && key.KeyID != keyID {
+			continue
+		}
+		if key.Algorithm != "" && key.Algorithm != algorithm {
+			continue
+		}
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		candidates = append(candidates, key)
+	}
+	return candidates
+}
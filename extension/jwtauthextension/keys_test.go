@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jwtauthextension
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStaticKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pkix, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+
+	jwk, err := parseStaticKey(StaticKeyConfig{
+		Algorithm: "ES256",
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix})),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &ecKey.PublicKey, jwk.Key)
+
+	jwk, err = parseStaticKey(StaticKeyConfig{
+		KeyID:     "rsa",
+		Algorithm: "RS256",
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)})),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &rsaKey.PublicKey, jwk.Key)
+	assert.Equal(t, "rsa", jwk.KeyID)
+
+	jwk, err = parseStaticKey(StaticKeyConfig{Algorithm: "HS256", Secret: testSecret})
+	require.NoError(t, err)
+	assert.Equal(t, []byte(testSecret), jwk.Key)
+
+	_, err = parseStaticKey(StaticKeyConfig{Algorithm: "RS256", PublicKey: "not a key"})
+	assert.ErrorContains(t, err, "failed to decode the PEM encoded public key")
+}
+
+func TestCandidates(t *testing.T) {
+	ks := &keyStore{
+		static: []jose.JSONWebKey{
+			{KeyID: "a", Algorithm: "RS256", Use: "sig"},
+			{KeyID: "b", Algorithm: "ES256"},
+			{KeyID: "c"},
+			{KeyID: "d", Use: "enc"},
+		},
+	}
+	ks.jwks.Store(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{KeyID: "e", Algorithm: "RS256"}}})
+
+	keyIDs := func(keys []jose.JSONWebKey) []string {
+		var ids []string
+		for _, key := range keys {
+			ids = append(ids, key.KeyID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"a"}, keyIDs(ks.candidates("a", "RS256")))
+	assert.Empty(t, ks.candidates("a", "ES256"))
+	assert.Equal(t, []string{"a", "c", "e"}, keyIDs(ks.candidates("", "RS256")))
+	assert.Empty(t, ks.candidates("d", "RS256"))
+	assert.Len(t, ks.static, 4)
+}
+
+func TestLookupClaim(t *testing.T) {
+	claims := map[string]any{
+		"tenant":    map[string]any{"id": "acme"},
+		"dotted.id": "dotted",
+	}
+	v, ok := lookupClaim(claims, "tenant.id")
+	assert.True(t, ok)
+	assert.Equal(t, "acme", v)
+	v, ok = lookupClaim(claims, "dotted.id")
+	assert.True(t, ok)
+	assert.Equal(t, "dotted", v)
+	_, ok = lookupClaim(claims, "tenant.name")
+	assert.False(t, ok)
+
+	assert.Equal(t, "1.5", claimValue(1.5))
+	assert.Equal(t, "true", claimValue(true))
+	assert.Equal(t, `{"id":"acme"}`, claimValue(claims["tenant"]))
+	assert.Equal(t, []string{"a", "2"}, claimValue([]any{"a", float64(2)}))
+}
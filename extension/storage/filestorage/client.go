@@ -37,6 +37,10 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	// encryption is nil when the values are stored unencrypted
+	encryption *encryption
+	// quota is nil when the size of the stored data is not limited
+	quota *clientQuota
 }
 
 func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
@@ -48,7 +52,7 @@ func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool, enc *encryption, quota *clientQuota) (*fileStorageClient, error) {
 	options := bboltOptions(timeout, noSync)
 	db, err := bbolt.Open(filePath, 0o600, options)
 	if err != nil {
@@ -64,7 +68,14 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	if quota != nil {
+		if err := quota.init(db); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, encryption: enc, quota: quota}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -94,17 +105,18 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 }
 
 // Batch executes the specified operations in order. Get operation results are updated in place
-func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
-	batch := func(tx *bbolt.Tx) error {
-		bucket := tx.Bucket(defaultBucket)
-		if bucket == nil {
-			return errors.New("storage not initialized")
-		}
-
+func (c *fileStorageClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	batch := func(_ *bbolt.Bucket, bucket kvBucket) error {
 		var err error
 		for _, op := range ops {
-			switch op.Type {
-			case storage.Get:
+			switch {
+			case c.encryption != nil && op.Type == storage.Get:
+				op.Value, err = c.encryption.get(bucket, op.Key)
+			case c.encryption != nil && op.Type == storage.Set:
+				err = c.encryption.put(bucket, op.Key, op.Value)
+			case c.encryption != nil && op.Type == storage.Delete:
+				err = c.encryption.delete(bucket, op.Key)
+			case op.Type == storage.Get:
 				value := bucket.Get([]byte(op.Key))
 				if value != nil {
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
@@ -114,9 +126,9 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation)
 				} else {
 					op.Value = nil
 				}
-			case storage.Set:
+			case op.Type == storage.Set:
 				err = bucket.Put([]byte(op.Key), op.Value)
-			case storage.Delete:
+			case op.Type == storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
 				return errors.New("wrong operation type")
@@ -126,13 +138,48 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation)
 				return err
 			}
 		}
-
 		return nil
 	}
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	return c.update(ctx, true, batch)
+}
+
+// update runs fn in a write transaction, with the bucket of the client and the bucket through which the
+// values are written, which accounts for the quota if there is one. The quota is enforced when enforce is
+// set, otherwise the size changes are only accounted for.
+func (c *fileStorageClient) update(ctx context.Context, enforce bool, fn func(raw *bbolt.Bucket, bucket kvBucket) error) error {
+	var qb *quotaBucket
+	err := c.db.Update(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(defaultBucket)
+		if raw == nil {
+			return errors.New("storage not initialized")
+		}
+
+		var bucket kvBucket = raw
+		if c.quota != nil {
+			qb = c.quota.bucket(raw)
+			bucket = qb
+		}
+		if err := fn(raw, bucket); err != nil {
+			return err
+		}
+
+		switch {
+		case qb == nil:
+			return nil
+		case enforce:
+			return qb.enforce()
+		default:
+			qb.account()
+			return nil
+		}
+	})
+	if qb != nil {
+		qb.done(ctx, err)
+	}
+	return err
 }
 
 // Close will close the database
@@ -143,6 +190,9 @@ func (c *fileStorageClient) Close(_ context.Context) error {
 	if c.cancel != nil {
 		c.cancel()
 	}
+	if c.quota != nil && !c.closed {
+		c.quota.release()
+	}
 	c.closed = true
 	return c.db.Close()
 }
@@ -195,6 +245,27 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 
 	compactionStart := time.Now()
 
+	// values encrypted with a previous key are re-encrypted before being copied to the compacted db
+	if c.encryption != nil {
+		reencrypted, skipped, reencryptErr := c.encryption.reencrypt(maxTransactionSize, func(fn func(*bbolt.Bucket, kvBucket) error) error {
+			return c.update(context.Background(), false, fn)
+		})
+		if reencryptErr != nil {
+			compactedDb.Close()
+			return reencryptErr
+		}
+		if reencrypted > 0 {
+			c.logger.Info("re-encrypted values with the current key",
+				zap.String(directoryKey, c.db.Path()),
+				zap.Int("count", reencrypted))
+		}
+		if skipped > 0 {
+			c.logger.Warn("values which aren't encrypted with a configured key were not re-encrypted, they can't be read",
+				zap.String(directoryKey, c.db.Path()),
+				zap.Int("count", skipped))
+		}
+	}
+
 	err = bbolt.Compact(compactedDb, c.db, maxTransactionSize)
 	if err != nil {
 		return err
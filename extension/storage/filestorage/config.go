@@ -10,11 +10,16 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
 )
 
 var (
 	errInvalidOctal          = errors.New("directory_permissions value must be a valid octal representation")
 	errInvalidPermissionBits = errors.New("directory_permissions contain invalid bits for file access")
+	errNoEncryptionKey       = errors.New("encryption requires either key or key_file")
+	errKeyAndKeyFile         = errors.New("encryption key and key_file are mutually exclusive")
+	errNegativeQuota         = errors.New("quota sizes cannot be negative")
 )
 
 // Config defines configuration for file storage extension.
@@ -33,6 +38,42 @@ type Config struct {
 	directoryPermissionsParsed int64  `mapstructure:"-,omitempty"`
 
 	Recreate bool `mapstructure:"recreate,omitempty"`
+
+	// Encryption enables encryption of the stored values
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+
+	// Quota limits the size of the stored data
+	Quota *QuotaConfig `mapstructure:"quota,omitempty"`
+}
+
+// QuotaConfig defines configuration for optional limits of the size of the stored data.
+// The size is the total length of the stored keys and values, the database files are larger.
+type QuotaConfig struct {
+	// MaxClientSizeMiB is the maximum size of the data stored by a single client. Zero means no limit.
+	MaxClientSizeMiB int64 `mapstructure:"max_client_size_mib,omitempty"`
+	// MaxDirectorySizeMiB is the maximum size of the data stored by all the clients of the extension.
+	// Zero means no limit.
+	MaxDirectorySizeMiB int64 `mapstructure:"max_directory_size_mib,omitempty"`
+}
+
+// EncryptionConfig defines configuration for optional encryption at rest of the stored values.
+type EncryptionConfig struct {
+	// Key is the base64 encoded AES key, which must be 16, 24 or 32 bytes long.
+	// It can be sourced from any confmap provider, e.g. ${env:FILE_STORAGE_KEY}.
+	Key configopaque.String `mapstructure:"key,omitempty"`
+	// KeyFile is the path of a file containing the base64 encoded AES key.
+	// It is read when the extension is created, and is mutually exclusive with Key.
+	KeyFile string `mapstructure:"key_file,omitempty"`
+	// PreviousKeys are the base64 encoded keys used before the current one. Values encrypted with a previous
+	// key can still be read, and they are re-encrypted with the current key on compaction.
+	PreviousKeys []configopaque.String `mapstructure:"previous_keys,omitempty"`
+	// HashKeys specifies that the keys of the stored values are hashed with HMAC-SHA256, so that they
+	// don't reveal anything about the stored data either.
+	HashKeys bool `mapstructure:"hash_keys,omitempty"`
+	// MigratePlaintext specifies that stored values which aren't encrypted are read as they are, and encrypted
+	// with the current key when written again or on compaction. It allows enabling encryption on existing
+	// databases, and should be disabled once all the values have been encrypted.
+	MigratePlaintext bool `mapstructure:"migrate_plaintext,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -54,7 +95,7 @@ type CompactionConfig struct {
 	// ReboundTriggerThresholdMiB is used when compaction is marked as needed. When allocated data size drops
 	// below the specified value, the compactions starts and the flag marking need for compaction is cleared
 	ReboundTriggerThresholdMiB int64 `mapstructure:"rebound_trigger_threshold_mib"`
-	// MaxTransactionSize specifies the maximum number of items that might be present in single compaction iteration
+	// MaxTransactionSize specifies the maximum size, in bytes of keys and values, of a single compaction transaction
 	MaxTransactionSize int64 `mapstructure:"max_transaction_size,omitempty"`
 	// CheckInterval specifies frequency of compaction check
 	CheckInterval time.Duration `mapstructure:"check_interval,omitempty"`
@@ -104,5 +145,37 @@ func (cfg *Config) Validate() error {
 		cfg.directoryPermissionsParsed = permissions
 	}
 
+	if cfg.Encryption != nil {
+		if err := cfg.Encryption.validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Quota != nil {
+		if cfg.Quota.MaxClientSizeMiB < 0 || cfg.Quota.MaxDirectorySizeMiB < 0 {
+			return errNegativeQuota
+		}
+	}
+
+	return nil
+}
+
+func (cfg *EncryptionConfig) validate() error {
+	if cfg.Key == "" && cfg.KeyFile == "" {
+		return errNoEncryptionKey
+	}
+	if cfg.Key != "" && cfg.KeyFile != "" {
+		return errKeyAndKeyFile
+	}
+	if cfg.Key != "" {
+		if _, err := newEncryptionKey(string(cfg.Key)); err != nil {
+			return err
+		}
+	}
+	for _, previousKey := range cfg.PreviousKeys {
+		if _, err := newEncryptionKey(string(previousKey)); err != nil {
+			return fmt.Errorf("invalid previous key: %w", err)
+		}
+	}
 	return nil
 }
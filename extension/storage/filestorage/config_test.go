@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/extension"
@@ -56,6 +57,19 @@ func TestLoadConfig(t *testing.T) {
 				DirectoryPermissions: "0750",
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "encryption"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig()
+				ret.(*Config).Directory = "."
+				ret.(*Config).Encryption = &EncryptionConfig{
+					Key:          "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=",
+					PreviousKeys: []configopaque.String{"ZmVkY2JhOTg3NjU0MzIxMA=="},
+					HashKeys:     true,
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -210,6 +224,89 @@ func TestDirectoryCreateConfig(t *testing.T) {
 	}
 }
 
+func TestEncryptionConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		encryption *EncryptionConfig
+		err        error
+		errMsg     string
+	}{
+		{
+			name:       "key",
+			encryption: &EncryptionConfig{Key: testEncryptionKey},
+		},
+		{
+			name:       "key file",
+			encryption: &EncryptionConfig{KeyFile: "key.txt"},
+		},
+		{
+			name:       "no key",
+			encryption: &EncryptionConfig{HashKeys: true},
+			err:        errNoEncryptionKey,
+		},
+		{
+			name:       "key and key file",
+			encryption: &EncryptionConfig{Key: testEncryptionKey, KeyFile: "key.txt"},
+			err:        errKeyAndKeyFile,
+		},
+		{
+			name:       "invalid key size",
+			encryption: &EncryptionConfig{Key: "c2hvcnQ="},
+			err:        errInvalidKeySize,
+		},
+		{
+			name:       "key not base64",
+			encryption: &EncryptionConfig{Key: "not base64!"},
+			errMsg:     "encryption key must be base64 encoded",
+		},
+		{
+			name:       "invalid previous key",
+			encryption: &EncryptionConfig{Key: testEncryptionKey, PreviousKeys: []configopaque.String{"c2hvcnQ="}},
+			err:        errInvalidKeySize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Encryption = tt.encryption
+			err := xconfmap.Validate(cfg)
+			switch {
+			case tt.errMsg != "":
+				require.ErrorContains(t, err, tt.errMsg)
+			default:
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestQuotaConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		quota *QuotaConfig
+		err   error
+	}{
+		{
+			name:  "valid",
+			quota: &QuotaConfig{MaxClientSizeMiB: 100, MaxDirectorySizeMiB: 500},
+		},
+		{
+			name:  "negative size",
+			quota: &QuotaConfig{MaxDirectorySizeMiB: -1},
+			err:   errNegativeQuota,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Quota = tt.quota
+			require.ErrorIs(t, xconfmap.Validate(cfg), tt.err)
+		})
+	}
+}
+
 func TestCompactionDirectory(t *testing.T) {
 	f := NewFactory()
 	tests := []struct {
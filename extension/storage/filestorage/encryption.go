@@ -0,0 +1,334 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// envelopeVersion is the first byte of every encrypted value, so that the format can evolve
+	envelopeVersion = 1
+	keyIDSize       = 4
+	envelopeHeader  = 1 + keyIDSize
+
+	keyHashingInfo = "filestorage key hashing"
+)
+
+var (
+	errInvalidKeySize    = errors.New("encryption key must be 16, 24 or 32 bytes long")
+	errInvalidEnvelope   = errors.New("stored value is not encrypted or was encrypted in an unsupported format")
+	errUnknownKey        = errors.New("stored value was encrypted with an unknown key")
+	errInvalidValueOwner = errors.New("stored value doesn't belong to its key")
+)
+
+// encryptionKey is a key used to encrypt values and, optionally, to hash keys.
+type encryptionKey struct {
+	id      [keyIDSize]byte
+	aead    cipher.AEAD
+	hashKey []byte
+}
+
+func newEncryptionKey(encoded string) (*encryptionKey, error) {
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64 encoded: %w", err)
+	}
+	switch len(secret) {
+	case 16, 24, 32:
+	default:
+		return nil, errInvalidKeySize
+	}
+
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	hashKey, err := hkdf.Key(sha256.New, secret, nil, keyHashingInfo, sha256.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &encryptionKey{aead: aead, hashKey: hashKey}
+	sum := sha256.Sum256(secret)
+	copy(key.id[:], sum[:])
+	return key, nil
+}
+
+// encryption encrypts the values written to a database with AES-GCM.
+//
+// Each value is stored as a version byte, the ID of the key it was encrypted with, a random nonce,
+// and the sealed plaintext. The plaintext contains the original key followed by the value, so that
+// hashed keys can be computed again when the encryption key is rotated. The stored key is used as
+// additional data, so a value can't be moved to another key without being detected.
+//
+// When migratePlaintext is set, stored values that aren't encrypted with a configured key are values written
+// before encryption was enabled. They are read as they are, under their original key, and encrypted when they
+// are written again or when the database is compacted.
+type encryption struct {
+	current          *encryptionKey
+	previous         []*encryptionKey
+	hashKeys         bool
+	migratePlaintext bool
+}
+
+func newEncryption(cfg *EncryptionConfig) (*encryption, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	encoded := string(cfg.Key)
+	if cfg.KeyFile != "" {
+		content, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key file: %w", err)
+		}
+		encoded = string(content)
+	}
+
+	current, err := newEncryptionKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+	e := &encryption{current: current, hashKeys: cfg.HashKeys, migratePlaintext: cfg.MigratePlaintext}
+	for _, previousKey := range cfg.PreviousKeys {
+		previous, err := newEncryptionKey(string(previousKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous key: %w", err)
+		}
+		e.previous = append(e.previous, previous)
+	}
+	return e, nil
+}
+
+// storedKey returns the key under which the value of key is stored when encrypted with encKey
+func (e *encryption) storedKey(encKey *encryptionKey, key string) []byte {
+	if !e.hashKeys {
+		return []byte(key)
+	}
+	mac := hmac.New(sha256.New, encKey.hashKey)
+	_, _ = mac.Write([]byte(key))
+	return mac.Sum(nil)
+}
+
+// candidateKeys returns the keys under which the value of key may be stored, the current one first
+func (e *encryption) candidateKeys(key string) [][]byte {
+	candidates := [][]byte{e.storedKey(e.current, key)}
+	if !e.hashKeys {
+		return candidates
+	}
+	for _, previous := range e.previous {
+		candidates = append(candidates, e.storedKey(previous, key))
+	}
+	if e.migratePlaintext {
+		// values written before encryption was enabled are stored under their original key
+		candidates = append(candidates, []byte(key))
+	}
+	return candidates
+}
+
+// sealed returns true if the stored value has the format of an encrypted value, i.e. it starts with the
+// envelope version and is long enough to hold the key ID, the nonce, the authentication tag and the key length
+func (e *encryption) sealed(stored []byte) bool {
+	aead := e.current.aead
+	return len(stored) > envelopeHeader+aead.NonceSize()+aead.Overhead() && stored[0] == envelopeVersion
+}
+
+// plaintext returns true if the stored value is a value written before encryption was enabled. Values
+// encrypted with a key that is no longer configured aren't, they can't be read.
+func (e *encryption) plaintext(stored []byte) bool {
+	return e.migratePlaintext && !e.sealed(stored)
+}
+
+func (e *encryption) seal(storedKey []byte, key string, value []byte) ([]byte, error) {
+	plaintext := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(key)+len(value)), uint64(len(key)))
+	plaintext = append(plaintext, key...)
+	plaintext = append(plaintext, value...)
+
+	aead := e.current.aead
+	sealed := make([]byte, envelopeHeader+aead.NonceSize(), envelopeHeader+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	sealed[0] = envelopeVersion
+	copy(sealed[1:envelopeHeader], e.current.id[:])
+	nonce := sealed[envelopeHeader:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(sealed, nonce, plaintext, storedKey), nil
+}
+
+// open decrypts a stored value and returns the original key and value, as well as the key it was encrypted with
+func (e *encryption) open(storedKey, sealed []byte) (string, []byte, *encryptionKey, error) {
+	if len(sealed) < envelopeHeader || sealed[0] != envelopeVersion {
+		return "", nil, nil, errInvalidEnvelope
+	}
+	encKey := e.keyByID(sealed[1:envelopeHeader])
+	if encKey == nil {
+		return "", nil, nil, errUnknownKey
+	}
+	nonceSize := encKey.aead.NonceSize()
+	if len(sealed) < envelopeHeader+nonceSize {
+		return "", nil, nil, errInvalidEnvelope
+	}
+	plaintext, err := encKey.aead.Open(nil, sealed[envelopeHeader:envelopeHeader+nonceSize], sealed[envelopeHeader+nonceSize:], storedKey)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to decrypt stored value: %w", err)
+	}
+
+	keyLen, n := binary.Uvarint(plaintext)
+	if n <= 0 || uint64(len(plaintext)-n) < keyLen {
+		return "", nil, nil, errInvalidEnvelope
+	}
+	key := string(plaintext[n : n+int(keyLen)])
+	if !bytes.Equal(e.storedKey(encKey, key), storedKey) {
+		return "", nil, nil, errInvalidValueOwner
+	}
+	return key, plaintext[n+int(keyLen):], encKey, nil
+}
+
+func (e *encryption) keyByID(id []byte) *encryptionKey {
+	if bytes.Equal(id, e.current.id[:]) {
+		return e.current
+	}
+	for _, previous := range e.previous {
+		if bytes.Equal(id, previous.id[:]) {
+			return previous
+		}
+	}
+	return nil
+}
+
+// get returns the decrypted value of key, or nil if there is none
+func (e *encryption) get(bucket kvBucket, key string) ([]byte, error) {
+	for _, storedKey := range e.candidateKeys(key) {
+		sealed := bucket.Get(storedKey)
+		if sealed == nil {
+			continue
+		}
+		if e.plaintext(sealed) {
+			return bytes.Clone(sealed), nil
+		}
+		// the output of Open is a new slice, it remains valid after the transaction
+		_, value, _, err := e.open(storedKey, sealed)
+		return value, err
+	}
+	return nil, nil
+}
+
+// put encrypts the value with the current key, removing the value stored under a previous key, if any
+func (e *encryption) put(bucket kvBucket, key string, value []byte) error {
+	candidates := e.candidateKeys(key)
+	sealed, err := e.seal(candidates[0], key, value)
+	if err != nil {
+		return err
+	}
+	if err := bucket.Put(candidates[0], sealed); err != nil {
+		return err
+	}
+	for _, storedKey := range candidates[1:] {
+		if err := bucket.Delete(storedKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encryption) delete(bucket kvBucket, key string) error {
+	for _, storedKey := range e.candidateKeys(key) {
+		if err := bucket.Delete(storedKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reencrypt encrypts all the values stored with a previous key, or not encrypted yet when migrating plaintext
+// values, with the current key. It uses transactions in which at most maxTransactionSize bytes of keys and values
+// are re-encrypted, like the compaction transactions. The transactions are run by update, which passes the bucket
+// of the values and the bucket through which they are written. It returns the number of values re-encrypted, and
+// the number of values skipped as they are encrypted with an unknown key, or not encrypted without migratePlaintext.
+func (e *encryption) reencrypt(maxTransactionSize int64, update func(func(*bbolt.Bucket, kvBucket) error) error) (int, int, error) {
+	if len(e.previous) == 0 && !e.migratePlaintext {
+		return 0, 0, nil
+	}
+	if maxTransactionSize <= 0 {
+		maxTransactionSize = defaultMaxTransactionSize
+	}
+
+	total := 0
+	for {
+		var (
+			rotated, skipped int
+			more             bool
+		)
+		err := update(func(bucket *bbolt.Bucket, writer kvBucket) error {
+			// collect the outdated keys first, as the bucket can't be modified while iterating over it
+			var (
+				outdated [][]byte
+				size     int64
+			)
+			cursor := bucket.Cursor()
+			for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+				if len(v) >= envelopeHeader && v[0] == envelopeVersion && bytes.Equal(v[1:envelopeHeader], e.current.id[:]) {
+					continue
+				}
+				if !e.plaintext(v) && (!e.sealed(v) || e.keyByID(v[1:envelopeHeader]) == nil) {
+					skipped++
+					continue
+				}
+				entrySize := int64(len(k) + len(v))
+				if len(outdated) > 0 && size+entrySize > maxTransactionSize {
+					more = true
+					break
+				}
+				outdated = append(outdated, bytes.Clone(k))
+				size += entrySize
+			}
+
+			for _, storedKey := range outdated {
+				stored := bucket.Get(storedKey)
+				key, value := string(storedKey), bytes.Clone(stored)
+				if !e.plaintext(stored) {
+					var err error
+					if key, value, _, err = e.open(storedKey, stored); err != nil {
+						return fmt.Errorf("failed to re-encrypt stored value: %w", err)
+					}
+				}
+				if err := writer.Delete(storedKey); err != nil {
+					return err
+				}
+				if err := e.put(writer, key, value); err != nil {
+					return err
+				}
+			}
+			rotated = len(outdated)
+			return nil
+		})
+		if err != nil {
+			return total, 0, err
+		}
+		total += rotated
+		if !more {
+			// the last transaction went over all the values, including the ones skipped by the previous ones
+			return total, skipped, nil
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.uber.org/zap"
+)
+
+const (
+	testEncryptionKey      = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	testOtherEncryptionKey = "ZmVkY2JhOTg3NjU0MzIxMA=="
+)
+
+func newEncryptedClient(t *testing.T, dbFile string, cfg *EncryptionConfig) *fileStorageClient {
+	enc, err := newEncryption(cfg)
+	require.NoError(t, err)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, enc, nil)
+	require.NoError(t, err)
+	return client
+}
+
+// rawEntries returns the keys and values as stored in the database
+func rawEntries(t *testing.T, client *fileStorageClient) map[string][]byte {
+	entries := map[string][]byte{}
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+			entries[string(k)] = bytes.Clone(v)
+			return nil
+		})
+	}))
+	return entries
+}
+
+func TestEncryptedClientOperations(t *testing.T) {
+	for _, hashKeys := range []bool{false, true} {
+		t.Run(fmt.Sprintf("hash_keys=%v", hashKeys), func(t *testing.T) {
+			client := newEncryptedClient(t, filepath.Join(t.TempDir(), "my_db"), &EncryptionConfig{
+				Key:      testEncryptionKey,
+				HashKeys: hashKeys,
+			})
+			t.Cleanup(func() {
+				require.NoError(t, client.Close(t.Context()))
+			})
+
+			ctx := t.Context()
+			testKey := "testKey"
+			testValue := []byte("my secret value")
+
+			value, err := client.Get(ctx, testKey)
+			require.NoError(t, err)
+			require.Nil(t, value)
+
+			require.NoError(t, client.Set(ctx, testKey, testValue))
+			value, err = client.Get(ctx, testKey)
+			require.NoError(t, err)
+			require.Equal(t, testValue, value)
+
+			entries := rawEntries(t, client)
+			require.Len(t, entries, 1)
+			for k, v := range entries {
+				assert.Equal(t, !hashKeys, k == testKey)
+				assert.NotContains(t, string(v), string(testValue))
+			}
+
+			require.NoError(t, client.Delete(ctx, testKey))
+			value, err = client.Get(ctx, testKey)
+			require.NoError(t, err)
+			require.Nil(t, value)
+			require.Empty(t, rawEntries(t, client))
+		})
+	}
+}
+
+func TestEncryptedClientUnknownKey(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	ctx := t.Context()
+
+	client := newEncryptedClient(t, dbFile, &EncryptionConfig{Key: testEncryptionKey})
+	require.NoError(t, client.Set(ctx, "testKey", []byte("testValue")))
+	require.NoError(t, client.Close(ctx))
+
+	client = newEncryptedClient(t, dbFile, &EncryptionConfig{Key: testOtherEncryptionKey})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+	})
+	_, err := client.Get(ctx, "testKey")
+	require.ErrorIs(t, err, errUnknownKey)
+}
+
+func TestEncryptedClientValueOwner(t *testing.T) {
+	client := newEncryptedClient(t, filepath.Join(t.TempDir(), "my_db"), &EncryptionConfig{Key: testEncryptionKey})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+	})
+	ctx := t.Context()
+	require.NoError(t, client.Set(ctx, "a", []byte("value of a")))
+
+	// copy the encrypted value of a to b
+	require.NoError(t, client.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		return bucket.Put([]byte("b"), bytes.Clone(bucket.Get([]byte("a"))))
+	}))
+	_, err := client.Get(ctx, "b")
+	require.Error(t, err)
+}
+
+func TestEncryptionKeyRotation(t *testing.T) {
+	for _, hashKeys := range []bool{false, true} {
+		t.Run(fmt.Sprintf("hash_keys=%v", hashKeys), func(t *testing.T) {
+			tempDir := t.TempDir()
+			dbFile := filepath.Join(tempDir, "my_db")
+			ctx := t.Context()
+
+			client := newEncryptedClient(t, dbFile, &EncryptionConfig{Key: testOtherEncryptionKey, HashKeys: hashKeys})
+			for i := range 10 {
+				require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), fmt.Appendf(nil, "value%d", i)))
+			}
+			require.NoError(t, client.Close(ctx))
+
+			rotated := &EncryptionConfig{
+				Key:          testEncryptionKey,
+				PreviousKeys: []configopaque.String{testOtherEncryptionKey},
+				HashKeys:     hashKeys,
+			}
+			client = newEncryptedClient(t, dbFile, rotated)
+
+			// values encrypted with the previous key can still be read and overwritten
+			value, err := client.Get(ctx, "key0")
+			require.NoError(t, err)
+			require.Equal(t, []byte("value0"), value)
+			require.NoError(t, client.Set(ctx, "key1", []byte("updated")))
+			require.Len(t, rawEntries(t, client), 10)
+
+			// compaction re-encrypts the remaining values with the current key
+			require.NoError(t, client.Compact(tempDir, time.Second, 3))
+			for _, v := range rawEntries(t, client) {
+				require.Equal(t, client.encryption.current.id[:], v[1:envelopeHeader])
+			}
+			require.NoError(t, client.Close(ctx))
+
+			// the previous key isn't needed anymore
+			client = newEncryptedClient(t, dbFile, &EncryptionConfig{Key: testEncryptionKey, HashKeys: hashKeys})
+			t.Cleanup(func() {
+				require.NoError(t, client.Close(t.Context()))
+			})
+			require.Len(t, rawEntries(t, client), 10)
+			for i := range 10 {
+				expected := fmt.Appendf(nil, "value%d", i)
+				if i == 1 {
+					expected = []byte("updated")
+				}
+				value, err := client.Get(ctx, fmt.Sprintf("key%d", i))
+				require.NoError(t, err)
+				require.Equal(t, expected, value)
+			}
+		})
+	}
+}
+
+func TestEncryptionPlaintextMigration(t *testing.T) {
+	for _, hashKeys := range []bool{false, true} {
+		t.Run(fmt.Sprintf("hash_keys=%v", hashKeys), func(t *testing.T) {
+			tempDir := t.TempDir()
+			dbFile := filepath.Join(tempDir, "my_db")
+			ctx := t.Context()
+
+			expected := map[string][]byte{
+				// values which may look like an encrypted value are read as they are too
+				"index": binary.LittleEndian.AppendUint64(nil, 1),
+				"empty": {},
+			}
+			for i := range 10 {
+				expected[fmt.Sprintf("key%d", i)] = fmt.Appendf(nil, "value%d", i)
+			}
+
+			client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, nil)
+			require.NoError(t, err)
+			for key, value := range expected {
+				require.NoError(t, client.Set(ctx, key, value))
+			}
+			require.NoError(t, client.Close(ctx))
+
+			// plaintext values can't be read without migrating them
+			client = newEncryptedClient(t, dbFile, &EncryptionConfig{Key: testEncryptionKey})
+			_, err = client.Get(ctx, "key0")
+			require.ErrorIs(t, err, errInvalidEnvelope)
+			_, err = client.Get(ctx, "index")
+			require.ErrorIs(t, err, errUnknownKey)
+			require.NoError(t, client.Close(ctx))
+
+			migrating := &EncryptionConfig{Key: testEncryptionKey, HashKeys: hashKeys, MigratePlaintext: true}
+			client = newEncryptedClient(t, dbFile, migrating)
+			for key, value := range expected {
+				actual, err := client.Get(ctx, key)
+				require.NoError(t, err)
+				require.Equal(t, value, actual)
+			}
+			expected["key1"] = []byte("updated")
+			require.NoError(t, client.Set(ctx, "key1", expected["key1"]))
+			require.Len(t, rawEntries(t, client), len(expected))
+
+			// compaction encrypts the remaining values with the current key
+			require.NoError(t, client.Compact(tempDir, time.Second, 3))
+			entries := rawEntries(t, client)
+			require.Len(t, entries, len(expected))
+			for k, v := range entries {
+				_, found := expected[k]
+				require.Equal(t, !hashKeys, found)
+				require.Equal(t, client.encryption.current.id[:], v[1:envelopeHeader])
+			}
+			require.NoError(t, client.Close(ctx))
+
+			// the migration isn't needed anymore
+			client = newEncryptedClient(t, dbFile, &EncryptionConfig{Key: testEncryptionKey, HashKeys: hashKeys})
+			t.Cleanup(func() {
+				require.NoError(t, client.Close(t.Context()))
+			})
+			for key, value := range expected {
+				actual, err := client.Get(ctx, key)
+				require.NoError(t, err)
+				require.Equal(t, value, actual)
+			}
+		})
+	}
+}
+
+func TestEncryptionPlaintextMigrationUnknownKey(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := t.Context()
+
+	client := newEncryptedClient(t, dbFile, &EncryptionConfig{Key: testOtherEncryptionKey})
+	require.NoError(t, client.Set(ctx, "key0", []byte("value0")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key1", []byte("value1")))
+	require.NoError(t, client.Close(ctx))
+
+	// values encrypted with a key that is no longer configured aren't read as plaintext
+	client = newEncryptedClient(t, dbFile, &EncryptionConfig{Key: testEncryptionKey, MigratePlaintext: true})
+	_, err = client.Get(ctx, "key0")
+	require.ErrorIs(t, err, errUnknownKey)
+	stored := rawEntries(t, client)["key0"]
+
+	// and they are skipped by compaction, while the plaintext values are encrypted
+	require.NoError(t, client.Compact(tempDir, time.Second, 0))
+	entries := rawEntries(t, client)
+	require.Equal(t, stored, entries["key0"])
+	require.Equal(t, client.encryption.current.id[:], entries["key1"][1:envelopeHeader])
+	require.NoError(t, client.Close(ctx))
+
+	// they can still be read once their key is configured again
+	client = newEncryptedClient(t, dbFile, &EncryptionConfig{
+		Key:          testEncryptionKey,
+		PreviousKeys: []configopaque.String{testOtherEncryptionKey},
+	})
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+	})
+	requireValue(t, client, "key0", []byte("value0"))
+	requireValue(t, client, "key1", []byte("value1"))
+}
+
+func TestEncryptionKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(testEncryptionKey+"\n"), 0o600))
+
+	fromFile, err := newEncryption(&EncryptionConfig{KeyFile: keyFile})
+	require.NoError(t, err)
+	fromKey, err := newEncryption(&EncryptionConfig{Key: testEncryptionKey})
+	require.NoError(t, err)
+	require.Equal(t, fromKey.current.id, fromFile.current.id)
+
+	_, err = newEncryption(&EncryptionConfig{KeyFile: filepath.Join(t.TempDir(), "missing")})
+	require.ErrorContains(t, err, "failed to read encryption key file")
+}
@@ -15,17 +15,23 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg              *Config
+	logger           *zap.Logger
+	encryption       *encryption
+	telemetryBuilder *metadata.TelemetryBuilder
+	// directoryUsage is nil when the size of the stored data is not limited
+	directoryUsage *directoryUsage
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
-func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newLocalFileStorage(set component.TelemetrySettings, config *Config) (extension.Extension, error) {
 	if config.CreateDirectory {
 		var dirs []string
 		if config.Compaction.OnStart || config.Compaction.OnRebound {
@@ -39,10 +45,24 @@ func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extensio
 			}
 		}
 	}
-	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
-	}, nil
+	enc, err := newEncryption(config.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	lfs := &localFileStorage{
+		cfg:              config,
+		logger:           set.Logger,
+		encryption:       enc,
+		telemetryBuilder: telemetryBuilder,
+	}
+	if config.Quota != nil {
+		lfs.directoryUsage = &directoryUsage{maxBytes: config.Quota.MaxDirectorySizeMiB * oneMiB}
+	}
+	return lfs, nil
 }
 
 // Start runs cleanup if configured
@@ -54,9 +74,10 @@ func (lfs *localFileStorage) Start(context.Context, component.Host) error {
 }
 
 // Shutdown will close any open databases
-func (*localFileStorage) Shutdown(context.Context) error {
+func (lfs *localFileStorage) Shutdown(context.Context) error {
 	// TODO clean up data files that did not have a client
 	// and are older than a threshold (possibly configurable)
+	lfs.telemetryBuilder.Shutdown()
 	return nil
 }
 
@@ -76,7 +97,8 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 			return nil, fmt.Errorf("error renaming the database. Please remove %s manually: %w", absoluteName, err)
 		}
 	}
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
+	quota := newClientQuota(lfs.cfg.Quota, lfs.directoryUsage, lfs.telemetryBuilder, rawName)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync, lfs.encryption, quota)
 	if err != nil {
 		return nil, err
 	}
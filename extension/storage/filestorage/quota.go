@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+)
+
+const clientKey = "client"
+
+// ErrQuotaExceeded is returned when a write would exceed the configured quota
+var ErrQuotaExceeded = errors.New("file storage quota exceeded")
+
+// kvBucket is the subset of the bucket operations used to store values
+type kvBucket interface {
+	Get(key []byte) []byte
+	Put(key, value []byte) error
+	Delete(key []byte) error
+}
+
+// directoryUsage tracks the size of the data stored by all the clients of an extension
+type directoryUsage struct {
+	maxBytes int64
+
+	mu   sync.Mutex
+	used int64
+}
+
+// reserve adds delta to the used bytes, unless it would exceed the maximum. Releasing space always succeeds.
+func (d *directoryUsage) reserve(delta int64) bool {
+	if d == nil {
+		return true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if delta > 0 && d.maxBytes > 0 && d.used+delta > d.maxBytes {
+		return false
+	}
+	d.used += delta
+	return true
+}
+
+// add adds delta to the used bytes regardless of the maximum
+func (d *directoryUsage) add(delta int64) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.used += delta
+}
+
+// clientQuota enforces the quota of a single client. The space is reserved within write transactions,
+// which bbolt runs one at a time, but it is given back once they are finished, so used is protected by mu.
+type clientQuota struct {
+	maxBytes  int64
+	directory *directoryUsage
+
+	mu   sync.Mutex
+	used int64
+
+	telemetryBuilder *metadata.TelemetryBuilder
+	attrs            metric.MeasurementOption
+}
+
+func newClientQuota(cfg *QuotaConfig, directory *directoryUsage, telemetryBuilder *metadata.TelemetryBuilder, name string) *clientQuota {
+	if cfg == nil {
+		return nil
+	}
+	return &clientQuota{
+		maxBytes:         cfg.MaxClientSizeMiB * oneMiB,
+		directory:        directory,
+		telemetryBuilder: telemetryBuilder,
+		attrs:            metric.WithAttributeSet(attribute.NewSet(attribute.String(clientKey, name))),
+	}
+}
+
+// init accounts for the data already stored in the database
+func (q *clientQuota) init(db *bbolt.DB) error {
+	var used int64
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+			used += entrySize(k, v)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	q.add(used)
+	q.telemetryBuilder.ExtensionFileStorageClientBytesUsed.Add(context.Background(), used, q.attrs)
+	return nil
+}
+
+// release gives back the space used by the client when it is closed
+func (q *clientQuota) release() {
+	q.mu.Lock()
+	used := q.used
+	q.used = 0
+	q.mu.Unlock()
+
+	q.directory.add(-used)
+	q.telemetryBuilder.ExtensionFileStorageClientBytesUsed.Add(context.Background(), -used, q.attrs)
+}
+
+// reserve adds delta to the used bytes, unless it would exceed the client or directory quota
+func (q *clientQuota) reserve(delta int64) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if delta > 0 && q.maxBytes > 0 && q.used+delta > q.maxBytes {
+		return false
+	}
+	if !q.directory.reserve(delta) {
+		return false
+	}
+	q.used += delta
+	return true
+}
+
+// add adds delta to the used bytes regardless of the client and directory quota
+func (q *clientQuota) add(delta int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.used += delta
+	q.directory.add(delta)
+}
+
+// usedBytes returns the size of the data stored by the client
+func (q *clientQuota) usedBytes() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.used
+}
+
+func (q *clientQuota) bucket(bucket *bbolt.Bucket) *quotaBucket {
+	return &quotaBucket{Bucket: bucket, quota: q}
+}
+
+// quotaBucket tracks the size changes and the number of values of a bucket within a transaction
+type quotaBucket struct {
+	*bbolt.Bucket
+	quota *clientQuota
+
+	delta int64
+	// items is the change of the number of stored values
+	items    int
+	reserved bool
+}
+
+func entrySize(key, value []byte) int64 {
+	return int64(len(key) + len(value))
+}
+
+func (b *quotaBucket) Put(key, value []byte) error {
+	old := b.Bucket.Get(key)
+	if err := b.Bucket.Put(key, value); err != nil {
+		return err
+	}
+	b.delta += entrySize(key, value)
+	if old != nil {
+		b.delta -= entrySize(key, old)
+	} else {
+		b.items++
+	}
+	return nil
+}
+
+func (b *quotaBucket) Delete(key []byte) error {
+	old := b.Bucket.Get(key)
+	if old == nil {
+		return nil
+	}
+	size := entrySize(key, old)
+	if err := b.Bucket.Delete(key); err != nil {
+		return err
+	}
+	b.delta -= size
+	b.items--
+	return nil
+}
+
+// enforce reserves the space needed by the transaction. The transactions which don't store more
+// values than they delete, such as the dequeuing of a persistent queue item along with the update
+// of the queue metadata, are always allowed, so that a full queue can still be drained.
+func (b *quotaBucket) enforce() error {
+	if b.items <= 0 {
+		b.account()
+		return nil
+	}
+	if !b.quota.reserve(b.delta) {
+		return ErrQuotaExceeded
+	}
+	b.reserved = true
+	return nil
+}
+
+// account adds the size changes of the transaction to the used bytes without enforcing the quota,
+// for the transactions which don't store new data, such as the re-encryption of the stored values
+func (b *quotaBucket) account() {
+	b.quota.add(b.delta)
+	b.reserved = true
+}
+
+// done must be called once the transaction is finished, to release the space reserved by
+// a failed transaction or to report the changes made by a successful one
+func (b *quotaBucket) done(ctx context.Context, err error) {
+	if !b.reserved {
+		return
+	}
+	if err != nil {
+		b.quota.add(-b.delta)
+		return
+	}
+	b.quota.telemetryBuilder.ExtensionFileStorageClientBytesUsed.Add(ctx, b.delta, b.quota.attrs)
+}
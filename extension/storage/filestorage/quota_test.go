@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadatatest"
+)
+
+// newQuotaClient creates a client whose data, i.e. keys and values, can't exceed maxBytes
+func newQuotaClient(t *testing.T, dbFile string, maxBytes int64, directory *directoryUsage, enc *encryption) *fileStorageClient {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	quota := newClientQuota(&QuotaConfig{}, directory, telemetryBuilder, filepath.Base(dbFile))
+	quota.maxBytes = maxBytes
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, enc, quota)
+	require.NoError(t, err)
+	return client
+}
+
+func requireValue(t *testing.T, client *fileStorageClient, key string, expected []byte) {
+	value, err := client.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, expected, value, key)
+}
+
+func TestQuotaReject(t *testing.T) {
+	client := newQuotaClient(t, filepath.Join(t.TempDir(), "my_db"), 30, nil, nil)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+	})
+	ctx := t.Context()
+
+	// each entry is 10 bytes long
+	require.NoError(t, client.Set(ctx, "key0", []byte("value0")))
+	require.NoError(t, client.Set(ctx, "key1", []byte("value1")))
+	require.NoError(t, client.Set(ctx, "key2", []byte("value2")))
+	require.ErrorIs(t, client.Set(ctx, "key3", []byte("value3")), ErrQuotaExceeded)
+	requireValue(t, client, "key3", nil)
+	require.Equal(t, int64(30), client.quota.usedBytes())
+
+	// overwriting doesn't store more keys, it is allowed even beyond the quota
+	require.NoError(t, client.Set(ctx, "key2", []byte("longer value")))
+	requireValue(t, client, "key2", []byte("longer value"))
+	require.Equal(t, int64(36), client.quota.usedBytes())
+
+	require.NoError(t, client.Delete(ctx, "key0"))
+	require.ErrorIs(t, client.Set(ctx, "key3", []byte("value3")), ErrQuotaExceeded)
+	require.NoError(t, client.Delete(ctx, "key1"))
+	require.NoError(t, client.Set(ctx, "key3", []byte("value3")))
+	require.Equal(t, int64(26), client.quota.usedBytes())
+}
+
+func TestQuotaQueueDrain(t *testing.T) {
+	client := newQuotaClient(t, filepath.Join(t.TempDir(), "my_db"), 26, nil, nil)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+	})
+	ctx := t.Context()
+
+	// a persistent queue stores its items under their index, along with its read and write indexes
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("ri", []byte("8")), storage.SetOperation("wi", []byte("8"))))
+	for i := 8; i < 11; i++ {
+		require.NoError(t, client.Batch(ctx,
+			storage.SetOperation(strconv.Itoa(i), []byte("value")),
+			storage.SetOperation("wi", []byte(strconv.Itoa(i+1)))))
+	}
+	require.Equal(t, int64(26), client.quota.usedBytes())
+	err := client.Batch(ctx,
+		storage.SetOperation("11", []byte("value")),
+		storage.SetOperation("wi", []byte("12")))
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	requireValue(t, client, "wi", []byte("11"))
+
+	// the queue still dequeues its items, even though its metadata grows beyond the quota
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("ri", []byte("9")), storage.GetOperation("8")))
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("ri", []byte("10")), storage.GetOperation("9")))
+	require.Equal(t, int64(27), client.quota.usedBytes())
+	require.NoError(t, client.Batch(ctx, storage.DeleteOperation("8"), storage.DeleteOperation("9")))
+	requireValue(t, client, "ri", []byte("10"))
+	require.Equal(t, int64(15), client.quota.usedBytes())
+}
+
+func TestQuotaExistingData(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	ctx := t.Context()
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "ri", []byte("0")))
+	for i := range 5 {
+		require.NoError(t, client.Set(ctx, strconv.Itoa(i), fmt.Appendf(nil, "value%d", i)))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the existing data is accounted for
+	client = newQuotaClient(t, dbFile, 38, nil, nil)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+	})
+	require.Equal(t, int64(38), client.quota.usedBytes())
+	require.ErrorIs(t, client.Set(ctx, "5", []byte("value5")), ErrQuotaExceeded)
+	require.NoError(t, client.Delete(ctx, "0"))
+	require.NoError(t, client.Set(ctx, "5", []byte("value5")))
+	require.Equal(t, int64(38), client.quota.usedBytes())
+}
+
+func TestQuotaConcurrentWrites(t *testing.T) {
+	tempDir := t.TempDir()
+	directory := &directoryUsage{}
+	ctx := t.Context()
+
+	var wg sync.WaitGroup
+	clients := make([]*fileStorageClient, 4)
+	for i := range clients {
+		clients[i] = newQuotaClient(t, filepath.Join(tempDir, fmt.Sprintf("db%d", i)), 0, directory, nil)
+		wg.Add(1)
+		go func(client *fileStorageClient) {
+			defer wg.Done()
+			for j := range 50 {
+				assert.NoError(t, client.Set(ctx, strconv.Itoa(j), []byte("value")))
+				if j%2 == 0 {
+					assert.NoError(t, client.Delete(ctx, strconv.Itoa(j)))
+				}
+			}
+		}(clients[i])
+	}
+	wg.Wait()
+
+	var total int64
+	for _, client := range clients {
+		var used int64
+		for k, v := range rawEntries(t, client) {
+			used += entrySize([]byte(k), v)
+		}
+		require.Equal(t, used, client.quota.usedBytes())
+		total += used
+	}
+	require.Equal(t, total, directory.used)
+
+	for _, client := range clients {
+		require.NoError(t, client.Close(ctx))
+	}
+	require.Zero(t, directory.used)
+}
+
+func TestQuotaReencryption(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := t.Context()
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, nil)
+	require.NoError(t, err)
+	for i := range 5 {
+		require.NoError(t, client.Set(ctx, strconv.Itoa(i), fmt.Appendf(nil, "value%d", i)))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	enc, err := newEncryption(&EncryptionConfig{Key: testEncryptionKey, HashKeys: true, MigratePlaintext: true})
+	require.NoError(t, err)
+	client = newQuotaClient(t, dbFile, oneMiB, nil, enc)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+	})
+
+	// the values encrypted on compaction are accounted for
+	require.NoError(t, client.Compact(tempDir, time.Second, 0))
+	var used int64
+	for k, v := range rawEntries(t, client) {
+		used += entrySize([]byte(k), v)
+	}
+	require.Greater(t, used, int64(5*len("0value0")))
+	require.Equal(t, used, client.quota.usedBytes())
+
+	// and they still count towards the quota
+	client.quota.maxBytes = used
+	require.ErrorIs(t, client.Set(ctx, "5", []byte("value5")), ErrQuotaExceeded)
+	require.NoError(t, client.Set(ctx, "0", []byte("value5")))
+	requireValue(t, client, "0", []byte("value5"))
+}
+
+func TestQuotaDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+	ctx := t.Context()
+	directory := &directoryUsage{maxBytes: 30}
+
+	client1 := newQuotaClient(t, filepath.Join(tempDir, "db1"), 0, directory, nil)
+	client2 := newQuotaClient(t, filepath.Join(tempDir, "db2"), 0, directory, nil)
+
+	require.NoError(t, client1.Set(ctx, "key0", []byte("value0")))
+	require.NoError(t, client2.Set(ctx, "key0", []byte("value0")))
+	require.NoError(t, client1.Set(ctx, "key1", []byte("value1")))
+	require.ErrorIs(t, client2.Set(ctx, "key1", []byte("value1")), ErrQuotaExceeded)
+
+	// closing a client frees its share of the directory quota
+	require.NoError(t, client1.Close(ctx))
+	require.Equal(t, int64(10), directory.used)
+	require.NoError(t, client2.Set(ctx, "key1", []byte("value1")))
+	require.NoError(t, client2.Close(ctx))
+	require.Equal(t, int64(0), directory.used)
+}
+
+func TestQuotaTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Quota = &QuotaConfig{MaxClientSizeMiB: 1}
+	ext, err := f.Create(t.Context(), metadatatest.NewSettings(tel), cfg)
+	require.NoError(t, err)
+	se, ok := ext.(storage.Extension)
+	require.True(t, ok)
+
+	ctx := t.Context()
+	client, err := se.GetClient(ctx, component.KindExporter, component.MustNewID("nop"), "")
+	require.NoError(t, err)
+
+	value := make([]byte, oneMiB/4)
+	for i := range 3 {
+		require.NoError(t, client.Set(ctx, strconv.Itoa(i), value))
+	}
+	require.ErrorIs(t, client.Set(ctx, "3", value), ErrQuotaExceeded)
+
+	attrs := attribute.NewSet(attribute.String(clientKey, "exporter_nop_"))
+	entry := int64(len("0") + len(value))
+	metadatatest.AssertEqualExtensionFileStorageClientBytesUsed(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 3 * entry, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+
+	require.NoError(t, client.Close(ctx))
+	metadatatest.AssertEqualExtensionFileStorageClientBytesUsed(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 0, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	require.NoError(t, ext.Shutdown(ctx))
+}
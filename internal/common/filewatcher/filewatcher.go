@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filewatcher reloads files when they change on disk, including when
+// they are replaced, like rotated credentials or updated databases.
+package filewatcher // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// Watcher calls a reload function every time the watched file changes.
+type Watcher struct {
+	path    string
+	reload  func() error
+	logger  *zap.Logger
+	watcher *fsnotify.Watcher
+	wg      sync.WaitGroup
+}
+
+// New starts watching the file at path. The reload function is called from
+// a background goroutine, which is stopped by Close. Reload errors are logged,
+// and the caller is expected to keep using the previously loaded content.
+//
+// The directory of the file is watched as well, so that a file deleted and
+// created again, e.g. by an atomic replace, is watched again once created.
+func New(path string, logger *zap.Logger, reload func() error) (*Watcher, error) {
+	path = filepath.Clean(path)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := errors.Join(watcher.Add(path), watcher.Add(filepath.Dir(path))); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		path:    path,
+		reload:  reload,
+		logger:  logger,
+		watcher: watcher,
+	}
+	w.wg.Add(1)
+	go w.watch()
+	return w, nil
+}
+
+func (w *Watcher) watch() {
+	defer w.wg.Done()
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			// the events of the other files of the directory are ignored
+			if event.Name != w.path {
+				continue
+			}
+			// Files replaced with a rename, and the symlinks of mounted volumes
+			// swapped by Kubernetes, remove the watched file: the new file at
+			// the same path is watched instead. When there is no file at the
+			// path yet, it is watched once created in the directory.
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) || event.Has(fsnotify.Chmod) || event.Has(fsnotify.Create) {
+				_ = w.watcher.Remove(w.path)
+				if err := w.watcher.Add(w.path); err != nil {
+					if !errors.Is(err, fs.ErrNotExist) {
+						w.logger.Error("Failed to watch file", zap.String("path", w.path), zap.Error(err))
+					}
+					continue
+				}
+			}
+			if err := w.reload(); err != nil {
+				w.logger.Warn("Failed to reload file, keeping the previously loaded content", zap.String("path", w.path), zap.Error(err))
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Warn("Error watching file", zap.String("path", w.path), zap.Error(err))
+		}
+	}
+}
+
+// Close stops watching the file and waits for a running reload to finish.
+func (w *Watcher) Close() error {
+	if w == nil {
+		return nil
+	}
+	err := w.watcher.Close()
+	w.wg.Wait()
+	return err
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filewatcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// content keeps the content of a file, as loaded by its reload function.
+type content struct {
+	path string
+	mu   sync.Mutex
+	data string
+}
+
+func (c *content) reload() error {
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data = string(b)
+	return nil
+}
+
+func (c *content) get() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data
+}
+
+func watch(t *testing.T, path string) *content {
+	c := &content{path: path}
+	require.NoError(t, c.reload())
+	w, err := New(path, zap.NewNop(), c.reload)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, w.Close())
+	})
+	return c
+}
+
+func assertContent(t *testing.T, c *content, expected string) {
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		assert.Equal(tt, expected, c.get())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWatcherWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+	c := watch(t, path)
+	assert.Equal(t, "first", c.get())
+
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	assertContent(t, c, "second")
+	require.NoError(t, os.WriteFile(path, []byte("third"), 0o600))
+	assertContent(t, c, "third")
+}
+
+func TestWatcherRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+	c := watch(t, path)
+
+	// the replaced file is watched, not only the one initially at the path
+	for _, data := range []string{"second", "third"} {
+		tmp := filepath.Join(dir, "file.tmp")
+		require.NoError(t, os.WriteFile(tmp, []byte(data), 0o600))
+		require.NoError(t, os.Rename(tmp, path))
+		assertContent(t, c, data)
+	}
+}
+
+func TestWatcherDeleteAndCreate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+	c := watch(t, path)
+
+	// the file is watched again once created, however long it was missing
+	for _, data := range []string{"second", "third"} {
+		require.NoError(t, os.Remove(path))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "other"), []byte(data), 0o600))
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+		assertContent(t, c, data)
+	}
+}
+
+func TestWatcherSymlinkSwap(t *testing.T) {
+	// Kubernetes mounts secrets and config maps as symlinks to a data
+	// directory, and updates them by swapping the data directory symlink
+	dir := t.TempDir()
+	writeVersion := func(version, data string) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, version), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, version, "file"), []byte(data), 0o600))
+	}
+	swap := func(version string) {
+		tmp := filepath.Join(dir, "..data_tmp")
+		require.NoError(t, os.Symlink(version, tmp))
+		require.NoError(t, os.Rename(tmp, filepath.Join(dir, "..data")))
+	}
+
+	writeVersion("..v1", "first")
+	swap("..v1")
+	path := filepath.Join(dir, "file")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "file"), path))
+	c := watch(t, path)
+	assert.Equal(t, "first", c.get())
+
+	writeVersion("..v2", "second")
+	swap("..v2")
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..v1")))
+	assertContent(t, c, "second")
+
+	writeVersion("..v3", "third")
+	swap("..v3")
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..v2")))
+	assertContent(t, c, "third")
+}
+
+func TestWatcherReloadError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+
+	var mu sync.Mutex
+	calls := 0
+	w, err := New(path, zap.NewNop(), func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return errors.New("invalid content")
+	})
+	require.NoError(t, err)
+
+	// reload errors don't stop the watcher
+	for i := 1; i <= 2; i++ {
+		require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return calls >= i
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestWatcherMissingFile(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing"), zap.NewNop(), func() error { return nil })
+	require.Error(t, err)
+
+	var w *Watcher
+	assert.NoError(t, w.Close())
+}
@@ -12,8 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/datatest"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
 )
 
 func TestDownscale(t *testing.T) {
@@ -83,17 +82,16 @@ func TestDownscale(t *testing.T) {
 				buckets[i] = Repr[B]{scale: r.scale, bkt: bkt}
 			}
 
-			is := datatest.New(t)
 			for i := 0; i < len(buckets)-1; i++ {
 				expo.Downscale(buckets[i].bkt, buckets[i].scale, buckets[i+1].scale)
 
-				is.Equalf(buckets[i+1].bkt.Offset(), buckets[i].bkt.Offset(), "offset")
+				assert.Equal(t, buckets[i+1].bkt.Offset(), buckets[i].bkt.Offset(), "offset")
 
 				want := buckets[i+1].bkt.BucketCounts().AsRaw()
 				got := buckets[i].bkt.BucketCounts().AsRaw()
 
-				is.Equalf(want, got[:len(want)], "counts")
-				is.Equalf(make([]uint64, len(got)-len(want)), got[len(want):], "extra-space")
+				assert.Equal(t, want, got[:len(want)], "counts")
+				assert.Equal(t, make([]uint64, len(got)-len(want)), got[len(want):], "extra-space")
 			}
 		})
 	}
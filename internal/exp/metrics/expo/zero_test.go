@@ -9,9 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/datatest"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo/expotest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo/expotest"
 )
 
 type hist = expotest.Histogram
@@ -57,8 +56,7 @@ func TestWidenZero(t *testing.T) {
 			}
 			expo.WidenZero(hist, zt)
 
-			is := datatest.New(t)
-			is.Equal(want, hist)
+			assert.Equal(t, want, hist)
 		})
 	}
 
@@ -109,8 +107,7 @@ func TestSlice(t *testing.T) {
 
 			expo.Abs(bins).Slice(from, to)
 
-			is := datatest.New(t)
-			is.Equal(want, bins)
+			assert.Equal(t, want, bins)
 		})
 	}
 
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package shard assigns streams to a fixed set of owners, so that components routing the
+// streams and components accumulating them agree on the owner of every stream.
+package shard // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/shard"
+
+import (
+	"slices"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+// Of returns the shard owning the stream, out of n shards. n must be positive.
+func Of(id identity.Stream, n int) int {
+	return int(id.Hash().Sum64() % uint64(n))
+}
+
+// Endpoints returns a sorted copy of the endpoints. The position of an endpoint in the
+// returned slice is the shard it owns.
+func Endpoints(endpoints []string) []string {
+	sorted := slices.Clone(endpoints)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
+
+// Index returns the shard owned by the endpoint, or -1 if it is not one of the endpoints.
+func Index(endpoints []string, endpoint string) int {
+	return slices.Index(Endpoints(endpoints), endpoint)
+}
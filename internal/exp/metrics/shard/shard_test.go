@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package shard
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+func TestOf(t *testing.T) {
+	m := pmetric.NewMetric()
+	m.SetName("requests")
+	m.SetEmptySum()
+	rm := pmetric.NewResourceMetrics()
+	id := identity.OfResourceMetric(rm.Resource(), rm.ScopeMetrics().AppendEmpty().Scope(), m)
+
+	counts := make([]int, 3)
+	for i := range 300 {
+		dp := pmetric.NewNumberDataPoint()
+		dp.Attributes().PutStr("n", fmt.Sprint(i))
+		stream := identity.OfStream(id, dp)
+
+		shard := Of(stream, 3)
+		assert.Equal(t, shard, Of(stream, 3), "shard must be stable")
+		assert.Equal(t, int(stream.Hash().Sum64()%3), shard)
+		counts[shard]++
+	}
+	for shard, count := range counts {
+		assert.Positive(t, count, "shard %d owns no stream", shard)
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	endpoints := []string{"host10:4317", "host2:4317", "host1:4317", "host2:4317"}
+
+	assert.Equal(t, []string{"host10:4317", "host1:4317", "host2:4317"}, Endpoints(endpoints))
+	assert.Equal(t, []string{"host10:4317", "host2:4317", "host1:4317", "host2:4317"}, endpoints, "input must not be modified")
+
+	assert.Equal(t, 0, Index(endpoints, "host10:4317"))
+	assert.Equal(t, 1, Index(endpoints, "host1:4317"))
+	assert.Equal(t, 2, Index(endpoints, "host2:4317"))
+	assert.Equal(t, -1, Index(endpoints, "host3:4317"))
+}
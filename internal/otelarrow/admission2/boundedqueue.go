@@ -16,20 +16,33 @@ import (
 	"google.golang.org/grpc/status"
 
 	internalmetadata "github.com/open-telemetry/opentelemetry-collector-contrib/internal/otelarrow/internal/metadata"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/otelarrow/netstats"
 )
 
+// receiverKey is the attribute identifying the receiver in the admission
+// metrics, the same as netstats.ReceiverKey.
+const receiverKey = "receiver"
+
 var (
 	ErrTooMuchWaiting  = status.Error(grpccodes.ResourceExhausted, "rejecting request, too much pending data")
 	ErrRequestTooLarge = status.Errorf(grpccodes.InvalidArgument, "rejecting request, request is too large")
 )
 
+// Reasons a request is rejected for, as passed to the function set with
+// WithRejectFunc.
+const (
+	ReasonTooLarge       = "too_large"
+	ReasonTooMuchWaiting = "too_much_waiting"
+	ReasonCanceled       = "canceled"
+)
+
 // BoundedQueue is a LIFO-oriented admission-controlled Queue.
 type BoundedQueue struct {
-	maxLimitAdmit    uint64
-	maxLimitWait     uint64
-	tracer           trace.Tracer
-	telemetryBuilder *internalmetadata.TelemetryBuilder
+	maxLimitAdmit     uint64
+	maxLimitWait      uint64
+	errTooMuchWaiting error
+	onReject          func(ctx context.Context, reason string)
+	tracer            trace.Tracer
+	telemetryBuilder  *internalmetadata.TelemetryBuilder
 
 	// lock protects currentAdmitted, currentWaiting, and waiters
 
@@ -47,30 +60,53 @@ type waiter struct {
 	pending uint64
 }
 
+// Option customizes a BoundedQueue.
+type Option func(*BoundedQueue)
+
+// WithTooMuchWaitingError replaces ErrTooMuchWaiting as the error returned
+// when too many bytes are waiting, e.g. to add a retry hint to it.
+func WithTooMuchWaitingError(err error) Option {
+	return func(bq *BoundedQueue) {
+		bq.errTooMuchWaiting = err
+	}
+}
+
+// WithRejectFunc sets a function called with the reason of every rejected
+// request.
+func WithRejectFunc(fn func(ctx context.Context, reason string)) Option {
+	return func(bq *BoundedQueue) {
+		bq.onReject = fn
+	}
+}
+
+// WithTracer sets the tracer of the spans of the requests waiting for
+// admission.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(bq *BoundedQueue) {
+		bq.tracer = tracer
+	}
+}
+
 // NewBoundedQueue returns a LIFO-oriented Queue implementation which
 // admits `maxLimitAdmit` bytes concurrently and allows up to
-// `maxLimitWait` bytes to wait for admission.
-func NewBoundedQueue(id component.ID, ts component.TelemetrySettings, maxLimitAdmit, maxLimitWait uint64) (Queue, error) {
-	bq := &BoundedQueue{
-		maxLimitAdmit: maxLimitAdmit,
-		maxLimitWait:  maxLimitWait,
-		waiters:       list.New(),
-		tracer:        ts.TracerProvider.Tracer("github.com/open-telemetry/opentelemetry-collector-contrib/internal/otelarrow"),
-	}
-	attr := metric.WithAttributes(attribute.String(netstats.ReceiverKey, id.String()))
+// `maxLimitWait` bytes to wait for admission. The admitted and waiting
+// bytes are reported as the otelarrow admission metrics of the receiver.
+func NewBoundedQueue(id component.ID, ts component.TelemetrySettings, maxLimitAdmit, maxLimitWait uint64, opts ...Option) (Queue, error) {
+	bq := NewBoundedQueueWithoutMetrics(ts, maxLimitAdmit, maxLimitWait, opts...)
+	attr := metric.WithAttributes(attribute.String(receiverKey, id.String()))
 	telemetryBuilder, err := internalmetadata.NewTelemetryBuilder(ts)
 	if err != nil {
 		return nil, err
 	}
 	err = telemetryBuilder.RegisterOtelarrowAdmissionInFlightBytesCallback(func(_ context.Context, observer metric.Int64Observer) error {
-		observer.Observe(bq.inFlightCB(), attr)
+		observer.Observe(bq.InFlight(), attr)
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 	err = telemetryBuilder.RegisterOtelarrowAdmissionWaitingBytesCallback(func(_ context.Context, observer metric.Int64Observer) error {
-		observer.Observe(bq.waitingCB(), attr)
+		observer.Observe(bq.Waiting(), attr)
 		return nil
 	})
 	if err != nil {
@@ -80,14 +116,32 @@ func NewBoundedQueue(id component.ID, ts component.TelemetrySettings, maxLimitAd
 	return bq, nil
 }
 
-func (bq *BoundedQueue) inFlightCB() int64 {
+// NewBoundedQueueWithoutMetrics returns a BoundedQueue like NewBoundedQueue,
+// for components reporting their own metrics with InFlight and Waiting.
+func NewBoundedQueueWithoutMetrics(ts component.TelemetrySettings, maxLimitAdmit, maxLimitWait uint64, opts ...Option) *BoundedQueue {
+	bq := &BoundedQueue{
+		maxLimitAdmit:     maxLimitAdmit,
+		maxLimitWait:      maxLimitWait,
+		errTooMuchWaiting: ErrTooMuchWaiting,
+		waiters:           list.New(),
+		tracer:            ts.TracerProvider.Tracer("github.com/open-telemetry/opentelemetry-collector-contrib/internal/otelarrow"),
+	}
+	for _, opt := range opts {
+		opt(bq)
+	}
+	return bq
+}
+
+// InFlight returns the number of admitted bytes.
+func (bq *BoundedQueue) InFlight() int64 {
 	// Note, see https://github.com/open-telemetry/otel-arrow/issues/270
 	bq.lock.Lock()
 	defer bq.lock.Unlock()
 	return int64(bq.currentAdmitted)
 }
 
-func (bq *BoundedQueue) waitingCB() int64 {
+// Waiting returns the number of bytes waiting for admission.
+func (bq *BoundedQueue) Waiting() int64 {
 	// Note, see https://github.com/open-telemetry/otel-arrow/issues/270
 	bq.lock.Lock()
 	defer bq.lock.Unlock()
@@ -99,12 +153,14 @@ func (bq *BoundedQueue) waitingCB() int64 {
 //
 // - element=nil, error=nil: the fast success path
 // - element=nil, error=non-nil: the fast failure path
-// - element=non-nil, error=non-nil: the slow success path
-func (bq *BoundedQueue) acquireOrGetWaiter(pending uint64) (*list.Element, error) {
+// - element=non-nil, error=nil: the slow success path
+//
+// The reason is set on the failure path.
+func (bq *BoundedQueue) acquireOrGetWaiter(pending uint64) (*list.Element, string, error) {
 	if pending > bq.maxLimitAdmit {
 		// when the request will never succeed because it is
 		// individually over the total limit, fail fast.
-		return nil, ErrRequestTooLarge
+		return nil, ReasonTooLarge, ErrRequestTooLarge
 	}
 
 	bq.lock.Lock()
@@ -113,26 +169,27 @@ func (bq *BoundedQueue) acquireOrGetWaiter(pending uint64) (*list.Element, error
 	if bq.currentAdmitted+pending <= bq.maxLimitAdmit {
 		// the fast success path.
 		bq.currentAdmitted += pending
-		return nil, nil
+		return nil, "", nil
 	}
 
 	// since we were unable to admit, check if we can wait.
 	if bq.currentWaiting+pending > bq.maxLimitWait {
-		return nil, ErrTooMuchWaiting
+		return nil, ReasonTooMuchWaiting, bq.errTooMuchWaiting
 	}
 
 	// otherwise we need to wait
-	return bq.addWaiterLocked(pending), nil
+	return bq.addWaiterLocked(pending), "", nil
 }
 
 // Acquire implements Queue.
 func (bq *BoundedQueue) Acquire(ctx context.Context, pending uint64) (ReleaseFunc, error) {
-	element, err := bq.acquireOrGetWaiter(pending)
+	element, reason, err := bq.acquireOrGetWaiter(pending)
 	parentSpan := trace.SpanFromContext(ctx)
 	pendingAttr := trace.WithAttributes(attribute.Int64("pending", int64(pending)))
 
 	if err != nil {
 		parentSpan.AddEvent("admission rejected (fast path)", pendingAttr)
+		bq.reject(ctx, reason)
 		return noopRelease, err
 	} else if element == nil {
 		parentSpan.AddEvent("admission accepted (fast path)", pendingAttr)
@@ -168,10 +225,17 @@ func (bq *BoundedQueue) Acquire(ctx context.Context, pending uint64) (ReleaseFun
 		}
 
 		parentSpan.AddEvent("admission rejected (canceled)", pendingAttr)
+		bq.reject(ctx, ReasonCanceled)
 		return noopRelease, status.Error(grpccodes.Canceled, context.Cause(ctx).Error())
 	}
 }
 
+func (bq *BoundedQueue) reject(ctx context.Context, reason string) {
+	if bq.onReject != nil {
+		bq.onReject(ctx, reason)
+	}
+}
+
 func (bq *BoundedQueue) admitWaitersLocked() {
 	for bq.waiters.Len() != 0 {
 		// Ensure there is enough room to admit the next waiter.
@@ -209,11 +273,15 @@ func (bq *BoundedQueue) releaseLocked(pending uint64) {
 	bq.admitWaitersLocked()
 }
 
+// releaseFunc returns a ReleaseFunc that is safe to call more than once.
 func (bq *BoundedQueue) releaseFunc(pending uint64) ReleaseFunc {
+	var once sync.Once
 	return func() {
-		bq.lock.Lock()
-		defer bq.lock.Unlock()
+		once.Do(func() {
+			bq.lock.Lock()
+			defer bq.lock.Unlock()
 
-		bq.releaseLocked(pending)
+			bq.releaseLocked(pending)
+		})
 	}
 }
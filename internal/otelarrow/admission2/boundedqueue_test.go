@@ -417,6 +417,48 @@ func TestBoundedQueueCancelation(t *testing.T) {
 	}
 }
 
+func TestBoundedQueueOptions(t *testing.T) {
+	errRetryLater := status.Error(codes.ResourceExhausted, "retry later")
+	var reasons []string
+	bq := NewBoundedQueueWithoutMetrics(componenttest.NewNopTelemetrySettings(), 10, 5,
+		WithTooMuchWaitingError(errRetryLater),
+		WithRejectFunc(func(_ context.Context, reason string) {
+			reasons = append(reasons, reason)
+		}),
+	)
+	ctx := t.Context()
+
+	_, err := bq.Acquire(ctx, 11)
+	require.ErrorIs(t, err, ErrRequestTooLarge)
+
+	release, err := bq.Acquire(ctx, 8)
+	require.NoError(t, err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = bq.Acquire(canceled, 3)
+	require.Equal(t, codes.Canceled, status.Code(err))
+
+	var releaseWaiter ReleaseFunc
+	notify := (&bqTest{t: t, BoundedQueue: bq}).startWaiter(ctx, 5, &releaseWaiter)
+	require.Eventually(t, func() bool {
+		return bq.InFlight() == 8 && bq.Waiting() == 5
+	}, 10*time.Second, 20*time.Millisecond)
+
+	_, err = bq.Acquire(ctx, 3)
+	require.ErrorIs(t, err, errRetryLater)
+	require.Equal(t, []string{ReasonTooLarge, ReasonCanceled, ReasonTooMuchWaiting}, reasons)
+
+	// releasing twice has no effect
+	release()
+	release()
+	notify.WaitForNotification()
+	require.Equal(t, int64(5), bq.InFlight())
+	releaseWaiter()
+	require.Equal(t, int64(0), bq.InFlight())
+	require.Equal(t, int64(0), bq.Waiting())
+}
+
 func TestBoundedQueueNoop(t *testing.T) {
 	nq := NewUnboundedQueue()
 	for _, i := range mkRange(1, 100) {
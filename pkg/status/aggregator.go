@@ -82,6 +82,14 @@ type subscription struct {
 // UnsubscribeFunc is a function used to unsubscribe from a stream.
 type UnsubscribeFunc func()
 
+// Subscriber is implemented by the Aggregator, and by the components exposing the statuses they
+// aggregate to other components, such as the healthcheckv2 extension.
+type Subscriber interface {
+	Subscribe(scope Scope, verbosity Verbosity) (<-chan *AggregateStatus, UnsubscribeFunc)
+}
+
+var _ Subscriber = (*Aggregator)(nil)
+
 // Aggregator records individual status events for components and aggregates statuses for the
 // pipelines they belong to and the collector overall.
 type Aggregator struct {
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datafreshnessprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/datafreshnessprocessor"
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errInvalidMaxIdle       = errors.New("max_idle must be positive")
+	errInvalidCheckInterval = errors.New("check_interval must be positive")
+)
+
+// Config defines the configuration for the data freshness processor.
+type Config struct {
+	// MaxIdle is how long the pipeline may go without receiving data before
+	// it is reported as stale.
+	MaxIdle time.Duration `mapstructure:"max_idle"`
+
+	// CheckInterval is how often the time since the last data was received
+	// is checked.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxIdle <= 0 {
+		return errInvalidMaxIdle
+	}
+	if cfg.CheckInterval <= 0 {
+		return errInvalidCheckInterval
+	}
+	return nil
+}
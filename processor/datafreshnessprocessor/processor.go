@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datafreshnessprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/datafreshnessprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// freshnessProcessor reports a recoverable error status when the pipeline it
+// belongs to has not received data for longer than max_idle, and an ok status
+// once data is received again. The status is aggregated by the health check v2
+// extension into the status of the pipeline, like any component status.
+type freshnessProcessor struct {
+	cfg    *Config
+	logger *zap.Logger
+	now    func() time.Time
+
+	// lastReceived is the time the last data was received, in Unix nanoseconds
+	lastReceived atomic.Int64
+
+	host   component.Host
+	stale  bool
+	cancel context.CancelFunc
+	doneWg sync.WaitGroup
+}
+
+func newFreshnessProcessor(cfg *Config, set component.TelemetrySettings) *freshnessProcessor {
+	return &freshnessProcessor{
+		cfg:    cfg,
+		logger: set.Logger,
+		now:    time.Now,
+	}
+}
+
+// start starts checking the time since the last data was received. The
+// pipeline is not reported as stale before max_idle elapsed since it started.
+func (p *freshnessProcessor) start(_ context.Context, host component.Host) error {
+	p.host = host
+	p.lastReceived.Store(p.now().UnixNano())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	p.doneWg.Add(1)
+	go func() {
+		defer p.doneWg.Done()
+
+		ticker := time.NewTicker(p.cfg.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.check()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *freshnessProcessor) shutdown(context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+	p.doneWg.Wait()
+	return nil
+}
+
+// check reports the status of the pipeline when it became stale, or received
+// data again after being stale.
+func (p *freshnessProcessor) check() {
+	idle := p.now().Sub(time.Unix(0, p.lastReceived.Load()))
+	stale := idle > p.cfg.MaxIdle
+	if stale == p.stale {
+		return
+	}
+	p.stale = stale
+
+	if stale {
+		p.logger.Warn("No data received by the pipeline", zap.Duration("max_idle", p.cfg.MaxIdle))
+		componentstatus.ReportStatus(p.host, componentstatus.NewRecoverableErrorEvent(
+			fmt.Errorf("no data received for more than %s", p.cfg.MaxIdle),
+		))
+		return
+	}
+	componentstatus.ReportStatus(p.host, componentstatus.NewEvent(componentstatus.StatusOK))
+}
+
+func (p *freshnessProcessor) received(items int) {
+	if items > 0 {
+		p.lastReceived.Store(p.now().UnixNano())
+	}
+}
+
+func (p *freshnessProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	p.received(td.SpanCount())
+	return td, nil
+}
+
+func (p *freshnessProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.received(md.DataPointCount())
+	return md, nil
+}
+
+func (p *freshnessProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	p.received(ld.LogRecordCount())
+	return ld, nil
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datafreshnessprocessor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/datafreshnessprocessor/internal/metadata"
+)
+
+// statusHost records the status events reported by the processor, like the collector does for the
+// health check v2 extension
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func (h *statusHost) statuses() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	statuses := make([]componentstatus.Status, 0, len(h.events))
+	for _, event := range h.events {
+		statuses = append(statuses, event.Status())
+	}
+	return statuses
+}
+
+func testConfig() *Config {
+	return &Config{MaxIdle: 50 * time.Millisecond, CheckInterval: 5 * time.Millisecond}
+}
+
+func TestProcessorReportsStalePipeline(t *testing.T) {
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	sink := &consumertest.TracesSink{}
+	p, err := NewFactory().CreateTraces(t.Context(), processortest.NewNopSettings(metadata.Type), testConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), host))
+	defer func() {
+		require.NoError(t, p.Shutdown(t.Context()))
+	}()
+
+	// The pipeline is stale once no data was received for max_idle
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+	}, time.Second, 5*time.Millisecond)
+
+	// The pipeline recovers as soon as data is received, which is forwarded unmodified
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, p.ConsumeTraces(t.Context(), td))
+	assert.Equal(t, 1, sink.SpanCount())
+
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestProcessorIgnoresEmptyData(t *testing.T) {
+	fp := newFreshnessProcessor(testConfig(), componenttest.NewNopTelemetrySettings())
+	now := time.Unix(0, 0)
+	fp.now = func() time.Time { return now }
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, fp.start(t.Context(), host))
+	require.NoError(t, fp.shutdown(t.Context()))
+
+	now = now.Add(time.Second)
+	_, err := fp.processMetrics(t.Context(), pmetric.NewMetrics())
+	require.NoError(t, err)
+	_, err = fp.processLogs(t.Context(), plog.NewLogs())
+	require.NoError(t, err)
+	fp.check()
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	_, err = fp.processLogs(t.Context(), ld)
+	require.NoError(t, err)
+	fp.check()
+	fp.check()
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+}
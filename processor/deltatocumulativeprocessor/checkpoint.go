@@ -0,0 +1,334 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/puzpuzpuz/xsync/v3"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/maps"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metrics"
+)
+
+// checkpointKey is the key under which the number of chunks of the stream state is
+// stored in the storage client, each chunk being stored under [chunkKey]. The version
+// suffix allows changing the encoding of the state without misinterpreting older
+// checkpoints.
+const checkpointKey = "deltatocumulative_streams_v1"
+
+// checkpointChunkSize is the maximum number of streams stored under a single key, so
+// that the size of the values written to the storage does not grow with the state.
+var checkpointChunkSize = 10_000
+
+// chunkKey returns the key under which the i-th chunk of the stream state is stored.
+func chunkKey(i int) string {
+	return checkpointKey + "_" + strconv.Itoa(i)
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// origins keeps a copy of the resource, scope and metric of the tracked streams.
+// These are not part of the stream state, but are required to encode it as metrics.
+// Tracking a known metric does not lock, as it is done for every sample.
+type origins struct {
+	resources *xsync.MapOf[identity.Resource, pcommon.Resource]
+	scopes    *xsync.MapOf[identity.Scope, pcommon.InstrumentationScope]
+	metrics   *xsync.MapOf[identity.Metric, pmetric.Metric]
+}
+
+func newOrigins() *origins {
+	return &origins{
+		resources: xsync.NewMapOf[identity.Resource, pcommon.Resource](),
+		scopes:    xsync.NewMapOf[identity.Scope, pcommon.InstrumentationScope](),
+		metrics:   xsync.NewMapOf[identity.Metric, pmetric.Metric](),
+	}
+}
+
+// track copies the resource, scope and metric of m, without datapoints, unless
+// they are already known. The metric is stored last, so that the resource and
+// scope of a known metric are known as well.
+func (o *origins) track(m metrics.Metric) {
+	id := m.Ident()
+	if _, ok := o.metrics.Load(id); ok {
+		return
+	}
+
+	o.resources.LoadOrCompute(id.Scope().Resource(), func() pcommon.Resource {
+		res := pcommon.NewResource()
+		m.Resource().CopyTo(res)
+		return res
+	})
+	o.scopes.LoadOrCompute(id.Scope(), func() pcommon.InstrumentationScope {
+		scope := pcommon.NewInstrumentationScope()
+		m.Scope().CopyTo(scope)
+		return scope
+	})
+	o.metrics.LoadOrCompute(id, func() pmetric.Metric {
+		metric := pmetric.NewMetric()
+		metric.SetName(m.Name())
+		metric.SetDescription(m.Description())
+		metric.SetUnit(m.Unit())
+		switch m.Type() {
+		case pmetric.MetricTypeSum:
+			sum := metric.SetEmptySum()
+			sum.SetIsMonotonic(m.Sum().IsMonotonic())
+			sum.SetAggregationTemporality(m.Sum().AggregationTemporality())
+		case pmetric.MetricTypeHistogram:
+			metric.SetEmptyHistogram().SetAggregationTemporality(m.Histogram().AggregationTemporality())
+		case pmetric.MetricTypeExponentialHistogram:
+			metric.SetEmptyExponentialHistogram().SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+		}
+		return metric
+	})
+}
+
+// encoder encodes the state of the streams as chunks of metrics, each holding at
+// most checkpointChunkSize streams.
+type encoder struct {
+	origins *origins
+	chunks  []pmetric.Metrics
+	size    int
+
+	// resource, scope and metric of the current chunk
+	rms map[identity.Resource]pmetric.ResourceMetrics
+	sms map[identity.Scope]pmetric.ScopeMetrics
+	ms  map[identity.Metric]pmetric.Metric
+
+	// origins of all encoded streams
+	seen map[identity.Metric]struct{}
+}
+
+// metric returns the metric of the current chunk to append the datapoint of a
+// stream of id to, starting a new chunk if the current one is full.
+func (e *encoder) metric(id identity.Metric) (pmetric.Metric, bool) {
+	origin, ok := e.origins.metrics.Load(id)
+	if !ok {
+		// stream was stored after its origin was forgotten. it is tracked
+		// again with the next sample.
+		return pmetric.Metric{}, false
+	}
+	res, _ := e.origins.resources.Load(id.Scope().Resource())
+	scope, _ := e.origins.scopes.Load(id.Scope())
+
+	if len(e.chunks) == 0 || e.size >= checkpointChunkSize {
+		e.chunks = append(e.chunks, pmetric.NewMetrics())
+		e.size = 0
+		e.rms = map[identity.Resource]pmetric.ResourceMetrics{}
+		e.sms = map[identity.Scope]pmetric.ScopeMetrics{}
+		e.ms = map[identity.Metric]pmetric.Metric{}
+	}
+	e.size++
+	e.seen[id] = struct{}{}
+
+	if m, ok := e.ms[id]; ok {
+		return m, true
+	}
+	sm, ok := e.sms[id.Scope()]
+	if !ok {
+		rm, ok := e.rms[id.Scope().Resource()]
+		if !ok {
+			rm = e.chunks[len(e.chunks)-1].ResourceMetrics().AppendEmpty()
+			res.CopyTo(rm.Resource())
+			e.rms[id.Scope().Resource()] = rm
+		}
+		sm = rm.ScopeMetrics().AppendEmpty()
+		scope.CopyTo(sm.Scope())
+		e.sms[id.Scope()] = sm
+	}
+
+	m := sm.Metrics().AppendEmpty()
+	origin.CopyTo(m)
+	e.ms[id] = m
+	return m, true
+}
+
+// encodeState encodes the state of all streams as chunks of metrics, each datapoint
+// holding the cumulative value of a stream. The metrics keep the delta temporality of
+// the streams, so that their identity is unchanged when restored. The origins of
+// streams no longer tracked are forgotten.
+func (p *deltaToCumulativeProcessor) encodeState() []pmetric.Metrics {
+	e := encoder{origins: p.origins, seen: map[identity.Metric]struct{}{}}
+
+	p.last.nums.Range(func(id identity.Stream, last *mutex[pmetric.NumberDataPoint]) bool {
+		if m, ok := e.metric(id.Metric()); ok {
+			last.use(func(last pmetric.NumberDataPoint) {
+				last.CopyTo(m.Sum().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+	p.last.hist.Range(func(id identity.Stream, last *mutex[pmetric.HistogramDataPoint]) bool {
+		if m, ok := e.metric(id.Metric()); ok {
+			last.use(func(last pmetric.HistogramDataPoint) {
+				last.CopyTo(m.Histogram().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+	p.last.expo.Range(func(id identity.Stream, last *mutex[pmetric.ExponentialHistogramDataPoint]) bool {
+		if m, ok := e.metric(id.Metric()); ok {
+			last.use(func(last pmetric.ExponentialHistogramDataPoint) {
+				last.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+
+	scopes := map[identity.Scope]struct{}{}
+	resources := map[identity.Resource]struct{}{}
+	for id := range e.seen {
+		scopes[id.Scope()] = struct{}{}
+		resources[id.Scope().Resource()] = struct{}{}
+	}
+	p.origins.metrics.Range(func(id identity.Metric, _ pmetric.Metric) bool {
+		if _, ok := e.seen[id]; !ok {
+			p.origins.metrics.Delete(id)
+		}
+		return true
+	})
+	p.origins.scopes.Range(func(id identity.Scope, _ pcommon.InstrumentationScope) bool {
+		if _, ok := scopes[id]; !ok {
+			p.origins.scopes.Delete(id)
+		}
+		return true
+	})
+	p.origins.resources.Range(func(id identity.Resource, _ pcommon.Resource) bool {
+		if _, ok := resources[id]; !ok {
+			p.origins.resources.Delete(id)
+		}
+		return true
+	})
+
+	return e.chunks
+}
+
+// checkpoint persists the state of all streams to the storage client, as chunks of at
+// most checkpointChunkSize streams. The chunks and their number are written in a single
+// batch, which also deletes the chunks left over from a larger previous checkpoint.
+func (p *deltaToCumulativeProcessor) checkpoint(ctx context.Context) error {
+	chunks := p.encodeState()
+
+	ops := make([]*storage.Operation, 0, len(chunks)+1)
+	for i, md := range chunks {
+		data, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+		if err != nil {
+			return fmt.Errorf("failed to encode stream state: %w", err)
+		}
+		ops = append(ops, storage.SetOperation(chunkKey(i), data))
+	}
+	ops = append(ops, storage.SetOperation(checkpointKey, []byte(strconv.Itoa(len(chunks)))))
+	for i := len(chunks); i < p.chunks; i++ {
+		ops = append(ops, storage.DeleteOperation(chunkKey(i)))
+	}
+
+	if err := p.storage.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to write stream state: %w", err)
+	}
+	p.chunks = len(chunks)
+	return nil
+}
+
+// restore loads the state of the streams from the storage client. Streams owned by
+// other replicas are skipped, as are streams exceeding max_streams. A state that
+// cannot be decoded is discarded with a warning rather than failing start.
+func (p *deltaToCumulativeProcessor) restore(ctx context.Context) error {
+	data, err := p.storage.Get(ctx, checkpointKey)
+	if err != nil {
+		return fmt.Errorf("failed to read stream state: %w", err)
+	}
+	if len(data) == 0 {
+		p.log.Debug("No stream state found in storage")
+		return nil
+	}
+	chunks, err := strconv.Atoi(string(data))
+	if err != nil || chunks < 0 {
+		p.log.Warn("Discarding invalid stream state", zap.ByteString("chunks", data))
+		return nil
+	}
+	// chunks of the previous checkpoint are overwritten or deleted by the next one
+	p.chunks = chunks
+
+	restored := 0
+	for i := range chunks {
+		data, err := p.storage.Get(ctx, chunkKey(i))
+		if err != nil {
+			return fmt.Errorf("failed to read stream state: %w", err)
+		}
+		md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(data)
+		if err != nil {
+			p.log.Warn("Discarding invalid stream state", zap.Int("chunk", i), zap.Error(err))
+			continue
+		}
+		restored += p.restoreChunk(md)
+	}
+
+	p.log.Info("Restored stream state", zap.Int("streams", restored))
+	return nil
+}
+
+// restoreChunk loads the streams of a chunk of the stream state, returning the
+// number of restored streams.
+func (p *deltaToCumulativeProcessor) restoreChunk(md pmetric.Metrics) int {
+	now := time.Now()
+	restored := 0
+	metrics.Filter(md, func(m metrics.Metric) bool {
+		p.origins.track(m)
+		m.Filter(func(id identity.Stream, dp any) bool {
+			if !p.cfg.Sharding.Owns(id) {
+				return false
+			}
+
+			var ok bool
+			switch dp := dp.(type) {
+			case pmetric.NumberDataPoint:
+				last := pmetric.NewNumberDataPoint()
+				dp.CopyTo(last)
+				ok = load(p.last.nums, id, last)
+			case pmetric.HistogramDataPoint:
+				last := pmetric.NewHistogramDataPoint()
+				dp.CopyTo(last)
+				ok = load(p.last.hist, id, last)
+			case pmetric.ExponentialHistogramDataPoint:
+				last := pmetric.NewExponentialHistogramDataPoint()
+				dp.CopyTo(last)
+				ok = load(p.last.expo, id, last)
+			}
+			if ok {
+				p.stale.Store(id, now)
+				restored++
+			}
+			return ok
+		})
+		return true
+	})
+	return restored
+}
+
+// load stores the state of a stream, unless the state is full.
+func load[T any](into *maps.Parallel[identity.Stream, *mutex[T]], id identity.Stream, last T) bool {
+	v, loaded := into.LoadOrStore(id, guard(last))
+	return !maps.Exceeded(v, loaded)
+}
@@ -0,0 +1,294 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/shard"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metrics"
+)
+
+var testStorageID = component.MustNewID("memory_storage")
+
+func TestCheckpointRestore(t *testing.T) {
+	host := newStorageHost()
+	cfg := &Config{MaxStreams: math.MaxInt, Storage: &testStorageID, CheckpointInterval: time.Hour}
+
+	t0 := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	t1 := pcommon.NewTimestampFromTime(time.Unix(1060, 0))
+	t2 := pcommon.NewTimestampFromTime(time.Unix(1120, 0))
+
+	sink := new(consumertest.MetricsSink)
+	proc := start(t, cfg, host, sink)
+	require.NoError(t, proc.ConsumeMetrics(t.Context(), deltaSums(1, t0, t1, 3)))
+	require.NoError(t, proc.Shutdown(t.Context()))
+
+	sink = new(consumertest.MetricsSink)
+	proc = start(t, cfg, host, sink)
+	require.Equal(t, 1, proc.last.Size())
+	require.NoError(t, proc.ConsumeMetrics(t.Context(), deltaSums(1, t1, t2, 4)))
+	require.NoError(t, proc.Shutdown(t.Context()))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	sum := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	dp := sum.DataPoints().At(0)
+	assert.Equal(t, int64(7), dp.IntValue())
+	assert.Equal(t, t0, dp.StartTimestamp())
+	assert.Equal(t, t2, dp.Timestamp())
+}
+
+func TestRestoreInvalidState(t *testing.T) {
+	host := newStorageHost()
+	host.ext.data[checkpointKey] = []byte("not a checkpoint")
+	cfg := &Config{MaxStreams: math.MaxInt, Storage: &testStorageID, CheckpointInterval: time.Hour}
+
+	proc := start(t, cfg, host, new(consumertest.MetricsSink))
+	assert.Equal(t, 0, proc.last.Size())
+	require.NoError(t, proc.Shutdown(t.Context()))
+}
+
+func TestRestoreSharding(t *testing.T) {
+	host := newStorageHost()
+	cfg := &Config{MaxStreams: math.MaxInt, Storage: &testStorageID, CheckpointInterval: time.Hour}
+
+	t0 := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	t1 := pcommon.NewTimestampFromTime(time.Unix(1060, 0))
+	proc := start(t, cfg, host, new(consumertest.MetricsSink))
+	require.NoError(t, proc.ConsumeMetrics(t.Context(), deltaSums(20, t0, t1, 1)))
+	require.Equal(t, 20, proc.last.Size())
+	require.NoError(t, proc.Shutdown(t.Context()))
+
+	sharded := *cfg
+	sharded.Sharding = ShardingConfig{Shards: 2, Index: 1}
+	proc = start(t, &sharded, host, new(consumertest.MetricsSink))
+	assert.Equal(t, owned(deltaSums(20, t0, t1, 1), sharded.Sharding), proc.last.Size())
+	require.NoError(t, proc.Shutdown(t.Context()))
+}
+
+func TestSharding(t *testing.T) {
+	t0 := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	t1 := pcommon.NewTimestampFromTime(time.Unix(1060, 0))
+
+	seen := map[string]int{}
+	for index := range 2 {
+		cfg := &Config{MaxStreams: math.MaxInt, Sharding: ShardingConfig{Shards: 2, Index: index}}
+		sink := new(consumertest.MetricsSink)
+		proc, _ := setup(t, cfg, sink)
+
+		// the streams owned by the other replica are dropped without failing the call
+		err := proc.ConsumeMetrics(t.Context(), deltaSums(20, t0, t1, 1))
+		require.NoError(t, err)
+		require.Equal(t, owned(deltaSums(20, t0, t1, 1), cfg.Sharding), sink.DataPointCount())
+
+		for _, md := range sink.AllMetrics() {
+			metrics.Filter(md, func(m metrics.Metric) bool {
+				m.Filter(func(_ identity.Stream, dp any) bool {
+					n, _ := dp.(pmetric.NumberDataPoint).Attributes().Get("n")
+					seen[n.AsString()]++
+					return true
+				})
+				return true
+			})
+		}
+	}
+
+	// every stream is owned by exactly one shard
+	assert.Len(t, seen, 20)
+	for n, count := range seen {
+		assert.Equal(t, 1, count, "stream %s", n)
+	}
+}
+
+func TestShardingEndpoints(t *testing.T) {
+	endpoints := []string{"otelcol-10:4317", "otelcol-2:4317", "otelcol-1:4317"}
+
+	// replicas are ordered like the endpoints of the load balancing exporter
+	for index, endpoint := range shard.Endpoints(endpoints) {
+		sharding := ShardingConfig{Endpoints: endpoints, Endpoint: endpoint}
+		assert.Equal(t, ShardingConfig{Shards: 3, Index: index}, sharding.resolve())
+	}
+
+	md := deltaSums(20, 0, 1, 1)
+	cfg := &Config{MaxStreams: math.MaxInt, Sharding: ShardingConfig{Endpoints: endpoints, Endpoint: "otelcol-2:4317"}}
+	sink := new(consumertest.MetricsSink)
+	proc, _ := setup(t, cfg, sink)
+	_ = proc.ConsumeMetrics(t.Context(), md)
+	assert.Equal(t, owned(deltaSums(20, 0, 1, 1), ShardingConfig{Shards: 3, Index: 2}), sink.DataPointCount())
+}
+
+func TestCheckpointChunks(t *testing.T) {
+	chunkSize := checkpointChunkSize
+	checkpointChunkSize = 8
+	t.Cleanup(func() { checkpointChunkSize = chunkSize })
+
+	host := newStorageHost()
+	cfg := &Config{MaxStreams: math.MaxInt, Storage: &testStorageID, CheckpointInterval: time.Hour}
+
+	t0 := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	t1 := pcommon.NewTimestampFromTime(time.Unix(1060, 0))
+	proc := start(t, cfg, host, new(consumertest.MetricsSink))
+	require.NoError(t, proc.ConsumeMetrics(t.Context(), deltaSums(20, t0, t1, 1)))
+	require.NoError(t, proc.Shutdown(t.Context()))
+
+	// 20 streams are split into chunks of 8 streams
+	assert.Equal(t, "3", string(host.ext.data[checkpointKey]))
+	for i, streams := range []int{8, 8, 4} {
+		md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(host.ext.data[chunkKey(i)])
+		require.NoError(t, err)
+		assert.Equal(t, streams, md.DataPointCount(), "chunk %d", i)
+	}
+
+	proc = start(t, cfg, host, new(consumertest.MetricsSink))
+	require.Equal(t, 20, proc.last.Size())
+	var removed []identity.Stream
+	proc.last.nums.Range(func(id identity.Stream, _ *mutex[pmetric.NumberDataPoint]) bool {
+		removed = append(removed, id)
+		return len(removed) < 12
+	})
+	for _, id := range removed {
+		proc.last.nums.LoadAndDelete(id)
+	}
+	require.NoError(t, proc.Shutdown(t.Context()))
+
+	// the chunk left over from the larger checkpoint is deleted
+	assert.Equal(t, "1", string(host.ext.data[checkpointKey]))
+	assert.NotContains(t, host.ext.data, chunkKey(1))
+	assert.NotContains(t, host.ext.data, chunkKey(2))
+
+	proc = start(t, cfg, host, new(consumertest.MetricsSink))
+	assert.Equal(t, 8, proc.last.Size())
+	require.NoError(t, proc.Shutdown(t.Context()))
+}
+
+// deltaSums returns a delta sum with n streams, each holding a single datapoint.
+func deltaSums(n int, start, ts pcommon.Timestamp, value int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for i := range n {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("n", fmt.Sprint(i))
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetIntValue(value)
+	}
+	return md
+}
+
+// owned returns the number of streams in md owned by the shard. md is expected to be
+// of delta temporality, as the temporality is part of the stream identity.
+func owned(md pmetric.Metrics, shard ShardingConfig) int {
+	count := 0
+	metrics.Filter(md, func(m metrics.Metric) bool {
+		m.Filter(func(id identity.Stream, _ any) bool {
+			if shard.Owns(id) {
+				count++
+			}
+			return true
+		})
+		return true
+	})
+	return count
+}
+
+func start(t *testing.T, cfg *Config, host component.Host, sink *consumertest.MetricsSink) *deltaToCumulativeProcessor {
+	iface, _ := setup(t, cfg, sink)
+	proc := iface.(*deltaToCumulativeProcessor)
+	require.NoError(t, proc.Start(t.Context(), host))
+	return proc
+}
+
+type storageHost struct {
+	component.Host
+	ext *memoryStorage
+}
+
+func newStorageHost() *storageHost {
+	return &storageHost{ext: &memoryStorage{data: map[string][]byte{}}}
+}
+
+func (h *storageHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{testStorageID: h.ext}
+}
+
+// memoryStorage is a storage extension whose clients share the same data, so that
+// it outlives the processor, like a persistent storage would.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mtx  sync.Mutex
+	data map[string][]byte
+}
+
+var _ storage.Extension = (*memoryStorage)(nil)
+
+func (s *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return &memoryClient{s}, nil
+}
+
+type memoryClient struct {
+	*memoryStorage
+}
+
+func (c *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.data[key], nil
+}
+
+func (c *memoryClient) Set(_ context.Context, key string, value []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *memoryClient) Delete(_ context.Context, key string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *memoryClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = c.Get(ctx, op.Key)
+		case storage.Set:
+			err = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = c.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*memoryClient) Close(context.Context) error {
+	return nil
+}
@@ -5,13 +5,17 @@ package deltatocumulativeprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"net"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/shard"
 	telemetry "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/telemetry"
 )
 
@@ -20,6 +24,48 @@ var _ xconfmap.Validator = (*Config)(nil)
 type Config struct {
 	MaxStale   time.Duration `mapstructure:"max_stale"`
 	MaxStreams int           `mapstructure:"max_streams"`
+
+	// Storage is the ID of the storage extension used to checkpoint the stream state, so that
+	// the cumulative series continue across restarts. Optional, the state is kept in memory only
+	// if not set.
+	Storage *component.ID `mapstructure:"storage"`
+	// CheckpointInterval is the interval at which the stream state is checkpointed to the storage.
+	// The state is also checkpointed on shutdown.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
+
+	// Sharding splits the ownership of the streams across multiple replicas.
+	Sharding ShardingConfig `mapstructure:"sharding"`
+}
+
+// ShardingConfig determines which streams are owned by this replica. Streams owned by other
+// replicas are dropped. The owner of a stream is determined by [shard.Of], as used by the
+// streamIDShard routing of the load balancing exporter.
+type ShardingConfig struct {
+	// Shards is the total number of replicas. Sharding is disabled if 0.
+	Shards int `mapstructure:"shards"`
+	// Index is the position of this replica, from 0 to Shards-1.
+	Index int `mapstructure:"index"`
+
+	// Endpoints is the list of endpoints of all replicas, as resolved by the load balancing
+	// exporter, including the port. Replaces Shards and Index: the replicas are ordered like
+	// the load balancing exporter orders its endpoints.
+	Endpoints []string `mapstructure:"endpoints"`
+	// Endpoint is the endpoint of this replica, one of Endpoints.
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// resolve returns the configuration with Shards and Index derived from Endpoints, if set.
+func (s ShardingConfig) resolve() ShardingConfig {
+	if len(s.Endpoints) == 0 {
+		return s
+	}
+	return ShardingConfig{Shards: len(shard.Endpoints(s.Endpoints)), Index: shard.Index(s.Endpoints, s.Endpoint)}
+}
+
+// Owns reports whether the stream is owned by this replica.
+func (s ShardingConfig) Owns(id identity.Stream) bool {
+	s = s.resolve()
+	return s.Shards <= 1 || shard.Of(id, s.Shards) == s.Index
 }
 
 func (c *Config) Validate() error {
@@ -29,6 +75,36 @@ func (c *Config) Validate() error {
 	if c.MaxStreams < 0 {
 		return fmt.Errorf("max_streams must be a positive number (got %d)", c.MaxStreams)
 	}
+	if c.Storage != nil && c.CheckpointInterval <= 0 {
+		return fmt.Errorf("checkpoint_interval must be a positive duration (got %s)", c.CheckpointInterval)
+	}
+	if len(c.Sharding.Endpoints) > 0 {
+		return c.Sharding.validateEndpoints()
+	}
+	if c.Sharding.Endpoint != "" {
+		return errors.New("sharding.endpoint requires sharding.endpoints")
+	}
+	if c.Sharding.Shards < 0 {
+		return fmt.Errorf("sharding.shards must be a positive number (got %d)", c.Sharding.Shards)
+	}
+	if c.Sharding.Shards > 0 && (c.Sharding.Index < 0 || c.Sharding.Index >= c.Sharding.Shards) {
+		return fmt.Errorf("sharding.index must be between 0 and %d (got %d)", c.Sharding.Shards-1, c.Sharding.Index)
+	}
+	return nil
+}
+
+func (s ShardingConfig) validateEndpoints() error {
+	if s.Shards != 0 {
+		return errors.New("sharding.shards and sharding.endpoints are mutually exclusive")
+	}
+	for _, endpoint := range s.Endpoints {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return fmt.Errorf("sharding.endpoints must include the port (got %q)", endpoint)
+		}
+	}
+	if shard.Index(s.Endpoints, s.Endpoint) < 0 {
+		return fmt.Errorf("sharding.endpoint must be one of sharding.endpoints (got %q)", s.Endpoint)
+	}
 	return nil
 }
 
@@ -39,6 +115,8 @@ func createDefaultConfig() component.Config {
 		// TODO: find good default
 		// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/31603
 		MaxStreams: math.MaxInt,
+
+		CheckpointInterval: time.Minute,
 	}
 }
 
@@ -24,6 +24,8 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	storageID := component.MustNewID("file_storage")
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -31,22 +33,55 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "all"),
 			expected: &Config{
-				MaxStale:   1 * time.Minute,
-				MaxStreams: 10,
+				MaxStale:           1 * time.Minute,
+				MaxStreams:         10,
+				CheckpointInterval: time.Minute,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "set-valid-max_stale"),
 			expected: &Config{
-				MaxStale:   2 * time.Minute,
-				MaxStreams: math.MaxInt,
+				MaxStale:           2 * time.Minute,
+				MaxStreams:         math.MaxInt,
+				CheckpointInterval: time.Minute,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "set-valid-max_streams"),
 			expected: &Config{
-				MaxStale:   5 * time.Minute,
-				MaxStreams: 20,
+				MaxStale:           5 * time.Minute,
+				MaxStreams:         20,
+				CheckpointInterval: time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "set-valid-storage"),
+			expected: &Config{
+				MaxStale:           5 * time.Minute,
+				MaxStreams:         math.MaxInt,
+				Storage:            &storageID,
+				CheckpointInterval: 30 * time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "set-valid-sharding"),
+			expected: &Config{
+				MaxStale:           5 * time.Minute,
+				MaxStreams:         math.MaxInt,
+				CheckpointInterval: time.Minute,
+				Sharding:           ShardingConfig{Shards: 3, Index: 1},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "set-valid-sharding-endpoints"),
+			expected: &Config{
+				MaxStale:           5 * time.Minute,
+				MaxStreams:         math.MaxInt,
+				CheckpointInterval: time.Minute,
+				Sharding: ShardingConfig{
+					Endpoints: []string{"otelcol-0:4317", "otelcol-1:4317"},
+					Endpoint:  "otelcol-1:4317",
+				},
 			},
 		},
 	}
@@ -65,3 +100,71 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "negative_max_streams",
+			modify: func(cfg *Config) { cfg.MaxStreams = -1 },
+			err:    "max_streams must be a positive number (got -1)",
+		},
+		{
+			name: "storage_without_checkpoint_interval",
+			modify: func(cfg *Config) {
+				cfg.Storage = &storageID
+				cfg.CheckpointInterval = 0
+			},
+			err: "checkpoint_interval must be a positive duration (got 0s)",
+		},
+		{
+			name:   "negative_shards",
+			modify: func(cfg *Config) { cfg.Sharding.Shards = -2 },
+			err:    "sharding.shards must be a positive number (got -2)",
+		},
+		{
+			name:   "index_out_of_range",
+			modify: func(cfg *Config) { cfg.Sharding = ShardingConfig{Shards: 3, Index: 3} },
+			err:    "sharding.index must be between 0 and 2 (got 3)",
+		},
+		{
+			name: "shards_and_endpoints",
+			modify: func(cfg *Config) {
+				cfg.Sharding = ShardingConfig{Shards: 2, Endpoints: []string{"otelcol-0:4317", "otelcol-1:4317"}, Endpoint: "otelcol-0:4317"}
+			},
+			err: "sharding.shards and sharding.endpoints are mutually exclusive",
+		},
+		{
+			name: "endpoint_without_port",
+			modify: func(cfg *Config) {
+				cfg.Sharding = ShardingConfig{Endpoints: []string{"otelcol-0", "otelcol-1"}, Endpoint: "otelcol-0"}
+			},
+			err: `sharding.endpoints must include the port (got "otelcol-0")`,
+		},
+		{
+			name: "unknown_endpoint",
+			modify: func(cfg *Config) {
+				cfg.Sharding = ShardingConfig{Endpoints: []string{"otelcol-0:4317", "otelcol-1:4317"}, Endpoint: "otelcol-2:4317"}
+			},
+			err: `sharding.endpoint must be one of sharding.endpoints (got "otelcol-2:4317")`,
+		},
+		{
+			name:   "endpoint_without_endpoints",
+			modify: func(cfg *Config) { cfg.Sharding.Endpoint = "otelcol-0:4317" },
+			err:    "sharding.endpoint requires sharding.endpoints",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.ErrorContains(t, xconfmap.Validate(cfg), tt.err)
+		})
+	}
+}
@@ -106,6 +106,12 @@ func (m *Parallel[K, V]) LoadAndDelete(k K) (_ V, loaded bool) {
 	return v, loaded
 }
 
+// Range calls f for each element of the map, until f returns false. See [xsync.MapOf.Range]
+// for the guarantees on concurrent modifications.
+func (m *Parallel[K, V]) Range(f func(k K, v V) bool) {
+	m.elems.Range(f)
+}
+
 func (ctx Context) Size() int64 {
 	return ctx.total.Load()
 }
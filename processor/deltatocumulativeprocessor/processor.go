@@ -5,16 +5,20 @@ package deltatocumulativeprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/puzpuzpuz/xsync/v3"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/shard"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/delta"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/maps"
@@ -27,6 +31,8 @@ var _ processor.Metrics = (*deltaToCumulativeProcessor)(nil)
 type deltaToCumulativeProcessor struct {
 	next consumer.Metrics
 	cfg  Config
+	id   component.ID
+	log  *zap.Logger
 
 	last state
 	aggr data.Aggregator
@@ -36,15 +42,24 @@ type deltaToCumulativeProcessor struct {
 
 	stale *xsync.MapOf[identity.Stream, time.Time]
 	tel   telemetry.Metrics
+
+	// storage checkpoints the stream state, nil if storage is not configured.
+	storage storage.Client
+	origins *origins
+	// chunks is the number of chunks of the last checkpoint
+	chunks int
+	wg     sync.WaitGroup
 }
 
-func newProcessor(cfg *Config, tel telemetry.Metrics, next consumer.Metrics) *deltaToCumulativeProcessor {
+func newProcessor(cfg *Config, set processor.Settings, tel telemetry.Metrics, next consumer.Metrics) *deltaToCumulativeProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	limit := maps.Limit(int64(cfg.MaxStreams))
 	proc := deltaToCumulativeProcessor{
 		next: next,
 		cfg:  *cfg,
+		id:   set.ID,
+		log:  set.Logger,
 		last: state{
 			ctx:  limit,
 			nums: maps.New[identity.Stream, *mutex[pmetric.NumberDataPoint]](limit),
@@ -59,6 +74,13 @@ func newProcessor(cfg *Config, tel telemetry.Metrics, next consumer.Metrics) *de
 		tel:   tel,
 	}
 
+	// derive the shard of this replica once, instead of for every datapoint
+	proc.cfg.Sharding = cfg.Sharding.resolve()
+
+	if cfg.Storage != nil {
+		proc.origins = newOrigins()
+	}
+
 	tel.WithTracked(proc.last.Size)
 	cfg.Metrics(tel)
 
@@ -85,11 +107,19 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 		expo: guard(pmetric.NewExponentialHistogramDataPoint()),
 	}
 
+	// streams owned by other replicas, which were routed to the wrong replica
+	var misrouted int
+	var owner int
+
 	metrics.Filter(md, func(m metrics.Metric) bool {
 		if m.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
 			return keep
 		}
 
+		if p.origins != nil {
+			p.origins.track(m)
+		}
+
 		// aggregate the datapoints.
 		// using filter here, as the pmetric.*DataPoint are reference types so
 		// we can modify them using their "value".
@@ -99,6 +129,14 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 			var attrs telemetry.Attributes
 			defer func() { p.tel.Datapoints().Inc(ctx, attrs...) }()
 
+			if !p.cfg.Sharding.Owns(id) {
+				// stream is owned by another replica
+				attrs.Set(telemetry.Error("shard"))
+				misrouted++
+				owner = shard.Of(id, p.cfg.Sharding.Shards)
+				return drop
+			}
+
 			var err error
 			switch dp := dp.(type) {
 			case pmetric.NumberDataPoint:
@@ -177,6 +215,16 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 		return m.Typed().Len() > 0
 	})
 
+	if misrouted > 0 {
+		// accumulating the streams here would split their cumulative series across replicas.
+		// they are not reported to the sender, as retrying would route them here again.
+		p.log.Warn("Dropped datapoints of streams owned by other shards",
+			zap.Int("datapoints", misrouted),
+			zap.Int("shard", p.cfg.Sharding.Index),
+			zap.Int("shards", p.cfg.Sharding.Shards),
+			zap.Int("owner", owner))
+	}
+
 	// no need to continue pipeline if we dropped all metrics
 	if md.MetricCount() == 0 {
 		return nil
@@ -184,7 +232,36 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 	return p.next.ConsumeMetrics(ctx, md)
 }
 
-func (p *deltaToCumulativeProcessor) Start(_ context.Context, _ component.Host) error {
+func (p *deltaToCumulativeProcessor) Start(ctx context.Context, host component.Host) error {
+	if p.cfg.Storage != nil {
+		client, err := getStorageClient(ctx, host, p.cfg.Storage, p.id)
+		if err != nil {
+			return err
+		}
+		p.storage = client
+		if err := p.restore(ctx); err != nil {
+			return err
+		}
+
+		// checkpoint the stream state periodically, in addition to shutdown
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			tick := time.NewTicker(p.cfg.CheckpointInterval)
+			defer tick.Stop()
+			for {
+				select {
+				case <-p.ctx.Done():
+					return
+				case <-tick.C:
+					if err := p.checkpoint(p.ctx); err != nil {
+						p.log.Error("Failed to checkpoint stream state", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
 	if p.cfg.MaxStale != 0 {
 		// delete stale streams once per minute
 		go func() {
@@ -213,8 +290,12 @@ func (p *deltaToCumulativeProcessor) Start(_ context.Context, _ component.Host)
 	return nil
 }
 
-func (p *deltaToCumulativeProcessor) Shutdown(_ context.Context) error {
+func (p *deltaToCumulativeProcessor) Shutdown(ctx context.Context) error {
 	p.cancel()
+	p.wg.Wait()
+	if p.storage != nil {
+		return errors.Join(p.checkpoint(ctx), p.storage.Close(ctx))
+	}
 	return nil
 }
 
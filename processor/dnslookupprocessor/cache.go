@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// ttlCache is an LRU cache whose entries expire. Expired entries are
+// dropped when read, so that no background goroutine is needed.
+type ttlCache struct {
+	entries *lru.Cache[string, cacheEntry]
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	value   string
+	expires time.Time
+}
+
+// newTTLCache returns nil when size is zero, disabling the cache.
+func newTTLCache(size int, ttl time.Duration) (*ttlCache, error) {
+	if size == 0 {
+		return nil, nil
+	}
+	entries, err := lru.New[string, cacheEntry](size)
+	if err != nil {
+		return nil, err
+	}
+	return &ttlCache{
+		entries: entries,
+		ttl:     ttl,
+		now:     time.Now,
+	}, nil
+}
+
+func (c *ttlCache) get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		return "", false
+	}
+	if !c.now().Before(entry.expires) {
+		c.entries.Remove(key)
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache) add(key, value string) {
+	if c == nil {
+		return
+	}
+	c.addWithTTL(key, value, c.ttl)
+}
+
+// addWithTTL adds an entry expiring after ttl instead of the TTL of the cache.
+func (c *ttlCache) addWithTTL(key, value string, ttl time.Duration) {
+	if c == nil {
+		return
+	}
+	c.entries.Add(key, cacheEntry{value: value, expires: c.now().Add(ttl)})
+}
+
+// lookupCache keeps the results of lookups: the values found in the hit cache,
+// and the values for which no record exists or whose lookup failed in the miss cache.
+type lookupCache struct {
+	hits       *ttlCache
+	misses     *ttlCache
+	failureTTL time.Duration
+}
+
+func newLookupCache(cfg *Config) (*lookupCache, error) {
+	hits, err := newTTLCache(cfg.HitCacheSize, cfg.HitCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	misses, err := newTTLCache(cfg.MissCacheSize, cfg.MissCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &lookupCache{hits: hits, misses: misses, failureTTL: cfg.FailureCacheTTL}, nil
+}
+
+// get returns the cached result for the key. The result is empty when the
+// key is in the miss cache, and cached is false when the key isn't cached.
+func (c *lookupCache) get(key string) (result string, cached bool) {
+	if result, ok := c.hits.get(key); ok {
+		return result, true
+	}
+	if _, ok := c.misses.get(key); ok {
+		return "", true
+	}
+	return "", false
+}
+
+func (c *lookupCache) addHit(key, result string) {
+	c.hits.add(key, result)
+}
+
+func (c *lookupCache) addMiss(key string) {
+	c.misses.add(key, "")
+}
+
+// addFailure keeps a failed lookup in the miss cache for the failure TTL, so
+// that the value isn't looked up again for every record while it fails.
+func (c *lookupCache) addFailure(key string) {
+	c.misses.addWithTTL(key, "", c.failureTTL)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCache(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HitCacheSize = 1
+	cache, err := newLookupCache(cfg)
+	require.NoError(t, err)
+
+	now := time.Now()
+	cache.hits.now = func() time.Time { return now }
+	cache.misses.now = func() time.Time { return now }
+
+	_, cached := cache.get("app.example.test")
+	assert.False(t, cached)
+
+	cache.addHit("app.example.test", "192.0.2.10")
+	cache.addMiss("unknown.example.test")
+	cache.addFailure("failing.example.test")
+	result, cached := cache.get("app.example.test")
+	assert.True(t, cached)
+	assert.Equal(t, "192.0.2.10", result)
+	result, cached = cache.get("unknown.example.test")
+	assert.True(t, cached)
+	assert.Empty(t, result)
+	result, cached = cache.get("failing.example.test")
+	assert.True(t, cached)
+	assert.Empty(t, result)
+
+	// the least recently used entry is evicted
+	cache.addHit("db.example.test", "192.0.2.11")
+	_, cached = cache.get("app.example.test")
+	assert.False(t, cached)
+
+	// entries expire, failures before the values without record
+	now = now.Add(cfg.FailureCacheTTL)
+	_, cached = cache.get("failing.example.test")
+	assert.False(t, cached)
+	_, cached = cache.get("unknown.example.test")
+	assert.True(t, cached)
+	now = now.Add(cfg.MissCacheTTL)
+	_, cached = cache.get("unknown.example.test")
+	assert.False(t, cached)
+	_, cached = cache.get("db.example.test")
+	assert.True(t, cached)
+	now = now.Add(cfg.HitCacheTTL)
+	_, cached = cache.get("db.example.test")
+	assert.False(t, cached)
+}
+
+func TestLookupCacheDisabled(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HitCacheSize = 0
+	cfg.MissCacheSize = 0
+	cache, err := newLookupCache(cfg)
+	require.NoError(t, err)
+
+	cache.addHit("app.example.test", "192.0.2.10")
+	cache.addMiss("unknown.example.test")
+	cache.addFailure("failing.example.test")
+	_, cached := cache.get("app.example.test")
+	assert.False(t, cached)
+	_, cached = cache.get("unknown.example.test")
+	assert.False(t, cached)
+	_, cached = cache.get("failing.example.test")
+	assert.False(t, cached)
+}
@@ -3,9 +3,164 @@
 
 package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
 
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type ContextID string
+
+const (
+	resource ContextID = "resource"
+	record   ContextID = "record"
+)
+
+func (c *ContextID) UnmarshalText(text []byte) error {
+	str := ContextID(strings.ToLower(string(text)))
+	switch str {
+	case resource, record:
+		*c = str
+		return nil
+	default:
+		return fmt.Errorf("unknown context %s, available values: %s, %s", str, resource, record)
+	}
+}
+
 // Config holds the configuration for the DnsLookup processor.
-type Config struct{}
+type Config struct {
+	// Resolve configures the forward lookup, resolving hostnames to IP addresses.
+	Resolve LookupConfig `mapstructure:"resolve"`
+
+	// Reverse configures the reverse lookup, resolving IP addresses to hostnames.
+	Reverse LookupConfig `mapstructure:"reverse"`
+
+	// HitCacheSize is the maximum number of successful lookups kept in the cache. Zero disables the cache.
+	HitCacheSize int `mapstructure:"hit_cache_size"`
+
+	// HitCacheTTL is the time successful lookups are kept in the cache.
+	HitCacheTTL time.Duration `mapstructure:"hit_cache_ttl"`
+
+	// MissCacheSize is the maximum number of lookups which found no record kept in the cache. Zero disables the cache.
+	MissCacheSize int `mapstructure:"miss_cache_size"`
+
+	// MissCacheTTL is the time lookups which found no record are kept in the cache.
+	MissCacheTTL time.Duration `mapstructure:"miss_cache_ttl"`
+
+	// FailureCacheTTL is the time failed lookups are kept in the miss cache. It is short, so that a failing
+	// nameserver isn't queried for every record, while transient failures are retried soon.
+	FailureCacheTTL time.Duration `mapstructure:"failure_cache_ttl"`
+
+	// Timeout is the maximum duration of a single lookup attempt.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// BatchTimeout is the maximum total duration of the lookups of a batch of data. Once it is exceeded,
+	// the remaining values of the batch are only looked up in the cache.
+	BatchTimeout time.Duration `mapstructure:"batch_timeout"`
+
+	// MaxRetries is the number of times a failed lookup is retried. Lookups which found no record are not retried.
+	MaxRetries int `mapstructure:"max_retries"`
 
-func (*Config) Validate() error {
+	// Hostfiles are files in the hosts file format, consulted before the nameservers.
+	Hostfiles []string `mapstructure:"hostfiles"`
+
+	// Nameservers are the addresses of the DNS servers to query, in the "host[:port]" form.
+	// They are queried in order, after the hostfiles.
+	Nameservers []string `mapstructure:"nameservers"`
+
+	// EnableSystemResolver enables the resolver of the operating system, queried last.
+	EnableSystemResolver bool `mapstructure:"enable_system_resolver"`
+}
+
+// LookupConfig configures a lookup direction.
+type LookupConfig struct {
+	// Enabled enables the lookup.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Context is where the attributes are looked for. Available options: resource or record.
+	Context ContextID `mapstructure:"context"`
+
+	// Attributes are the attributes holding the value to look up. The first one present is used.
+	Attributes []string `mapstructure:"attributes"`
+
+	// ResolvedAttribute is the attribute the result of the lookup is written to.
+	ResolvedAttribute string `mapstructure:"resolved_attribute"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	var errs []error
+	if !cfg.Resolve.Enabled && !cfg.Reverse.Enabled {
+		errs = append(errs, errors.New("at least one of 'resolve' or 'reverse' must be enabled"))
+	}
+	if cfg.Resolve.Enabled {
+		if err := cfg.Resolve.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("resolve: %w", err))
+		}
+	}
+	if cfg.Reverse.Enabled {
+		if err := cfg.Reverse.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("reverse: %w", err))
+		}
+	}
+	if cfg.HitCacheSize < 0 || cfg.MissCacheSize < 0 {
+		errs = append(errs, errors.New("cache sizes must not be negative"))
+	}
+	if cfg.HitCacheSize > 0 && cfg.HitCacheTTL <= 0 {
+		errs = append(errs, errors.New("'hit_cache_ttl' must be positive when the hit cache is enabled"))
+	}
+	if cfg.MissCacheSize > 0 && cfg.MissCacheTTL <= 0 {
+		errs = append(errs, errors.New("'miss_cache_ttl' must be positive when the miss cache is enabled"))
+	}
+	if cfg.MissCacheSize > 0 && cfg.FailureCacheTTL <= 0 {
+		errs = append(errs, errors.New("'failure_cache_ttl' must be positive when the miss cache is enabled"))
+	}
+	if cfg.Timeout <= 0 {
+		errs = append(errs, errors.New("'timeout' must be positive"))
+	}
+	if cfg.BatchTimeout <= 0 {
+		errs = append(errs, errors.New("'batch_timeout' must be positive"))
+	}
+	if cfg.MaxRetries < 0 {
+		errs = append(errs, errors.New("'max_retries' must not be negative"))
+	}
+	for _, nameserver := range cfg.Nameservers {
+		if _, err := nameserverAddress(nameserver); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(cfg.Hostfiles) == 0 && len(cfg.Nameservers) == 0 && !cfg.EnableSystemResolver {
+		errs = append(errs, errors.New("at least one of 'hostfiles', 'nameservers' or 'enable_system_resolver' must be set"))
+	}
+	return errors.Join(errs...)
+}
+
+func (lc *LookupConfig) Validate() error {
+	if lc.Context != resource && lc.Context != record {
+		return fmt.Errorf("unknown context %q, available values: %s, %s", lc.Context, resource, record)
+	}
+	if len(lc.Attributes) == 0 {
+		return errors.New("'attributes' must not be empty")
+	}
+	if lc.ResolvedAttribute == "" {
+		return errors.New("'resolved_attribute' must be set")
+	}
 	return nil
 }
+
+// nameserverAddress returns the nameserver address with the default DNS port when it has none.
+func nameserverAddress(nameserver string) (string, error) {
+	if _, _, err := net.SplitHostPort(nameserver); err == nil {
+		return nameserver, nil
+	}
+	host := strings.TrimSuffix(strings.TrimPrefix(nameserver, "["), "]")
+	if host == "" {
+		return "", fmt.Errorf("invalid nameserver address %q", nameserver)
+	}
+	return net.JoinHostPort(host, "53"), nil
+}
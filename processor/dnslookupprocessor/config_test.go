@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Resolve: LookupConfig{
+					Enabled:           true,
+					Context:           record,
+					Attributes:        []string{"server.address", "net.peer.name"},
+					ResolvedAttribute: "server.ip",
+				},
+				Reverse: LookupConfig{
+					Enabled:           true,
+					Context:           resource,
+					Attributes:        []string{"client.address"},
+					ResolvedAttribute: "client.name",
+				},
+				HitCacheSize:         100,
+				HitCacheTTL:          2 * time.Minute,
+				MissCacheSize:        0,
+				MissCacheTTL:         5 * time.Second,
+				FailureCacheTTL:      time.Second,
+				Timeout:              time.Second,
+				BatchTimeout:         10 * time.Second,
+				MaxRetries:           0,
+				Hostfiles:            []string{"/etc/hosts"},
+				Nameservers:          []string{"192.0.2.53", "[2001:db8::53]:5353"},
+				EnableSystemResolver: false,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "disabled"),
+			errorMessage: "at least one of 'resolve' or 'reverse' must be enabled",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_context"),
+			errorMessage: "unknown context span, available values: resource, record",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			err = sub.Unmarshal(cfg)
+			if err == nil {
+				err = xconfmap.Validate(cfg)
+			}
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(*Config)
+		errorMessage string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:         "missing attributes",
+			modify:       func(cfg *Config) { cfg.Resolve.Attributes = nil },
+			errorMessage: "resolve: 'attributes' must not be empty",
+		},
+		{
+			name: "missing resolved attribute",
+			modify: func(cfg *Config) {
+				cfg.Reverse.Enabled = true
+				cfg.Reverse.ResolvedAttribute = ""
+			},
+			errorMessage: "reverse: 'resolved_attribute' must be set",
+		},
+		{
+			name:         "negative cache size",
+			modify:       func(cfg *Config) { cfg.MissCacheSize = -1 },
+			errorMessage: "cache sizes must not be negative",
+		},
+		{
+			name:         "missing hit cache ttl",
+			modify:       func(cfg *Config) { cfg.HitCacheTTL = 0 },
+			errorMessage: "'hit_cache_ttl' must be positive",
+		},
+		{
+			name: "disabled cache without ttl",
+			modify: func(cfg *Config) {
+				cfg.MissCacheSize = 0
+				cfg.MissCacheTTL = 0
+			},
+		},
+		{
+			name:         "missing timeout",
+			modify:       func(cfg *Config) { cfg.Timeout = 0 },
+			errorMessage: "'timeout' must be positive",
+		},
+		{
+			name:         "missing failure cache ttl",
+			modify:       func(cfg *Config) { cfg.FailureCacheTTL = 0 },
+			errorMessage: "'failure_cache_ttl' must be positive",
+		},
+		{
+			name:         "missing batch timeout",
+			modify:       func(cfg *Config) { cfg.BatchTimeout = 0 },
+			errorMessage: "'batch_timeout' must be positive",
+		},
+		{
+			name:         "negative retries",
+			modify:       func(cfg *Config) { cfg.MaxRetries = -1 },
+			errorMessage: "'max_retries' must not be negative",
+		},
+		{
+			name:         "invalid nameserver",
+			modify:       func(cfg *Config) { cfg.Nameservers = []string{"[]"} },
+			errorMessage: `invalid nameserver address "[]"`,
+		},
+		{
+			name:         "no resolver",
+			modify:       func(cfg *Config) { cfg.EnableSystemResolver = false },
+			errorMessage: "at least one of 'hostfiles', 'nameservers' or 'enable_system_resolver' must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.errorMessage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errorMessage)
+		})
+	}
+}
+
+func TestNameserverAddress(t *testing.T) {
+	for input, expected := range map[string]string{
+		"192.0.2.53":          "192.0.2.53:53",
+		"192.0.2.53:5353":     "192.0.2.53:5353",
+		"2001:db8::53":        "[2001:db8::53]:53",
+		"[2001:db8::53]":      "[2001:db8::53]:53",
+		"[2001:db8::53]:5353": "[2001:db8::53]:5353",
+		"dns.example.test":    "dns.example.test:53",
+	} {
+		address, err := nameserverAddress(input)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, address, input)
+	}
+}
@@ -5,26 +5,209 @@ package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"errors"
+	"net/netip"
 
-	"go.opentelemetry.io/collector/pdata/plog"
-	"go.opentelemetry.io/collector/pdata/pmetric"
-	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
 )
 
-type dnsLookupProcessor struct{}
+// lookup is a lookup direction: resolving hostnames, or reverse resolving IP addresses.
+type lookup struct {
+	cfg   LookupConfig
+	name  string
+	attrs metric.MeasurementOption
+	cache *lookupCache
+	// skip reports values which must not be looked up, like IP addresses when resolving.
+	skip func(value string) bool
+	fn   func(ctx context.Context, value string) ([]string, error)
+}
+
+type dnsLookupProcessor struct {
+	cfg              *Config
+	logger           *zap.Logger
+	telemetryBuilder *metadata.TelemetryBuilder
+	lookups          []*lookup
+}
+
+func newDNSLookupProcessor(cfg *Config, r resolver.Resolver, set processor.Settings) (*dnsLookupProcessor, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dnsLookupProcessor{
+		cfg:              cfg,
+		logger:           set.Logger,
+		telemetryBuilder: telemetryBuilder,
+	}
+	if cfg.Resolve.Enabled {
+		l, err := newLookup(cfg, cfg.Resolve, "resolve", isIP, r.Resolve)
+		if err != nil {
+			return nil, err
+		}
+		p.lookups = append(p.lookups, l)
+	}
+	if cfg.Reverse.Enabled {
+		l, err := newLookup(cfg, cfg.Reverse, "reverse", func(value string) bool { return !isIP(value) }, r.Reverse)
+		if err != nil {
+			return nil, err
+		}
+		p.lookups = append(p.lookups, l)
+	}
+	return p, nil
+}
+
+func newLookup(cfg *Config, lc LookupConfig, name string, skip func(string) bool, fn func(context.Context, string) ([]string, error)) (*lookup, error) {
+	cache, err := newLookupCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &lookup{
+		cfg:   lc,
+		name:  name,
+		attrs: metric.WithAttributeSet(attribute.NewSet(attribute.String("lookup", name))),
+		cache: cache,
+		skip:  skip,
+		fn:    fn,
+	}, nil
+}
+
+// newResolver chains the configured resolvers: hostfiles first, then the nameservers and the system resolver.
+func newResolver(cfg *Config) (resolver.Resolver, error) {
+	var resolvers []resolver.Resolver
+	if len(cfg.Hostfiles) > 0 {
+		hostfiles, err := resolver.NewHostFileResolver(cfg.Hostfiles)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, hostfiles)
+	}
+	for _, nameserver := range cfg.Nameservers {
+		address, err := nameserverAddress(nameserver)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, resolver.NewNameserverResolver(address))
+	}
+	if cfg.EnableSystemResolver {
+		resolvers = append(resolvers, resolver.NewSystemResolver())
+	}
+	return resolver.NewChainResolver(resolvers...), nil
+}
 
-func newDNSLookupProcessor() *dnsLookupProcessor {
-	return &dnsLookupProcessor{}
+func isIP(value string) bool {
+	_, err := netip.ParseAddr(value)
+	return err == nil
 }
 
-func (*dnsLookupProcessor) processMetrics(_ context.Context, ms pmetric.Metrics) (pmetric.Metrics, error) {
-	return ms, nil
+// hasContext reports whether a lookup is configured for the given context.
+func (p *dnsLookupProcessor) hasContext(contextID ContextID) bool {
+	for _, l := range p.lookups {
+		if l.cfg.Context == contextID {
+			return true
+		}
+	}
+	return false
 }
 
-func (*dnsLookupProcessor) processTraces(_ context.Context, ts ptrace.Traces) (ptrace.Traces, error) {
-	return ts, nil
+// batchContext bounds the total duration of the lookups of a batch of data.
+func (p *dnsLookupProcessor) batchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, p.cfg.BatchTimeout)
+}
+
+// processAttributes runs the lookups configured for the context on the attributes.
+func (p *dnsLookupProcessor) processAttributes(ctx context.Context, contextID ContextID, attrs pcommon.Map) {
+	for _, l := range p.lookups {
+		if l.cfg.Context != contextID {
+			continue
+		}
+		value, ok := sourceValue(l.cfg.Attributes, attrs)
+		if !ok || l.skip(value) {
+			continue
+		}
+		if result, ok := p.lookup(ctx, l, value); ok {
+			putResult(attrs, l.cfg.ResolvedAttribute, result)
+		}
+	}
+}
+
+// sourceValue returns the value of the first attribute present. The first
+// element is used for string slices, like host.ip.
+func sourceValue(keys []string, attrs pcommon.Map) (string, bool) {
+	for _, key := range keys {
+		v, ok := attrs.Get(key)
+		if !ok {
+			continue
+		}
+		if v.Type() == pcommon.ValueTypeSlice && v.Slice().Len() > 0 {
+			v = v.Slice().At(0)
+		}
+		if v.Type() == pcommon.ValueTypeStr && v.Str() != "" {
+			return v.Str(), true
+		}
+	}
+	return "", false
+}
+
+// putResult writes the result of a lookup to the attribute. host.ip is a
+// string slice in the semantic conventions, other attributes are strings.
+func putResult(attrs pcommon.Map, key, result string) {
+	if key == string(semconv.HostIPKey) {
+		attrs.PutEmptySlice(key).AppendEmpty().SetStr(result)
+		return
+	}
+	attrs.PutStr(key, result)
+}
+
+// lookup returns the first result of the lookup of value, from the cache when possible.
+// Once the context of the batch is done, values are only looked up in the cache.
+func (p *dnsLookupProcessor) lookup(ctx context.Context, l *lookup, value string) (string, bool) {
+	if result, cached := l.cache.get(value); cached {
+		p.telemetryBuilder.ProcessorDnslookupCacheHits.Add(ctx, 1, l.attrs)
+		return result, result != ""
+	}
+	p.telemetryBuilder.ProcessorDnslookupCacheMisses.Add(ctx, 1, l.attrs)
+	if ctx.Err() != nil {
+		return "", false
+	}
+
+	var results []string
+	var err error
+	for attempt := 0; attempt <= p.cfg.MaxRetries; attempt++ {
+		lookupCtx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+		results, err = l.fn(lookupCtx, value)
+		cancel()
+		if err == nil || errors.Is(err, resolver.ErrNotFound) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	switch {
+	case err == nil && len(results) > 0:
+		l.cache.addHit(value, results[0])
+		return results[0], true
+	case err == nil || errors.Is(err, resolver.ErrNotFound):
+		l.cache.addMiss(value)
+	default:
+		// lookups interrupted by the batch timeout didn't fail on their own, and aren't cached
+		if ctx.Err() == nil {
+			l.cache.addFailure(value)
+		}
+		p.telemetryBuilder.ProcessorDnslookupLookupFailures.Add(ctx, 1, l.attrs)
+		p.logger.Debug("DNS lookup failed", zap.String("lookup", l.name), zap.String("value", value), zap.Error(err))
+	}
+	return "", false
 }
 
-func (*dnsLookupProcessor) processLogs(_ context.Context, ls plog.Logs) (plog.Logs, error) {
-	return ls, nil
+func (p *dnsLookupProcessor) shutdown(context.Context) error {
+	p.telemetryBuilder.Shutdown()
+	return nil
 }
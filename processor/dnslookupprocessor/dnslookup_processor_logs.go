@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func (p *dnsLookupProcessor) processLogs(ctx context.Context, ls plog.Logs) (plog.Logs, error) {
+	ctx, cancel := p.batchContext(ctx)
+	defer cancel()
+
+	processResource, processRecord := p.hasContext(resource), p.hasContext(record)
+	rl := ls.ResourceLogs()
+	for i := 0; i < rl.Len(); i++ {
+		if processResource {
+			p.processAttributes(ctx, resource, rl.At(i).Resource().Attributes())
+		}
+		if processRecord {
+			for j := 0; j < rl.At(i).ScopeLogs().Len(); j++ {
+				for k := 0; k < rl.At(i).ScopeLogs().At(j).LogRecords().Len(); k++ {
+					p.processAttributes(ctx, record, rl.At(i).ScopeLogs().At(j).LogRecords().At(k).Attributes())
+				}
+			}
+		}
+	}
+	return ls, nil
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func (p *dnsLookupProcessor) processMetrics(ctx context.Context, ms pmetric.Metrics) (pmetric.Metrics, error) {
+	ctx, cancel := p.batchContext(ctx)
+	defer cancel()
+
+	processResource, processRecord := p.hasContext(resource), p.hasContext(record)
+	rm := ms.ResourceMetrics()
+	for i := 0; i < rm.Len(); i++ {
+		if processResource {
+			p.processAttributes(ctx, resource, rm.At(i).Resource().Attributes())
+		}
+		if processRecord {
+			for j := 0; j < rm.At(i).ScopeMetrics().Len(); j++ {
+				for k := 0; k < rm.At(i).ScopeMetrics().At(j).Metrics().Len(); k++ {
+					p.processMetricAttributes(ctx, rm.At(i).ScopeMetrics().At(j).Metrics().At(k))
+				}
+			}
+		}
+	}
+	return ms, nil
+}
+
+func (p *dnsLookupProcessor) processMetricAttributes(ctx context.Context, m pmetric.Metric) {
+	// This is a lot of repeated code, but since there is no single parent superclass
+	// between metric data types, we can't use polymorphism.
+	//exhaustive:enforce
+
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.processAttributes(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.processAttributes(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.processAttributes(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.processAttributes(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.processAttributes(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+)
+
+var errTemporary = errors.New("temporary failure")
+
+// fakeResolver answers from maps, and fails the first failures calls. When
+// blocking, resolving waits for the context to be done.
+type fakeResolver struct {
+	addresses map[string][]string
+	hostnames map[string][]string
+	failures  int
+	calls     int
+	blocking  bool
+}
+
+func (f *fakeResolver) Resolve(ctx context.Context, hostname string) ([]string, error) {
+	if f.blocking {
+		f.calls++
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return f.answer(f.addresses, hostname)
+}
+
+func (f *fakeResolver) Reverse(_ context.Context, ip string) ([]string, error) {
+	return f.answer(f.hostnames, ip)
+}
+
+func (f *fakeResolver) answer(records map[string][]string, value string) ([]string, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errTemporary
+	}
+	if results, ok := records[value]; ok {
+		return results, nil
+	}
+	return nil, resolver.ErrNotFound
+}
+
+func newTestResolver() *fakeResolver {
+	return &fakeResolver{
+		addresses: map[string][]string{"app.example.test": {"192.0.2.10", "192.0.2.20"}},
+		hostnames: map[string][]string{"192.0.2.11": {"db.example.test"}},
+	}
+}
+
+func TestProcessLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Resolve.Context = record
+	cfg.Resolve.Attributes = []string{"server.address", "peer.name"}
+	cfg.Resolve.ResolvedAttribute = "server.ip"
+	cfg.Reverse.Enabled = true
+	cfg.Reverse.Attributes = []string{"client.address"}
+	cfg.Reverse.ResolvedAttribute = "client.name"
+
+	r := newTestResolver()
+	p, err := newDNSLookupProcessor(cfg, r, processortest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("client.address", "192.0.2.11")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Attributes().PutStr("peer.name", "app.example.test")
+	records.AppendEmpty().Attributes().PutStr("server.address", "unknown.example.test")
+	records.AppendEmpty().Attributes().PutStr("server.address", "192.0.2.99")
+	records.AppendEmpty().Attributes().PutStr("server.address", "app.example.test")
+
+	logs, err = p.processLogs(t.Context(), logs)
+	require.NoError(t, err)
+
+	name, ok := rl.Resource().Attributes().Get("client.name")
+	require.True(t, ok)
+	assert.Equal(t, "db.example.test", name.Str())
+
+	ip, ok := records.At(0).Attributes().Get("server.ip")
+	require.True(t, ok)
+	assert.Equal(t, "192.0.2.10", ip.Str())
+	_, ok = records.At(1).Attributes().Get("server.ip")
+	assert.False(t, ok, "no record found")
+	_, ok = records.At(2).Attributes().Get("server.ip")
+	assert.False(t, ok, "IP addresses are not resolved")
+	ip, ok = records.At(3).Attributes().Get("server.ip")
+	require.True(t, ok)
+	assert.Equal(t, "192.0.2.10", ip.Str())
+
+	// the second lookup of app.example.test is answered from the cache
+	assert.Equal(t, 3, r.calls)
+	assert.Equal(t, 1, logs.ResourceLogs().Len())
+	require.NoError(t, p.shutdown(t.Context()))
+}
+
+func TestProcessTraces(t *testing.T) {
+	p, err := newDNSLookupProcessor(createDefaultConfig().(*Config), newTestResolver(), processortest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("host.name", "app.example.test")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().PutStr("host.name", "app.example.test")
+
+	_, err = p.processTraces(t.Context(), traces)
+	require.NoError(t, err)
+
+	ip, ok := rs.Resource().Attributes().Get("host.ip")
+	require.True(t, ok)
+	assert.Equal(t, []any{"192.0.2.10"}, ip.Slice().AsRaw(), "host.ip is a string slice")
+	_, ok = rs.ScopeSpans().At(0).Spans().At(0).Attributes().Get("host.ip")
+	assert.False(t, ok, "only the resource context is configured")
+	require.NoError(t, p.shutdown(t.Context()))
+}
+
+func TestProcessMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Resolve.Enabled = false
+	cfg.Reverse.Enabled = true
+	cfg.Reverse.Context = record
+	p, err := newDNSLookupProcessor(cfg, newTestResolver(), processortest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("host.ip", "192.0.2.11")
+	ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("host.ip", "192.0.2.11")
+	ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("host.ip", "192.0.2.11")
+	ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes().PutStr("host.ip", "192.0.2.11")
+	ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().Attributes().PutEmptySlice("host.ip").FromRaw([]any{"192.0.2.11", "192.0.2.12"})
+
+	_, err = p.processMetrics(t.Context(), metrics)
+	require.NoError(t, err)
+
+	assert.Equal(t, "db.example.test", ms.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw()["host.name"])
+	assert.Equal(t, "db.example.test", ms.At(1).Sum().DataPoints().At(0).Attributes().AsRaw()["host.name"])
+	assert.Equal(t, "db.example.test", ms.At(2).Histogram().DataPoints().At(0).Attributes().AsRaw()["host.name"])
+	assert.Equal(t, "db.example.test", ms.At(3).ExponentialHistogram().DataPoints().At(0).Attributes().AsRaw()["host.name"])
+	assert.Equal(t, "db.example.test", ms.At(4).Summary().DataPoints().At(0).Attributes().AsRaw()["host.name"])
+	require.NoError(t, p.shutdown(t.Context()))
+}
+
+func TestLookupRetriesAndTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(t.Context())) })
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxRetries = 1
+	r := newTestResolver()
+	p, err := newDNSLookupProcessor(cfg, r, metadatatest.NewSettings(tel))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.shutdown(t.Context()))
+	}()
+	l := p.lookups[0]
+
+	// the first attempt fails, the retry succeeds
+	r.failures = 1
+	result, ok := p.lookup(t.Context(), l, "app.example.test")
+	assert.True(t, ok)
+	assert.Equal(t, "192.0.2.10", result)
+	assert.Equal(t, 2, r.calls)
+
+	// cached
+	_, ok = p.lookup(t.Context(), l, "app.example.test")
+	assert.True(t, ok)
+	assert.Equal(t, 2, r.calls)
+
+	// not found lookups are cached in the miss cache, and not retried
+	_, ok = p.lookup(t.Context(), l, "unknown.example.test")
+	assert.False(t, ok)
+	_, ok = p.lookup(t.Context(), l, "unknown.example.test")
+	assert.False(t, ok)
+	assert.Equal(t, 3, r.calls)
+
+	// failures aren't retried beyond max_retries, and are cached for the failure TTL only
+	now := time.Now()
+	l.cache.misses.now = func() time.Time { return now }
+	r.calls, r.failures = 0, 4
+	_, ok = p.lookup(t.Context(), l, "other.example.test")
+	assert.False(t, ok)
+	assert.Equal(t, 2, r.calls)
+	_, ok = p.lookup(t.Context(), l, "other.example.test")
+	assert.False(t, ok)
+	assert.Equal(t, 2, r.calls)
+	now = now.Add(cfg.FailureCacheTTL)
+	_, ok = p.lookup(t.Context(), l, "other.example.test")
+	assert.False(t, ok)
+	assert.Equal(t, 4, r.calls)
+
+	lookupAttr := attribute.NewSet(attribute.String("lookup", "resolve"))
+	metadatatest.AssertEqualProcessorDnslookupCacheHits(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 3, Attributes: lookupAttr}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorDnslookupCacheMisses(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 4, Attributes: lookupAttr}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorDnslookupLookupFailures(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2, Attributes: lookupAttr}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestBatchTimeout(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Resolve.Context = record
+	cfg.Timeout = time.Minute
+	cfg.BatchTimeout = 20 * time.Millisecond
+	r := &fakeResolver{blocking: true}
+	p, err := newDNSLookupProcessor(cfg, r, processortest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.shutdown(t.Context()))
+	}()
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Attributes().PutStr("host.name", "app.example.test")
+	records.AppendEmpty().Attributes().PutStr("host.name", "db.example.test")
+	records.AppendEmpty().Attributes().PutStr("host.name", "web.example.test")
+
+	// the first lookup is interrupted by the batch timeout, the others aren't attempted
+	_, err = p.processLogs(t.Context(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, 1, r.calls)
+	for i := 0; i < records.Len(); i++ {
+		_, ok := records.At(i).Attributes().Get("host.ip")
+		assert.False(t, ok)
+	}
+
+	// the interrupted lookup isn't cached as a failure
+	_, cached := p.lookups[0].cache.get("app.example.test")
+	assert.False(t, cached)
+}
+
+func TestCreateWithHostfile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Hostfiles = []string{filepath.Join("testdata", "hosts")}
+	cfg.EnableSystemResolver = false
+	p, err := createDNSLookupProcessor(cfg, processortest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+
+	result, ok := p.lookup(t.Context(), p.lookups[0], "db.example.test")
+	assert.True(t, ok)
+	assert.Equal(t, "192.0.2.11", result)
+	require.NoError(t, p.shutdown(t.Context()))
+
+	cfg.Hostfiles = []string{filepath.Join("testdata", "missing")}
+	_, err = createDNSLookupProcessor(cfg, processortest.NewNopSettings(metadata.Type))
+	assert.ErrorContains(t, err, "failed to load hostfile")
+}
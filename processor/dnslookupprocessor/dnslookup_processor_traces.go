@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func (p *dnsLookupProcessor) processTraces(ctx context.Context, ts ptrace.Traces) (ptrace.Traces, error) {
+	ctx, cancel := p.batchContext(ctx)
+	defer cancel()
+
+	processResource, processRecord := p.hasContext(resource), p.hasContext(record)
+	rs := ts.ResourceSpans()
+	for i := 0; i < rs.Len(); i++ {
+		if processResource {
+			p.processAttributes(ctx, resource, rs.At(i).Resource().Attributes())
+		}
+		if processRecord {
+			for j := 0; j < rs.At(i).ScopeSpans().Len(); j++ {
+				for k := 0; k < rs.At(i).ScopeSpans().At(j).Spans().Len(); k++ {
+					p.processAttributes(ctx, record, rs.At(i).ScopeSpans().At(j).Spans().At(k).Attributes())
+				}
+			}
+		}
+	}
+	return ts, nil
+}
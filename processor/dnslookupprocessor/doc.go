@@ -3,5 +3,6 @@
 
 //go:generate mdatagen metadata.yaml
 
-// Package dnslookupprocessor
+// Package dnslookupprocessor resolves hostnames to IP addresses and IP addresses
+// to hostnames, from source attributes into target attributes.
 package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+)
+
+// HostFileResolver answers lookups from files in the hosts file format.
+// The files are read once, when the resolver is created.
+type HostFileResolver struct {
+	hostnames map[string][]string
+	addresses map[string][]string
+}
+
+var _ Resolver = (*HostFileResolver)(nil)
+
+func NewHostFileResolver(paths []string) (*HostFileResolver, error) {
+	r := &HostFileResolver{
+		hostnames: map[string][]string{},
+		addresses: map[string][]string{},
+	}
+	for _, path := range paths {
+		if err := r.load(path); err != nil {
+			return nil, fmt.Errorf("failed to load hostfile %q: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+func (r *HostFileResolver) load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		addr, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			continue
+		}
+		ip := addr.WithZone("").String()
+		for _, name := range fields[1:] {
+			name = normalizeHostname(name)
+			r.addresses[name] = appendUnique(r.addresses[name], ip)
+			r.hostnames[ip] = appendUnique(r.hostnames[ip], name)
+		}
+	}
+	return scanner.Err()
+}
+
+func (r *HostFileResolver) Resolve(_ context.Context, hostname string) ([]string, error) {
+	if addresses, ok := r.addresses[normalizeHostname(hostname)]; ok {
+		return addresses, nil
+	}
+	return nil, ErrNotFound
+}
+
+func (r *HostFileResolver) Reverse(_ context.Context, ip string) ([]string, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if hostnames, ok := r.hostnames[addr.WithZone("").String()]; ok {
+		return hostnames, nil
+	}
+	return nil, ErrNotFound
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.ToLower(strings.TrimSuffix(hostname, "."))
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
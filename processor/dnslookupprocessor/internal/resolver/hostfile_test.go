@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostFileResolver(t *testing.T) {
+	r, err := NewHostFileResolver([]string{filepath.Join("..", "..", "testdata", "hosts")})
+	require.NoError(t, err)
+
+	addresses, err := r.Resolve(t.Context(), "App.Example.Test.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.10", "2001:db8::10"}, addresses)
+
+	addresses, err = r.Resolve(t.Context(), "db.example.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.11"}, addresses)
+
+	_, err = r.Resolve(t.Context(), "database")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	hostnames, err := r.Reverse(t.Context(), "192.0.2.10")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.example.test", "app"}, hostnames)
+
+	hostnames, err = r.Reverse(t.Context(), "2001:0db8::0010")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.example.test"}, hostnames)
+
+	_, err = r.Reverse(t.Context(), "192.0.2.99")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewHostFileResolver([]string{filepath.Join("..", "..", "testdata", "missing")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// NetResolver answers lookups with a net.Resolver, either the system resolver
+// or one querying a given nameserver.
+type NetResolver struct {
+	resolver *net.Resolver
+}
+
+var _ Resolver = (*NetResolver)(nil)
+
+// NewNameserverResolver returns a resolver querying the nameserver at the given "host:port" address.
+func NewNameserverResolver(address string) *NetResolver {
+	return &NetResolver{
+		resolver: &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, address)
+			},
+		},
+	}
+}
+
+// NewSystemResolver returns a resolver using the configuration of the operating system.
+func NewSystemResolver() *NetResolver {
+	return &NetResolver{resolver: net.DefaultResolver}
+}
+
+func (r *NetResolver) Resolve(ctx context.Context, hostname string) ([]string, error) {
+	addresses, err := r.resolver.LookupHost(ctx, hostname)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return addresses, nil
+}
+
+func (r *NetResolver) Reverse(ctx context.Context, ip string) ([]string, error) {
+	names, err := r.resolver.LookupAddr(ctx, ip)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	hostnames := make([]string, 0, len(names))
+	for _, name := range names {
+		hostnames = append(hostnames, strings.TrimSuffix(name, "."))
+	}
+	return hostnames, nil
+}
+
+func translateError(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return ErrNotFound
+	}
+	return err
+}
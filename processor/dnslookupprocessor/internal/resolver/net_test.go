@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// startDNSServer starts a DNS server on a local UDP port, answering A and PTR
+// queries from the given records. It returns the address of the server.
+func startDNSServer(t *testing.T, a, ptr map[string]string) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		_ = conn.Close()
+		<-done
+	})
+
+	go func() {
+		defer close(done)
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp, err := answer(buf[:n], a, ptr); err == nil {
+				_, _ = conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func answer(query []byte, a, ptr map[string]string) ([]byte, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(query); err != nil {
+		return nil, err
+	}
+	msg.Header.Response = true
+	msg.Header.Authoritative = true
+	msg.Header.RCode = dnsmessage.RCodeNameError
+	if len(msg.Questions) != 1 {
+		return msg.Pack()
+	}
+
+	q := msg.Questions[0]
+	name := strings.ToLower(q.Name.String())
+	header := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: 60}
+	switch {
+	case q.Type == dnsmessage.TypeA && a[name] != "":
+		msg.Header.RCode = dnsmessage.RCodeSuccess
+		ip := net.ParseIP(a[name]).To4()
+		msg.Answers = append(msg.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.AResource{A: [4]byte(ip)}})
+	case q.Type == dnsmessage.TypeAAAA && a[name] != "":
+		// the name exists, without IPv6 address
+		msg.Header.RCode = dnsmessage.RCodeSuccess
+	case q.Type == dnsmessage.TypePTR && ptr[name] != "":
+		msg.Header.RCode = dnsmessage.RCodeSuccess
+		target, err := dnsmessage.NewName(ptr[name])
+		if err != nil {
+			return nil, err
+		}
+		msg.Answers = append(msg.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.PTRResource{PTR: target}})
+	}
+	return msg.Pack()
+}
+
+func TestNameserverResolver(t *testing.T) {
+	address := startDNSServer(t,
+		map[string]string{"app.example.test.": "192.0.2.10"},
+		map[string]string{"10.2.0.192.in-addr.arpa.": "app.example.test."},
+	)
+	r := NewNameserverResolver(address)
+
+	addresses, err := r.Resolve(t.Context(), "app.example.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.10"}, addresses)
+
+	_, err = r.Resolve(t.Context(), "unknown.example.test")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	hostnames, err := r.Reverse(t.Context(), "192.0.2.10")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.example.test"}, hostnames)
+
+	_, err = r.Reverse(t.Context(), "192.0.2.99")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when no record exists for the looked up value.
+var ErrNotFound = errors.New("no DNS record found")
+
+// Resolver looks up hostnames and IP addresses.
+type Resolver interface {
+	// Resolve returns the IP addresses of a hostname.
+	Resolve(ctx context.Context, hostname string) ([]string, error)
+
+	// Reverse returns the hostnames of an IP address.
+	Reverse(ctx context.Context, ip string) ([]string, error)
+}
+
+// ChainResolver queries its resolvers in order, returning the first answer found.
+type ChainResolver struct {
+	resolvers []Resolver
+}
+
+var _ Resolver = (*ChainResolver)(nil)
+
+func NewChainResolver(resolvers ...Resolver) *ChainResolver {
+	return &ChainResolver{resolvers: resolvers}
+}
+
+func (c *ChainResolver) Resolve(ctx context.Context, hostname string) ([]string, error) {
+	return c.lookup(ctx, func(r Resolver) ([]string, error) {
+		return r.Resolve(ctx, hostname)
+	})
+}
+
+func (c *ChainResolver) Reverse(ctx context.Context, ip string) ([]string, error) {
+	return c.lookup(ctx, func(r Resolver) ([]string, error) {
+		return r.Reverse(ctx, ip)
+	})
+}
+
+// lookup returns ErrNotFound only when every resolver found no record, so that
+// a transient failure of one of them isn't mistaken for a missing record.
+func (c *ChainResolver) lookup(ctx context.Context, fn func(Resolver) ([]string, error)) ([]string, error) {
+	var errs []error
+	for _, r := range c.resolvers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		results, err := fn(r)
+		if err == nil && len(results) > 0 {
+			return results, nil
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return nil, ErrNotFound
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticResolver struct {
+	results []string
+	err     error
+}
+
+func (s staticResolver) Resolve(context.Context, string) ([]string, error) {
+	return s.results, s.err
+}
+
+func (s staticResolver) Reverse(context.Context, string) ([]string, error) {
+	return s.results, s.err
+}
+
+func TestChainResolver(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	notFound := staticResolver{err: ErrNotFound}
+	failing := staticResolver{err: errTimeout}
+	found := staticResolver{results: []string{"192.0.2.10"}}
+
+	results, err := NewChainResolver(notFound, failing, found).Resolve(t.Context(), "app.example.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.10"}, results)
+
+	_, err = NewChainResolver(notFound, notFound).Reverse(t.Context(), "192.0.2.10")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewChainResolver(failing, notFound).Resolve(t.Context(), "app.example.test")
+	assert.ErrorIs(t, err, errTimeout)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = NewChainResolver(found).Resolve(ctx, "app.example.test")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -18,6 +18,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+	ip2location "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -76,6 +78,19 @@ func TestLoadConfig(t *testing.T) {
 				Attributes: []attribute.Key{"client.address", "source.address", "custom.address"},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "multiple_providers"),
+			expected: &Config{
+				Context: resource,
+				Providers: map[string]provider.Config{
+					"maxmind":     &maxmind.Config{DatabasePath: "/tmp/GeoLite2-City.mmdb"},
+					"maxmind/asn": &maxmind.Config{DatabasePath: "/tmp/GeoLite2-ASN.mmdb"},
+					"ip2location": &ip2location.Config{DatabasePath: "/tmp/IP2LOCATION-LITE-DB11.BIN"},
+					"csv/sites":   &csvprovider.Config{DatabasePath: "/tmp/sites.csv"},
+				},
+				Attributes: defaultAttributes,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -6,6 +6,7 @@ package geoipprocessor // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -16,6 +17,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+	ip2location "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -33,7 +36,9 @@ var (
 
 // providerFactories is a map that stores GeoIPProviderFactory instances, keyed by the provider type.
 var providerFactories = map[string]provider.GeoIPProviderFactory{
-	maxmind.TypeStr: &maxmind.Factory{},
+	maxmind.TypeStr:     &maxmind.Factory{},
+	ip2location.TypeStr: &ip2location.Factory{},
+	csvprovider.TypeStr: &csvprovider.Factory{},
 }
 
 // NewFactory creates a new processor factory with default configuration,
@@ -42,10 +47,18 @@ func NewFactory() processor.Factory {
 	return processor.NewFactory(metadata.Type, createDefaultConfig, processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability), processor.WithLogs(createLogsProcessor, metadata.LogsStability), processor.WithTraces(createTracesProcessor, metadata.TracesStability))
 }
 
+// providerType returns the provider type of a providers key. Keys are either the provider
+// type or the type followed by a name, e.g. "maxmind/asn", so that a provider type can be
+// configured several times.
+func providerType(key string) string {
+	providerType, _, _ := strings.Cut(key, "/")
+	return providerType
+}
+
 // getProviderFactory retrieves the GeoIPProviderFactory for the given key.
 // It returns the factory and a boolean indicating whether the factory was found.
 func getProviderFactory(key string) (provider.GeoIPProviderFactory, bool) {
-	if factory, ok := providerFactories[key]; ok {
+	if factory, ok := providerFactories[providerType(key)]; ok {
 		return factory, true
 	}
 
@@ -70,13 +83,17 @@ func createGeoIPProviders(
 	providers := make([]provider.GeoIPProvider, 0, len(config.Providers))
 
 	for key, cfg := range config.Providers {
-		factory := factories[key]
+		factory := factories[providerType(key)]
 		if factory == nil {
 			return nil, fmt.Errorf("geoIP provider factory not found for key: %q", key)
 		}
 
 		provider, err := factory.CreateGeoIPProvider(ctx, set, cfg)
 		if err != nil {
+			// providers watch their database files until they are closed
+			for _, created := range providers {
+				_ = created.Close(ctx)
+			}
 			return nil, fmt.Errorf("failed to create provider for key %q: %w", key, err)
 		}
 
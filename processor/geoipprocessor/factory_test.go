@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor"
@@ -87,3 +88,21 @@ func TestCreateProcessor_FailedProvider(t *testing.T) {
 	_, err := factory.CreateMetrics(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
 	assert.EqualError(t, err, fmt.Errorf("failed to create provider for key %q: %w", providerKey, errors.New("error creating provider")).Error())
 }
+
+func TestCreateProcessor_NamedProviderKey(t *testing.T) {
+	var created []provider.Config
+	baseMockFactory.CreateGeoIPProviderF = func(_ context.Context, _ processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+		created = append(created, cfg)
+		return &baseMockProvider, nil
+	}
+	providerFactories["mock"] = &baseMockFactory
+
+	cityConfig := &providerConfigMock{}
+	asnConfig := &providerConfigMock{}
+	cfg := &Config{Providers: map[string]provider.Config{"mock": cityConfig, "mock/asn": asnConfig}}
+
+	mp, err := NewFactory().CreateMetrics(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NotNil(t, mp)
+	assert.ElementsMatch(t, []provider.Config{cityConfig, asnConfig}, created)
+}
@@ -38,4 +38,10 @@ const (
 
 	// AttributeGeoLocationLon represents the attribute name for the longitude.
 	AttributeGeoLocationLon = string(semconv.GeoLocationLonKey)
+
+	// AttributeASNumber represents the attribute name for the autonomous system number.
+	AttributeASNumber = "as.number"
+
+	// AttributeASOrganizationName represents the attribute name for the organization name of the autonomous system.
+	AttributeASOrganizationName = "as.organization.name"
 )
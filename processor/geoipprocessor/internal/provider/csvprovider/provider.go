@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// networkColumn is the name of the first column of the file, which holds the
+// networks in CIDR notation or single IP addresses.
+const networkColumn = "network"
+
+// networks holds the attributes of the networks of one IP address family.
+type networks struct {
+	// prefixLengths lists the prefix lengths present in the file, longest first.
+	prefixLengths []int
+	attributes    map[netip.Prefix]attribute.Set
+}
+
+func (n *networks) add(prefix netip.Prefix, attributes attribute.Set) {
+	if n.attributes == nil {
+		n.attributes = map[netip.Prefix]attribute.Set{}
+	}
+	n.attributes[prefix] = attributes
+	if !slices.Contains(n.prefixLengths, prefix.Bits()) {
+		n.prefixLengths = append(n.prefixLengths, prefix.Bits())
+		slices.SortFunc(n.prefixLengths, func(a, b int) int { return b - a })
+	}
+}
+
+// lookup returns the attributes of the most specific network containing the address.
+func (n *networks) lookup(addr netip.Addr) (attribute.Set, bool) {
+	for _, bits := range n.prefixLengths {
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			continue
+		}
+		if attributes, ok := n.attributes[prefix]; ok {
+			return attributes, true
+		}
+	}
+	return attribute.Set{}, false
+}
+
+type table struct {
+	ipv4 networks
+	ipv6 networks
+}
+
+type csvProvider struct {
+	table   atomic.Pointer[table]
+	path    string
+	watcher *filewatcher.Watcher
+}
+
+var _ provider.GeoIPProvider = (*csvProvider)(nil)
+
+func newCSVProvider(cfg *Config, logger *zap.Logger) (*csvProvider, error) {
+	p := &csvProvider{path: cfg.DatabasePath}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	p.watcher, err = filewatcher.New(cfg.DatabasePath, logger, p.reload)
+	if err != nil {
+		return nil, fmt.Errorf("could not watch CSV file: %w", err)
+	}
+	return p, nil
+}
+
+// reload reads the file and replaces the current networks once it has been parsed successfully.
+func (p *csvProvider) reload() error {
+	f, err := os.Open(p.path)
+	if err != nil {
+		return fmt.Errorf("could not open CSV file: %w", err)
+	}
+	defer f.Close()
+
+	t, err := parseTable(f)
+	if err != nil {
+		return fmt.Errorf("could not parse CSV file %q: %w", p.path, err)
+	}
+	p.table.Store(t)
+	return nil
+}
+
+// parseTable reads a CSV file whose header names the attributes to add, for example:
+//
+//	network,site.name,network.zone
+//	10.1.0.0/16,datacenter-1,internal
+func parseTable(r io.Reader) (*table, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing header")
+	} else if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(header[0]) != networkColumn {
+		return nil, fmt.Errorf("the first column must be %q, got %q", networkColumn, header[0])
+	}
+	if len(header) < 2 {
+		return nil, errors.New("at least one attribute column must be defined")
+	}
+	keys := make([]string, 0, len(header)-1)
+	for _, key := range header[1:] {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, errors.New("attribute columns must have a name")
+		}
+		keys = append(keys, key)
+	}
+
+	t := &table{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return t, nil
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		prefix, err := parseNetwork(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		family := &t.ipv6
+		if prefix.Addr().Is4() {
+			family = &t.ipv4
+		}
+		if _, ok := family.attributes[prefix]; ok {
+			return nil, fmt.Errorf("line %d: duplicate network %s", line, prefix)
+		}
+
+		attributes := make([]attribute.KeyValue, 0, len(keys))
+		for i, value := range record[1:] {
+			if value = strings.TrimSpace(value); value != "" {
+				attributes = append(attributes, attribute.String(keys[i], value))
+			}
+		}
+		family.add(prefix, attribute.NewSet(attributes...))
+	}
+}
+
+// parseNetwork parses a network in CIDR notation or a single IP address.
+func parseNetwork(network string) (netip.Prefix, error) {
+	if strings.Contains(network, "/") {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(network)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Location implements provider.GeoIPProvider for CSV files. If the IP isn't part of any network of the file, an error will be returned.
+func (p *csvProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	addr, ok := netip.AddrFromSlice(ipAddress)
+	if !ok {
+		return attribute.Set{}, fmt.Errorf("invalid IP address: %v", ipAddress)
+	}
+	addr = addr.Unmap()
+
+	t := p.table.Load()
+	family := &t.ipv6
+	if addr.Is4() {
+		family = &t.ipv4
+	}
+	attributes, found := family.lookup(addr)
+	if !found || attributes.Len() == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attributes, nil
+}
+
+// Close stops watching the CSV file.
+func (p *csvProvider) Close(context.Context) error {
+	return p.watcher.Close()
+}
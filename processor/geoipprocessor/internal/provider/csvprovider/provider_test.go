@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newCSVProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open CSV file")
+}
+
+// TestProviderLocation asserts that the CSV provider adds the attributes of the most specific network given an IP.
+func TestProviderLocation(t *testing.T) {
+	provider, err := newCSVProvider(&Config{DatabasePath: filepath.Join("testdata", "networks.csv")}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(t.Context()))
+	}()
+
+	tests := []struct {
+		name               string
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:           "nil IP address",
+			expectedErrMsg: "invalid IP address: <nil>",
+		},
+		{
+			name:           "no network for the IP",
+			sourceIP:       net.IPv4(192, 0, 2, 11),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:     "least specific network",
+			sourceIP: net.IPv4(10, 2, 0, 1),
+			expectedAttributes: attribute.NewSet(
+				attribute.String("network.zone", "internal"),
+			),
+		},
+		{
+			name:     "intermediate network",
+			sourceIP: net.IPv4(10, 1, 3, 1),
+			expectedAttributes: attribute.NewSet(
+				attribute.String("site.name", "datacenter-1"),
+				attribute.String("network.zone", "internal"),
+			),
+		},
+		{
+			name:     "most specific network",
+			sourceIP: net.IPv4(10, 1, 2, 3),
+			expectedAttributes: attribute.NewSet(
+				attribute.String("site.name", "datacenter-1"),
+				attribute.String("network.zone", "dmz"),
+			),
+		},
+		{
+			name:     "single address",
+			sourceIP: net.IPv4(192, 0, 2, 10),
+			expectedAttributes: attribute.NewSet(
+				attribute.String("site.name", "office"),
+			),
+		},
+		{
+			name:     "IPv6 network",
+			sourceIP: net.ParseIP("2001:db8::1"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String("site.name", "datacenter-2"),
+				attribute.String("network.zone", "internal"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualAttributes, err := provider.Location(t.Context(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), actualAttributes.ToSlice())
+		})
+	}
+}
+
+func TestParseTableErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectedErrMsg string
+	}{
+		{
+			name:           "empty file",
+			expectedErrMsg: "missing header",
+		},
+		{
+			name:           "missing network column",
+			content:        "cidr,site.name\n",
+			expectedErrMsg: `the first column must be "network", got "cidr"`,
+		},
+		{
+			name:           "missing attribute columns",
+			content:        "network\n",
+			expectedErrMsg: "at least one attribute column must be defined",
+		},
+		{
+			name:           "unnamed attribute column",
+			content:        "network,site.name,\n",
+			expectedErrMsg: "attribute columns must have a name",
+		},
+		{
+			name:           "invalid network",
+			content:        "network,site.name\n10.0.0.0/8,a\n10.0.0.0/33,b\n",
+			expectedErrMsg: `line 3: netip.ParsePrefix("10.0.0.0/33")`,
+		},
+		{
+			name:           "duplicate network",
+			content:        "network,site.name\n10.0.0.0/8,a\n10.1.0.0/8,b\n",
+			expectedErrMsg: "line 3: duplicate network 10.0.0.0/8",
+		},
+		{
+			name:           "wrong number of fields",
+			content:        "network,site.name\n10.0.0.0/8,a,b\n",
+			expectedErrMsg: "record on line 2: wrong number of fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTable(strings.NewReader(tt.content))
+			assert.ErrorContains(t, err, tt.expectedErrMsg)
+		})
+	}
+}
+
+// TestProviderReload asserts that the CSV provider reloads the file when it changes, and keeps the previous networks when the new file is invalid.
+func TestProviderReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "networks.csv")
+	require.NoError(t, os.WriteFile(path, []byte("network,site.name\n10.0.0.0/8,first\n"), 0o600))
+
+	provider, err := newCSVProvider(&Config{DatabasePath: path}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(t.Context()))
+	}()
+
+	assertSite := func(expected string) {
+		assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+			attributes, err := provider.Location(t.Context(), net.IPv4(10, 0, 0, 1))
+			assert.NoError(tt, err)
+			site, _ := attributes.Value("site.name")
+			assert.Equal(tt, expected, site.AsString())
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	assertSite("first")
+
+	require.NoError(t, os.WriteFile(path, []byte("network,site.name\n10.0.0.0/8,second\n"), 0o600))
+	assertSite("second")
+
+	require.NoError(t, os.WriteFile(path, []byte("network,site.name\nnot-a-network,third\n"), 0o600))
+	time.Sleep(100 * time.Millisecond)
+	assertSite("second")
+}
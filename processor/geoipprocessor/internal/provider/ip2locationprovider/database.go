@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"os"
+	"sort"
+)
+
+// headerSize is the size of the BIN file header, the tables start after it.
+const headerSize = 64
+
+// productCode identifies IP2Location IP geolocation databases in the header
+// of the files published since 2021.
+const productCode = 1
+
+// Column positions of the fields for every database type, see
+// https://www.ip2location.com/database. Position 1 is the first address of
+// a row, 0 means that the database type doesn't contain the field.
+var (
+	countryPosition   = [27]uint8{0, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}
+	regionPosition    = [27]uint8{0, 0, 0, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3}
+	cityPosition      = [27]uint8{0, 0, 0, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4}
+	latitudePosition  = [27]uint8{0, 0, 0, 0, 0, 5, 5, 0, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5}
+	longitudePosition = [27]uint8{0, 0, 0, 0, 0, 6, 6, 0, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6}
+	zipCodePosition   = [27]uint8{0, 0, 0, 0, 0, 0, 0, 0, 0, 7, 7, 7, 7, 0, 7, 7, 7, 0, 7, 0, 7, 7, 7, 0, 7, 7, 7}
+)
+
+var (
+	errInvalidDatabase     = errors.New("invalid IP2Location BIN database")
+	errUnsupportedDatabase = errors.New("unsupported IP2Location database type")
+)
+
+// record holds the fields of a database row. Empty fields are not present in
+// the database type or unknown for the IP range.
+type record struct {
+	countryCode string
+	countryName string
+	region      string
+	city        string
+	zipCode     string
+	// hasCoordinates is set when the database type contains the coordinates,
+	// which can then be 0 for a location on the equator or the prime meridian.
+	hasCoordinates bool
+	latitude       float32
+	longitude      float32
+}
+
+// table is the IPv4 or IPv6 part of a database.
+type table struct {
+	// count is the number of rows.
+	count uint32
+	// offset is the file offset of the first row.
+	offset int64
+	// ipSize is the size in bytes of the IP address starting every row.
+	ipSize int64
+}
+
+// database reads the IP2Location BIN format. Rows are sorted by the first
+// address of their IP range, which ends at the first address of the next row,
+// followed by one 4 bytes value per column: either a float or the file offset
+// of a string stored as its length followed by its bytes.
+type database struct {
+	file    *os.File
+	dbType  uint8
+	columns uint8
+	ipv4    table
+	ipv6    table
+}
+
+func openDatabase(path string) (*database, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	db, err := newDatabase(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func newDatabase(file *os.File) (*database, error) {
+	header := make([]byte, headerSize)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidDatabase, err)
+	}
+
+	db := &database{
+		file:    file,
+		dbType:  header[0],
+		columns: header[1],
+	}
+	year := header[2]
+	if year >= 21 && header[29] != productCode {
+		return nil, fmt.Errorf("%w: not an IP geolocation database", errInvalidDatabase)
+	}
+	if db.dbType == 0 || int(db.dbType) >= len(countryPosition) {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedDatabase, db.dbType)
+	}
+	if db.columns < 2 {
+		return nil, fmt.Errorf("%w: %d columns", errInvalidDatabase, db.columns)
+	}
+
+	// table addresses are 1-based
+	db.ipv4 = table{
+		count:  binary.LittleEndian.Uint32(header[5:]),
+		offset: int64(binary.LittleEndian.Uint32(header[9:])) - 1,
+		ipSize: net.IPv4len,
+	}
+	db.ipv6 = table{
+		count:  binary.LittleEndian.Uint32(header[13:]),
+		offset: int64(binary.LittleEndian.Uint32(header[17:])) - 1,
+		ipSize: net.IPv6len,
+	}
+	return db, nil
+}
+
+func (db *database) close() error {
+	return db.file.Close()
+}
+
+func (db *database) rowSize(t table) int64 {
+	return t.ipSize + int64(db.columns-1)*4
+}
+
+// readIP reads the first address of the given row as an integer.
+func (db *database) readIP(t table, row uint32) (*big.Int, error) {
+	buf := make([]byte, t.ipSize)
+	if _, err := db.file.ReadAt(buf, t.offset+int64(row)*db.rowSize(t)); err != nil {
+		return nil, err
+	}
+	// addresses are stored in little endian
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
+
+// lookup returns the record of the IP range containing the IP address, or
+// false when the database has no range for it.
+func (db *database) lookup(ip net.IP) (record, bool, error) {
+	t := db.ipv6
+	if ipv4 := ip.To4(); ipv4 != nil && db.ipv4.count > 0 {
+		t = db.ipv4
+		ip = ipv4
+	} else if ip.To16() == nil {
+		return record{}, false, fmt.Errorf("invalid IP address: %v", ip)
+	}
+	if t.count == 0 {
+		return record{}, false, nil
+	}
+	ipNumber := new(big.Int).SetBytes(ip.To16()[net.IPv6len-t.ipSize:])
+
+	// find the last row starting at or before the address
+	var err error
+	row := sort.Search(int(t.count), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		from, readErr := db.readIP(t, uint32(i))
+		if readErr != nil {
+			err = readErr
+			return true
+		}
+		return from.Cmp(ipNumber) > 0
+	}) - 1
+	if err != nil {
+		return record{}, false, fmt.Errorf("%w: %w", errInvalidDatabase, err)
+	}
+	if row < 0 {
+		return record{}, false, nil
+	}
+	// the last row only marks the end of the previous range
+	if row == int(t.count)-1 {
+		return record{}, false, nil
+	}
+
+	rec, err := db.readRecord(t, uint32(row))
+	if err != nil {
+		return record{}, false, fmt.Errorf("%w: %w", errInvalidDatabase, err)
+	}
+	return rec, true, nil
+}
+
+func (db *database) readRecord(t table, row uint32) (record, error) {
+	columns := make([]byte, db.rowSize(t)-t.ipSize)
+	if _, err := db.file.ReadAt(columns, t.offset+int64(row)*db.rowSize(t)+t.ipSize); err != nil {
+		return record{}, err
+	}
+	column := func(positions [27]uint8) (uint32, bool) {
+		position := positions[db.dbType]
+		if position == 0 || position > db.columns {
+			return 0, false
+		}
+		offset := int(position-2) * 4
+		return binary.LittleEndian.Uint32(columns[offset:]), true
+	}
+
+	var rec record
+	var err error
+	if pointer, ok := column(countryPosition); ok {
+		if rec.countryCode, err = db.readString(pointer); err != nil {
+			return record{}, err
+		}
+		// the country name follows its two letters code
+		if rec.countryName, err = db.readString(pointer + 3); err != nil {
+			return record{}, err
+		}
+	}
+	for _, field := range []struct {
+		positions [27]uint8
+		value     *string
+	}{
+		{regionPosition, &rec.region},
+		{cityPosition, &rec.city},
+		{zipCodePosition, &rec.zipCode},
+	} {
+		if pointer, ok := column(field.positions); ok {
+			if *field.value, err = db.readString(pointer); err != nil {
+				return record{}, err
+			}
+		}
+	}
+	latitude, hasLatitude := column(latitudePosition)
+	longitude, hasLongitude := column(longitudePosition)
+	if hasLatitude && hasLongitude {
+		rec.hasCoordinates = true
+		rec.latitude = math.Float32frombits(latitude)
+		rec.longitude = math.Float32frombits(longitude)
+	}
+	return rec, nil
+}
+
+// readString reads a string stored at the given file offset.
+func (db *database) readString(offset uint32) (string, error) {
+	length := make([]byte, 1)
+	if _, err := db.file.ReadAt(length, int64(offset)); err != nil {
+		return "", err
+	}
+	value := make([]byte, length[0])
+	if _, err := db.file.ReadAt(value, int64(offset)+1); err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
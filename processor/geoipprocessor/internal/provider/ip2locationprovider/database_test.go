@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRow is an IP range of a DB9 database: country, region, city,
+// latitude, longitude and zip code.
+type testRow struct {
+	from        string
+	countryCode string
+	countryName string
+	region      string
+	city        string
+	latitude    float32
+	longitude   float32
+	zipCode     string
+}
+
+var (
+	testIPv4Rows = []testRow{
+		{from: "0.0.0.0", countryCode: "-", countryName: "-", region: "-", city: "-", zipCode: "-"},
+		{from: "1.2.3.0", countryCode: "AU", countryName: "Australia", region: "Queensland", city: "Brisbane", latitude: -27.46794, longitude: 153.02809, zipCode: "4000"},
+		{from: "1.2.4.0", countryCode: "-", countryName: "-", region: "-", city: "-", zipCode: "-"},
+		{from: "89.160.20.112", countryCode: "SE", countryName: "Sweden", region: "Ostergotlands lan", city: "Linkoping", latitude: 58.41086, longitude: 15.62157, zipCode: "582 23"},
+		{from: "89.160.20.128", countryCode: "-", countryName: "-", region: "-", city: "-", zipCode: "-"},
+		{from: "193.60.192.0", countryCode: "GB", countryName: "United Kingdom of Great Britain and Northern Ireland", region: "England", city: "Greenwich", latitude: 51.4779, longitude: 0, zipCode: "SE10"},
+		{from: "193.60.193.0", countryCode: "-", countryName: "-", region: "-", city: "-", zipCode: "-"},
+		// the last row only marks the end of the previous range
+		{from: "255.255.255.255"},
+	}
+	testIPv6Rows = []testRow{
+		{from: "::", countryCode: "-", countryName: "-", region: "-", city: "-", zipCode: "-"},
+		{from: "2001:220::", countryCode: "KR", countryName: "Korea (Republic of)", region: "Seoul", city: "Seoul", latitude: 37.566, longitude: 126.9784, zipCode: "03141"},
+		{from: "2001:221::", countryCode: "-", countryName: "-", region: "-", city: "-", zipCode: "-"},
+		{from: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+	}
+)
+
+// writeTestDatabase writes a DB9 database with the given IPv4 and IPv6 rows in the IP2Location BIN format.
+func writeTestDatabase(t *testing.T, path string, ipv4Rows, ipv6Rows []testRow) {
+	const (
+		dbType  = 9
+		columns = 7
+	)
+	ipv4RowSize := 4 + (columns-1)*4
+	ipv6RowSize := 16 + (columns-1)*4
+	ipv4Offset := headerSize
+	ipv6Offset := ipv4Offset + len(ipv4Rows)*ipv4RowSize
+	stringsOffset := ipv6Offset + len(ipv6Rows)*ipv6RowSize
+
+	file := make([]byte, stringsOffset)
+	file[0] = dbType
+	file[1] = columns
+	file[2] = 25 // year
+	file[3] = 1  // month
+	file[4] = 1  // day
+	binary.LittleEndian.PutUint32(file[5:], uint32(len(ipv4Rows)))
+	binary.LittleEndian.PutUint32(file[9:], uint32(ipv4Offset+1))
+	binary.LittleEndian.PutUint32(file[13:], uint32(len(ipv6Rows)))
+	binary.LittleEndian.PutUint32(file[17:], uint32(ipv6Offset+1))
+	file[29] = productCode
+
+	appendString := func(value string) uint32 {
+		offset := uint32(len(file))
+		file = append(file, byte(len(value)))
+		file = append(file, value...)
+		return offset
+	}
+	// the country code is stored in 2 bytes, followed by the country name
+	appendCountry := func(code, name string) uint32 {
+		offset := uint32(len(file))
+		file = append(file, byte(len(code)))
+		file = append(file, (code + "  ")[:2]...)
+		appendString(name)
+		return offset
+	}
+	writeRows := func(offset, rowSize, ipSize int, rows []testRow) {
+		for i, row := range rows {
+			rowOffset := offset + i*rowSize
+			ip := net.ParseIP(row.from).To16()[net.IPv6len-ipSize:]
+			for j := range ip {
+				file[rowOffset+j] = ip[len(ip)-1-j]
+			}
+			values := []uint32{
+				appendCountry(row.countryCode, row.countryName),
+				appendString(row.region),
+				appendString(row.city),
+				math.Float32bits(row.latitude),
+				math.Float32bits(row.longitude),
+				appendString(row.zipCode),
+			}
+			for j, value := range values {
+				binary.LittleEndian.PutUint32(file[rowOffset+ipSize+j*4:], value)
+			}
+		}
+	}
+	writeRows(ipv4Offset, ipv4RowSize, net.IPv4len, ipv4Rows)
+	writeRows(ipv6Offset, ipv6RowSize, net.IPv6len, ipv6Rows)
+
+	require.NoError(t, os.WriteFile(path, file, 0o600))
+}
+
+func TestDatabaseLookup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "IP2LOCATION-DB9.BIN")
+	writeTestDatabase(t, path, testIPv4Rows, testIPv6Rows)
+
+	db, err := openDatabase(path)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, db.close())
+	}()
+
+	tests := []struct {
+		ip       string
+		expected record
+		found    bool
+	}{
+		{
+			ip:       "1.2.3.4",
+			expected: record{hasCoordinates: true, countryCode: "AU", countryName: "Australia", region: "Queensland", city: "Brisbane", latitude: -27.46794, longitude: 153.02809, zipCode: "4000"},
+			found:    true,
+		},
+		{
+			ip:       "1.2.3.255",
+			expected: record{hasCoordinates: true, countryCode: "AU", countryName: "Australia", region: "Queensland", city: "Brisbane", latitude: -27.46794, longitude: 153.02809, zipCode: "4000"},
+			found:    true,
+		},
+		{
+			ip:       "1.2.4.0",
+			expected: record{hasCoordinates: true, countryCode: "-", countryName: "-", region: "-", city: "-", zipCode: "-"},
+			found:    true,
+		},
+		{
+			ip:       "89.160.20.112",
+			expected: record{hasCoordinates: true, countryCode: "SE", countryName: "Sweden", region: "Ostergotlands lan", city: "Linkoping", latitude: 58.41086, longitude: 15.62157, zipCode: "582 23"},
+			found:    true,
+		},
+		{
+			ip:       "::ffff:89.160.20.120",
+			expected: record{hasCoordinates: true, countryCode: "SE", countryName: "Sweden", region: "Ostergotlands lan", city: "Linkoping", latitude: 58.41086, longitude: 15.62157, zipCode: "582 23"},
+			found:    true,
+		},
+		{
+			ip:       "193.60.192.1",
+			expected: record{hasCoordinates: true, countryCode: "GB", countryName: "United Kingdom of Great Britain and Northern Ireland", region: "England", city: "Greenwich", latitude: 51.4779, zipCode: "SE10"},
+			found:    true,
+		},
+		{
+			ip:       "2001:220::1",
+			expected: record{hasCoordinates: true, countryCode: "KR", countryName: "Korea (Republic of)", region: "Seoul", city: "Seoul", latitude: 37.566, longitude: 126.9784, zipCode: "03141"},
+			found:    true,
+		},
+		{
+			ip: "255.255.255.255",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			rec, found, err := db.lookup(net.ParseIP(tt.ip))
+			require.NoError(t, err)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, rec)
+		})
+	}
+}
+
+func TestOpenDatabaseErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := openDatabase(filepath.Join(dir, "missing.BIN"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	truncated := filepath.Join(dir, "truncated.BIN")
+	require.NoError(t, os.WriteFile(truncated, []byte{9, 7}, 0o600))
+	_, err = openDatabase(truncated)
+	assert.ErrorIs(t, err, errInvalidDatabase)
+
+	path := filepath.Join(dir, "proxy.BIN")
+	writeTestDatabase(t, path, testIPv4Rows, nil)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data[29] = 2 // IP2Proxy product code
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	_, err = openDatabase(path)
+	assert.ErrorIs(t, err, errInvalidDatabase)
+
+	data[29] = productCode
+	data[0] = 42
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	_, err = openDatabase(path)
+	assert.ErrorIs(t, err, errUnsupportedDatabase)
+}
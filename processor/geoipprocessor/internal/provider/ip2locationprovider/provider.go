@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// notAvailable is the value of the fields without data for an IP range.
+const notAvailable = "-"
+
+type ip2LocationProvider struct {
+	// mu guards db, which is replaced when the database file changes.
+	mu sync.RWMutex
+	db *database
+
+	path    string
+	watcher *filewatcher.Watcher
+}
+
+var _ provider.GeoIPProvider = (*ip2LocationProvider)(nil)
+
+func newIP2LocationProvider(cfg *Config, logger *zap.Logger) (*ip2LocationProvider, error) {
+	db, err := openDatabase(cfg.DatabasePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open IP2Location database: %w", err)
+	}
+
+	p := &ip2LocationProvider{db: db, path: cfg.DatabasePath}
+	p.watcher, err = filewatcher.New(cfg.DatabasePath, logger, p.reload)
+	if err != nil {
+		_ = db.close()
+		return nil, fmt.Errorf("could not watch IP2Location database: %w", err)
+	}
+	return p, nil
+}
+
+// reload opens the database file again and replaces the current database once the new one is ready.
+func (p *ip2LocationProvider) reload() error {
+	db, err := openDatabase(p.path)
+	if err != nil {
+		return fmt.Errorf("could not open IP2Location database: %w", err)
+	}
+
+	p.mu.Lock()
+	previous := p.db
+	p.db = db
+	p.mu.Unlock()
+	return previous.close()
+}
+
+// Location implements provider.GeoIPProvider for IP2Location. If no metadata is found in the database, an error will be returned.
+func (p *ip2LocationProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	rec, found, err := p.db.lookup(ipAddress)
+	if err != nil {
+		return attribute.Set{}, err
+	} else if !found {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+
+	attributes := make([]attribute.KeyValue, 0, 7)
+	appendIfAvailable := func(keyName, value string) {
+		if value != "" && value != notAvailable {
+			attributes = append(attributes, attribute.String(keyName, value))
+		}
+	}
+
+	appendIfAvailable(conventions.AttributeGeoCountryIsoCode, rec.countryCode)
+	appendIfAvailable(conventions.AttributeGeoCountryName, rec.countryName)
+	appendIfAvailable(conventions.AttributeGeoRegionName, rec.region)
+	appendIfAvailable(conventions.AttributeGeoCityName, rec.city)
+	appendIfAvailable(conventions.AttributeGeoPostalCode, rec.zipCode)
+	// The ranges without location data, e.g. reserved ranges, have no country and their coordinates are 0.
+	if rec.hasCoordinates && rec.countryCode != "" && rec.countryCode != notAvailable {
+		attributes = append(attributes, attribute.Float64(conventions.AttributeGeoLocationLat, toFloat64(rec.latitude)), attribute.Float64(conventions.AttributeGeoLocationLon, toFloat64(rec.longitude)))
+	}
+
+	if len(attributes) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attribute.NewSet(attributes...), nil
+}
+
+// toFloat64 converts the coordinates stored as float32 without exposing the
+// binary approximation, so that 51.50853 isn't reported as 51.508529663085938.
+func toFloat64(value float32) float64 {
+	converted, _ := strconv.ParseFloat(strconv.FormatFloat(float64(value), 'f', -1, 32), 64)
+	return converted
+}
+
+// Close stops watching the database file and closes it.
+func (p *ip2LocationProvider) Close(context.Context) error {
+	err := p.watcher.Close()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.db != nil {
+		err = errors.Join(err, p.db.close())
+		p.db = nil
+	}
+	return err
+}
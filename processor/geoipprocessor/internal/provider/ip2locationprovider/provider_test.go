@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newIP2LocationProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open IP2Location database")
+}
+
+// TestProviderLocation asserts that the IP2Location provider adds the geo location data given an IP.
+func TestProviderLocation(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "IP2LOCATION-DB9.BIN")
+	writeTestDatabase(t, dbPath, testIPv4Rows, testIPv6Rows)
+
+	provider, err := newIP2LocationProvider(&Config{DatabasePath: dbPath}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(t.Context()))
+	}()
+
+	tests := []struct {
+		name               string
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:           "nil IP address",
+			expectedErrMsg: "invalid IP address: <nil>",
+		},
+		{
+			name:           "no IP metadata in database",
+			sourceIP:       net.IPv4(1, 2, 4, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:     "all attributes for IPv4",
+			sourceIP: net.IPv4(89, 160, 20, 115),
+			expectedAttributes: attribute.NewSet([]attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCityName, "Linkoping"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "SE"),
+				attribute.String(conventions.AttributeGeoCountryName, "Sweden"),
+				attribute.String(conventions.AttributeGeoRegionName, "Ostergotlands lan"),
+				attribute.String(conventions.AttributeGeoPostalCode, "582 23"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 58.41086),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 15.62157),
+			}...),
+		},
+		{
+			name:     "coordinates on the prime meridian",
+			sourceIP: net.IPv4(193, 60, 192, 1),
+			expectedAttributes: attribute.NewSet([]attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCityName, "Greenwich"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "GB"),
+				attribute.String(conventions.AttributeGeoCountryName, "United Kingdom of Great Britain and Northern Ireland"),
+				attribute.String(conventions.AttributeGeoRegionName, "England"),
+				attribute.String(conventions.AttributeGeoPostalCode, "SE10"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 51.4779),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 0),
+			}...),
+		},
+		{
+			name:     "all attributes for IPv6",
+			sourceIP: net.ParseIP("2001:220::1"),
+			expectedAttributes: attribute.NewSet([]attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCityName, "Seoul"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "KR"),
+				attribute.String(conventions.AttributeGeoCountryName, "Korea (Republic of)"),
+				attribute.String(conventions.AttributeGeoRegionName, "Seoul"),
+				attribute.String(conventions.AttributeGeoPostalCode, "03141"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 37.566),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 126.9784),
+			}...),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualAttributes, err := provider.Location(t.Context(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), actualAttributes.ToSlice())
+		})
+	}
+}
+
+// TestProviderReload asserts that the IP2Location provider reloads the database when the file is replaced.
+func TestProviderReload(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "IP2LOCATION-DB9.BIN")
+	writeTestDatabase(t, dbPath, testIPv4Rows, nil)
+
+	provider, err := newIP2LocationProvider(&Config{DatabasePath: dbPath}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(t.Context()))
+	}()
+
+	_, err = provider.Location(t.Context(), net.ParseIP("2001:220::1"))
+	require.EqualError(t, err, "no geo IP metadata found")
+
+	// replace the database the way database updaters do
+	replacement := dbPath + ".tmp"
+	writeTestDatabase(t, replacement, testIPv4Rows, testIPv6Rows)
+	require.NoError(t, os.Rename(replacement, dbPath))
+
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		attributes, err := provider.Location(t.Context(), net.ParseIP("2001:220::1"))
+		assert.NoError(tt, err)
+		countryCode, _ := attributes.Value(attribute.Key(conventions.AttributeGeoCountryIsoCode))
+		assert.Equal(tt, "KR", countryCode.AsString())
+	}, 5*time.Second, 10*time.Millisecond)
+}
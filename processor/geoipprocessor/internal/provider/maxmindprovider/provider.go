@@ -8,10 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/oschwald/geoip2-golang"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/filewatcher"
 	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
 )
@@ -21,50 +24,88 @@ var (
 	defaultLanguageCode = "en"
 	geoIP2CityDBType    = "GeoIP2-City"
 	geoLite2CityDBType  = "GeoLite2-City"
+	geoLite2ASNDBType   = "GeoLite2-ASN"
 
 	errUnsupportedDB = errors.New("unsupported geo IP database type")
 )
 
 type maxMindProvider struct {
+	// mu guards geoReader, which is replaced when the database file changes.
+	mu        sync.RWMutex
 	geoReader *geoip2.Reader
 	// language code to be used in name retrieval, e.g. "en" or "pt-BR"
 	langCode string
+
+	path    string
+	watcher *filewatcher.Watcher
 }
 
 var _ provider.GeoIPProvider = (*maxMindProvider)(nil)
 
-func newMaxMindProvider(cfg *Config) (*maxMindProvider, error) {
+func newMaxMindProvider(cfg *Config, logger *zap.Logger) (*maxMindProvider, error) {
 	geoReader, err := geoip2.Open(cfg.DatabasePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not open geoip database: %w", err)
 	}
 
-	return &maxMindProvider{geoReader: geoReader, langCode: defaultLanguageCode}, nil
+	g := &maxMindProvider{geoReader: geoReader, langCode: defaultLanguageCode, path: cfg.DatabasePath}
+	g.watcher, err = filewatcher.New(cfg.DatabasePath, logger, g.reload)
+	if err != nil {
+		_ = geoReader.Close()
+		return nil, fmt.Errorf("could not watch geoip database: %w", err)
+	}
+	return g, nil
+}
+
+// reload opens the database file again and replaces the current reader once the new one is ready.
+func (g *maxMindProvider) reload() error {
+	geoReader, err := geoip2.Open(g.path)
+	if err != nil {
+		return fmt.Errorf("could not open geoip database: %w", err)
+	}
+
+	g.mu.Lock()
+	previous := g.geoReader
+	g.geoReader = geoReader
+	g.mu.Unlock()
+	return previous.Close()
 }
 
-// Location implements provider.GeoIPProvider for MaxMind. If a non City database type is used or no metadata is found in the database, an error will be returned.
+// Location implements provider.GeoIPProvider for MaxMind. If a non City or ASN database type is used or no metadata is found in the database, an error will be returned.
 func (g *maxMindProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var attrs *[]attribute.KeyValue
+	var err error
 	switch g.geoReader.Metadata().DatabaseType {
 	case geoIP2CityDBType, geoLite2CityDBType:
-		attrs, err := g.cityAttributes(ipAddress)
-		if err != nil {
-			return attribute.Set{}, err
-		} else if len(*attrs) == 0 {
-			return attribute.Set{}, provider.ErrNoMetadataFound
-		}
-		return attribute.NewSet(*attrs...), nil
+		attrs, err = g.cityAttributes(ipAddress)
+	case geoLite2ASNDBType:
+		attrs, err = g.asnAttributes(ipAddress)
 	default:
 		return attribute.Set{}, fmt.Errorf("%w type: %s", errUnsupportedDB, g.geoReader.Metadata().DatabaseType)
 	}
+	if err != nil {
+		return attribute.Set{}, err
+	} else if len(*attrs) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attribute.NewSet(*attrs...), nil
 }
 
-// Close unmaps the geo database file from virtual memory and returns the
-// resources to the system.
+// Close stops watching the database file, unmaps it from virtual memory and
+// returns the resources to the system.
 func (g *maxMindProvider) Close(context.Context) error {
+	err := g.watcher.Close()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	if g.geoReader != nil {
-		return g.geoReader.Close()
+		err = errors.Join(err, g.geoReader.Close())
+		g.geoReader = nil
 	}
-	return nil
+	return err
 }
 
 // cityAttributes returns a list of key-values containing geographical metadata associated to the provided IP. The key names are populated using the internal geo IP conventions package. If an invalid or nil IP is provided, an error is returned.
@@ -109,3 +150,22 @@ func (g *maxMindProvider) cityAttributes(ipAddress net.IP) (*[]attribute.KeyValu
 
 	return &attributes, err
 }
+
+// asnAttributes returns the autonomous system number and organization associated to the provided IP.
+func (g *maxMindProvider) asnAttributes(ipAddress net.IP) (*[]attribute.KeyValue, error) {
+	attributes := make([]attribute.KeyValue, 0, 2)
+
+	asn, err := g.geoReader.ASN(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if asn.AutonomousSystemNumber != 0 {
+		attributes = append(attributes, attribute.Int64(conventions.AttributeASNumber, int64(asn.AutonomousSystemNumber)))
+	}
+	if asn.AutonomousSystemOrganization != "" {
+		attributes = append(attributes, attribute.String(conventions.AttributeASOrganizationName, asn.AutonomousSystemOrganization))
+	}
+
+	return &attributes, nil
+}
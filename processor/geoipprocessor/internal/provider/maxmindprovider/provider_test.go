@@ -6,26 +6,29 @@ package maxmind
 import (
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 
 	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
 )
 
 func TestInvalidNewProvider(t *testing.T) {
-	_, err := newMaxMindProvider(&Config{})
+	_, err := newMaxMindProvider(&Config{}, zap.NewNop())
 	expectedErrMsgSuffix := "no such file or directory"
 	if runtime.GOOS == "windows" {
 		expectedErrMsgSuffix = "The system cannot find the file specified."
 	}
 	require.ErrorContains(t, err, "could not open geoip database: open : "+expectedErrMsgSuffix)
 
-	_, err = newMaxMindProvider(&Config{DatabasePath: "no valid path"})
+	_, err = newMaxMindProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
 	require.ErrorContains(t, err, "could not open geoip database: open no valid path: "+expectedErrMsgSuffix)
 }
 
@@ -92,12 +95,35 @@ func TestProviderLocation(t *testing.T) {
 				attribute.Float64(conventions.AttributeGeoLocationLon, 1),
 			}...),
 		},
+		{
+			name:         "autonomous system for IPv4 using GeoLite2-ASN database",
+			sourceIP:     net.IPv4(1, 2, 3, 4),
+			testDatabase: "GeoLite2-ASN-Test.mmdb",
+			expectedAttributes: attribute.NewSet([]attribute.KeyValue{
+				attribute.Int64(conventions.AttributeASNumber, 1221),
+				attribute.String(conventions.AttributeASOrganizationName, "Telstra Pty Ltd"),
+			}...),
+		},
+		{
+			name:         "autonomous system number for IPv6 using GeoLite2-ASN database",
+			sourceIP:     net.ParseIP("2001:220::"),
+			testDatabase: "GeoLite2-ASN-Test.mmdb",
+			expectedAttributes: attribute.NewSet([]attribute.KeyValue{
+				attribute.Int64(conventions.AttributeASNumber, 9286),
+			}...),
+		},
+		{
+			name:           "no IP metadata in ASN database",
+			sourceIP:       net.IPv4(0, 0, 0, 0),
+			testDatabase:   "GeoLite2-ASN-Test.mmdb",
+			expectedErrMsg: "no geo IP metadata found",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// prepare provider
-			provider, err := newMaxMindProvider(&Config{DatabasePath: tmpDBfiles + "/" + tt.testDatabase})
+			provider, err := newMaxMindProvider(&Config{DatabasePath: tmpDBfiles + "/" + tt.testDatabase}, zap.NewNop())
 			assert.NoError(t, err)
 
 			// assert metrics
@@ -113,3 +139,39 @@ func TestProviderLocation(t *testing.T) {
 		})
 	}
 }
+
+// TestProviderReload asserts that the MaxMind provider reloads the database when the file is replaced.
+func TestProviderReload(t *testing.T) {
+	tmpDBfiles := testdata.GenerateLocalDB(t, "./testdata")
+	dbPath := filepath.Join(t.TempDir(), "GeoIP.mmdb")
+	copyFile(t, filepath.Join(tmpDBfiles, "GeoLite2-City-Test.mmdb"), dbPath)
+
+	provider, err := newMaxMindProvider(&Config{DatabasePath: dbPath}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(t.Context()))
+	}()
+
+	attributes, err := provider.Location(t.Context(), net.IPv4(1, 2, 3, 4))
+	require.NoError(t, err)
+	cityName, _ := attributes.Value(attribute.Key(conventions.AttributeGeoCityName))
+	assert.Equal(t, "Boxford", cityName.AsString())
+
+	// replace the database the way database updaters do
+	replacement := dbPath + ".tmp"
+	copyFile(t, filepath.Join(tmpDBfiles, "GeoLite2-ASN-Test.mmdb"), replacement)
+	require.NoError(t, os.Rename(replacement, dbPath))
+
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		attributes, err := provider.Location(t.Context(), net.IPv4(1, 2, 3, 4))
+		assert.NoError(tt, err)
+		asNumber, _ := attributes.Value(attribute.Key(conventions.AttributeASNumber))
+		assert.Equal(tt, int64(1221), asNumber.AsInt64())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0o600))
+}
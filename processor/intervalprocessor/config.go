@@ -21,6 +21,10 @@ type Config struct {
 	// PassThrough is a configuration that determines whether gauge and summary metrics should be passed through
 	// as they are or aggregated.
 	PassThrough PassThrough `mapstructure:"pass_through"`
+	// AggregateDeltas is a flag that determines whether delta sums, histograms and exponential
+	// histograms should be merged into a single datapoint per stream and interval, or passed through
+	// as they are.
+	AggregateDeltas bool `mapstructure:"aggregate_deltas"`
 }
 
 type PassThrough struct {
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package data implements the merging of delta datapoints of the same stream into one.
+package data // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/data"
+
+import (
+	"errors"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+)
+
+// maxBuckets limits the number of buckets of each range of merged exponential histograms.
+// The histograms are downscaled when the merge would exceed it.
+var maxBuckets = 160
+
+// ErrBoundsMismatch is returned when the observations of a histogram can't be added to
+// the ones of a histogram with different bucket boundaries.
+var ErrBoundsMismatch = errors.New("histogram bucket boundaries differ")
+
+// AddNumbers adds the value of dp to state. The sum of an int and a double is a double.
+func AddNumbers(state, dp pmetric.NumberDataPoint) error {
+	if state.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		state.SetIntValue(state.IntValue() + dp.IntValue())
+		return nil
+	}
+	state.SetDoubleValue(doubleValue(state) + doubleValue(dp))
+	return nil
+}
+
+func doubleValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+// AddHistograms adds the observations of dp to state. When the bucket boundaries differ,
+// the observations can't be merged, state is left as is and [ErrBoundsMismatch] is returned.
+func AddHistograms(state, dp pmetric.HistogramDataPoint) error {
+	if !state.ExplicitBounds().Equal(dp.ExplicitBounds()) {
+		return ErrBoundsMismatch
+	}
+
+	n := min(state.BucketCounts().Len(), dp.BucketCounts().Len())
+	for i := 0; i < n; i++ {
+		state.BucketCounts().SetAt(i, state.BucketCounts().At(i)+dp.BucketCounts().At(i))
+	}
+	state.SetCount(state.Count() + dp.Count())
+
+	if state.HasSum() && dp.HasSum() {
+		state.SetSum(state.Sum() + dp.Sum())
+	} else {
+		state.RemoveSum()
+	}
+	if state.HasMin() && dp.HasMin() {
+		state.SetMin(math.Min(state.Min(), dp.Min()))
+	} else {
+		state.RemoveMin()
+	}
+	if state.HasMax() && dp.HasMax() {
+		state.SetMax(math.Max(state.Max(), dp.Max()))
+	} else {
+		state.RemoveMax()
+	}
+	return nil
+}
+
+// AddExponentialHistograms adds the observations of dp to state. The histograms are
+// aligned to the lowest of their scales and to the widest of their zero thresholds
+// first, which may modify dp.
+func AddExponentialHistograms(state, dp pmetric.ExponentialHistogramDataPoint) error {
+	type H = pmetric.ExponentialHistogramDataPoint
+
+	if state.Scale() != dp.Scale() {
+		hi, lo := expo.HiLo(state, dp, H.Scale)
+		from, to := expo.Scale(hi.Scale()), expo.Scale(lo.Scale())
+		expo.Downscale(hi.Positive(), from, to)
+		expo.Downscale(hi.Negative(), from, to)
+		hi.SetScale(lo.Scale())
+	}
+
+	// Downscale if the number of buckets after the merge would be too large.
+	from := expo.Scale(state.Scale())
+	to := min(
+		expo.Limit(maxBuckets, from, state.Positive(), dp.Positive()),
+		expo.Limit(maxBuckets, from, state.Negative(), dp.Negative()),
+	)
+	if from != to {
+		expo.Downscale(state.Positive(), from, to)
+		expo.Downscale(state.Negative(), from, to)
+		expo.Downscale(dp.Positive(), from, to)
+		expo.Downscale(dp.Negative(), from, to)
+		state.SetScale(int32(to))
+		dp.SetScale(int32(to))
+	}
+
+	if state.ZeroThreshold() != dp.ZeroThreshold() {
+		hi, lo := expo.HiLo(state, dp, H.ZeroThreshold)
+		expo.WidenZero(lo, hi.ZeroThreshold())
+	}
+
+	expo.Merge(state.Positive(), dp.Positive())
+	expo.Merge(state.Negative(), dp.Negative())
+	state.SetCount(state.Count() + dp.Count())
+	state.SetZeroCount(state.ZeroCount() + dp.ZeroCount())
+
+	if state.HasSum() && dp.HasSum() {
+		state.SetSum(state.Sum() + dp.Sum())
+	} else {
+		state.RemoveSum()
+	}
+	if state.HasMin() && dp.HasMin() {
+		state.SetMin(math.Min(state.Min(), dp.Min()))
+	} else {
+		state.RemoveMin()
+	}
+	if state.HasMax() && dp.HasMax() {
+		state.SetMax(math.Max(state.Max(), dp.Max()))
+	} else {
+		state.RemoveMax()
+	}
+	return nil
+}
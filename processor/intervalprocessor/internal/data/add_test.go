@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestAddNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    func(pmetric.NumberDataPoint)
+		dp       func(pmetric.NumberDataPoint)
+		expected func(pmetric.NumberDataPoint)
+	}{
+		{
+			name:     "ints",
+			state:    func(dp pmetric.NumberDataPoint) { dp.SetIntValue(3) },
+			dp:       func(dp pmetric.NumberDataPoint) { dp.SetIntValue(4) },
+			expected: func(dp pmetric.NumberDataPoint) { dp.SetIntValue(7) },
+		},
+		{
+			name:     "doubles",
+			state:    func(dp pmetric.NumberDataPoint) { dp.SetDoubleValue(1.5) },
+			dp:       func(dp pmetric.NumberDataPoint) { dp.SetDoubleValue(-0.25) },
+			expected: func(dp pmetric.NumberDataPoint) { dp.SetDoubleValue(1.25) },
+		},
+		{
+			name:     "int_and_double",
+			state:    func(dp pmetric.NumberDataPoint) { dp.SetIntValue(2) },
+			dp:       func(dp pmetric.NumberDataPoint) { dp.SetDoubleValue(0.5) },
+			expected: func(dp pmetric.NumberDataPoint) { dp.SetDoubleValue(2.5) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, dp, expected := pmetric.NewNumberDataPoint(), pmetric.NewNumberDataPoint(), pmetric.NewNumberDataPoint()
+			tt.state(state)
+			tt.dp(dp)
+			tt.expected(expected)
+
+			require.NoError(t, AddNumbers(state, dp))
+			assert.Equal(t, expected, state)
+		})
+	}
+}
+
+func TestAddHistograms(t *testing.T) {
+	histogram := func(bounds []float64, counts []uint64, sum, minimum, maximum float64) pmetric.HistogramDataPoint {
+		dp := pmetric.NewHistogramDataPoint()
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		dp.SetMin(minimum)
+		dp.SetMax(maximum)
+		return dp
+	}
+
+	t.Run("same_bounds", func(t *testing.T) {
+		state := histogram([]float64{1, 10}, []uint64{1, 2, 3}, 40, 0.5, 12)
+		dp := histogram([]float64{1, 10}, []uint64{4, 0, 1}, 20, 0.1, 15)
+
+		require.NoError(t, AddHistograms(state, dp))
+		assert.Equal(t, histogram([]float64{1, 10}, []uint64{5, 2, 4}, 60, 0.1, 15), state)
+	})
+
+	t.Run("different_bounds", func(t *testing.T) {
+		state := histogram([]float64{1, 10}, []uint64{1, 2, 3}, 40, 0.5, 12)
+		dp := histogram([]float64{5}, []uint64{4, 1}, 20, 0.1, 15)
+
+		require.ErrorIs(t, AddHistograms(state, dp), ErrBoundsMismatch)
+		assert.Equal(t, histogram([]float64{1, 10}, []uint64{1, 2, 3}, 40, 0.5, 12), state)
+	})
+
+	t.Run("missing_sum", func(t *testing.T) {
+		state := histogram([]float64{1}, []uint64{1, 2}, 4, 0.5, 2)
+		dp := histogram([]float64{1}, []uint64{1, 0}, 1, 0.5, 0.5)
+		dp.RemoveSum()
+
+		require.NoError(t, AddHistograms(state, dp))
+		assert.False(t, state.HasSum())
+		assert.Equal(t, uint64(4), state.Count())
+	})
+}
+
+type expdp struct {
+	scale     int32
+	zero      uint64
+	threshold float64
+	posOffset int32
+	pos       []uint64
+	negOffset int32
+	neg       []uint64
+}
+
+func (e expdp) dp() pmetric.ExponentialHistogramDataPoint {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(e.scale)
+	dp.SetZeroCount(e.zero)
+	dp.SetZeroThreshold(e.threshold)
+	dp.Positive().SetOffset(e.posOffset)
+	dp.Positive().BucketCounts().FromRaw(e.pos)
+	dp.Negative().SetOffset(e.negOffset)
+	dp.Negative().BucketCounts().FromRaw(e.neg)
+	count := e.zero
+	for _, c := range append(append([]uint64{}, e.pos...), e.neg...) {
+		count += c
+	}
+	dp.SetCount(count)
+	return dp
+}
+
+func TestAddExponentialHistograms(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBuckets int
+		state      expdp
+		dp         expdp
+		expected   expdp
+	}{
+		{
+			name:     "same_scale",
+			state:    expdp{scale: 2, zero: 1, posOffset: 1, pos: []uint64{1, 2}, negOffset: 0, neg: []uint64{3}},
+			dp:       expdp{scale: 2, zero: 2, posOffset: 2, pos: []uint64{1, 1, 5}},
+			expected: expdp{scale: 2, zero: 3, posOffset: 1, pos: []uint64{1, 3, 1, 5}, negOffset: 0, neg: []uint64{3}},
+		},
+		{
+			name:  "downscales_to_lower_scale",
+			state: expdp{scale: 1, posOffset: 0, pos: []uint64{1, 1}},
+			// buckets -2..1 at scale 2 are the buckets -1..0 at scale 1.
+			dp:       expdp{scale: 2, posOffset: -2, pos: []uint64{1, 2, 3, 4}},
+			expected: expdp{scale: 1, posOffset: -1, pos: []uint64{3, 8, 1}},
+		},
+		{
+			name:     "state_is_downscaled",
+			state:    expdp{scale: 3, posOffset: 4, pos: []uint64{2, 2}},
+			dp:       expdp{scale: 2, posOffset: 2, pos: []uint64{1}},
+			expected: expdp{scale: 2, posOffset: 2, pos: []uint64{5}},
+		},
+		{
+			name:       "limits_buckets",
+			maxBuckets: 4,
+			state:      expdp{scale: 0, posOffset: 0, pos: []uint64{1, 1, 1, 1}},
+			dp:         expdp{scale: 0, posOffset: 4, pos: []uint64{1, 1, 1, 1}},
+			expected:   expdp{scale: -1, posOffset: 0, pos: []uint64{2, 2, 2, 2}},
+		},
+		{
+			name: "widens_zero_bucket",
+			// at scale 0, the bucket 0 is (1, 2] and the bucket 1 is (2, 4].
+			state:    expdp{scale: 0, zero: 1, threshold: 2, posOffset: 1, pos: []uint64{1, 1}},
+			dp:       expdp{scale: 0, zero: 1, threshold: 0.5, posOffset: 0, pos: []uint64{4, 1, 1}},
+			expected: expdp{scale: 0, zero: 6, threshold: 2, posOffset: 1, pos: []uint64{2, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.maxBuckets > 0 {
+				defer func(limit int) { maxBuckets = limit }(maxBuckets)
+				maxBuckets = tt.maxBuckets
+			}
+
+			state := tt.state.dp()
+			require.NoError(t, AddExponentialHistograms(state, tt.dp.dp()))
+			assert.Equal(t, tt.expected.dp(), state)
+		})
+	}
+}
+
+func TestAddExponentialHistogramsMinMaxSum(t *testing.T) {
+	state, dp := expdp{pos: []uint64{1}}.dp(), expdp{pos: []uint64{1}}.dp()
+	state.SetSum(2)
+	state.SetMin(1.5)
+	state.SetMax(1.7)
+	dp.SetSum(1.2)
+	dp.SetMin(1.2)
+	dp.SetMax(1.2)
+
+	require.NoError(t, AddExponentialHistograms(state, dp))
+	assert.Equal(t, uint64(2), state.Count())
+	assert.InDelta(t, 3.2, state.Sum(), 1e-9)
+	assert.Equal(t, 1.2, state.Min())
+	assert.Equal(t, 1.7, state.Max())
+}
@@ -5,6 +5,7 @@ package metrics // import "github.com/open-telemetry/opentelemetry-collector-con
 
 import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
 type DataPointSlice[DP DataPoint[DP]] interface {
@@ -18,3 +19,11 @@ type DataPoint[Self any] interface {
 	Attributes() pcommon.Map
 	CopyTo(dest Self)
 }
+
+type DeltaDataPoint[Self any] interface {
+	DataPoint[Self]
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	SetTimestamp(pcommon.Timestamp)
+	Flags() pmetric.DataPointFlags
+}
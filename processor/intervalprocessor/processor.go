@@ -17,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/data"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/metrics"
 )
 
@@ -96,6 +97,7 @@ func (*intervalProcessor) Capabilities() consumer.Capabilities {
 
 func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	var errs error
+	var dropped int
 
 	p.stateLock.Lock()
 	defer p.stateLock.Unlock()
@@ -124,6 +126,16 @@ func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 					// Check if we care about this value
 					sum := m.Sum()
 
+					if sum.AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+						if !p.config.AggregateDeltas {
+							return false
+						}
+
+						mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+						dropped += aggregateDeltaDataPoints(sum.DataPoints(), mClone.Sum().DataPoints(), metricID, p.numberLookup, data.AddNumbers)
+						return true
+					}
+
 					if !sum.IsMonotonic() {
 						return false
 					}
@@ -140,6 +152,12 @@ func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 				case pmetric.MetricTypeHistogram:
 					histogram := m.Histogram()
 
+					if histogram.AggregationTemporality() == pmetric.AggregationTemporalityDelta && p.config.AggregateDeltas {
+						mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+						dropped += aggregateDeltaDataPoints(histogram.DataPoints(), mClone.Histogram().DataPoints(), metricID, p.histogramLookup, data.AddHistograms)
+						return true
+					}
+
 					if histogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
 						return false
 					}
@@ -152,6 +170,12 @@ func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 				case pmetric.MetricTypeExponentialHistogram:
 					expHistogram := m.ExponentialHistogram()
 
+					if expHistogram.AggregationTemporality() == pmetric.AggregationTemporalityDelta && p.config.AggregateDeltas {
+						mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+						dropped += aggregateDeltaDataPoints(expHistogram.DataPoints(), mClone.ExponentialHistogram().DataPoints(), metricID, p.expHistogramLookup, data.AddExponentialHistograms)
+						return true
+					}
+
 					if expHistogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
 						return false
 					}
@@ -171,6 +195,10 @@ func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 		return rm.ScopeMetrics().Len() == 0
 	})
 
+	if dropped > 0 {
+		p.logger.Warn("Dropped delta datapoints that can't be added to the ones of the interval", zap.Int("datapoints", dropped))
+	}
+
 	if err := p.nextConsumer.ConsumeMetrics(ctx, md); err != nil {
 		errs = errors.Join(errs, err)
 	}
@@ -201,6 +229,45 @@ func aggregateDataPoints[DPS metrics.DataPointSlice[DP], DP metrics.DataPoint[DP
 	}
 }
 
+// aggregateDeltaDataPoints merges the delta datapoints of each stream into a single datapoint,
+// spanning from the earliest start to the latest timestamp seen during the interval. Datapoints
+// that can't be added to the ones already seen, e.g. histograms whose bucket boundaries changed,
+// are dropped and their number is returned.
+func aggregateDeltaDataPoints[DPS metrics.DataPointSlice[DP], DP metrics.DeltaDataPoint[DP]](dataPoints, mCloneDataPoints DPS, metricID identity.Metric, dpLookup map[identity.Stream]DP, add func(state, dp DP) error) (dropped int) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		existingDP, ok := dpLookup[streamID]
+		if !ok {
+			dpClone := mCloneDataPoints.AppendEmpty()
+			dp.CopyTo(dpClone)
+			dpLookup[streamID] = dpClone
+			continue
+		}
+
+		// Datapoints without a recorded value have nothing to add, unless there is
+		// nothing recorded so far either
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+		if existingDP.Flags().NoRecordedValue() {
+			dp.CopyTo(existingDP)
+			continue
+		}
+
+		start := min(existingDP.StartTimestamp(), dp.StartTimestamp())
+		timestamp := max(existingDP.Timestamp(), dp.Timestamp())
+		if err := add(existingDP, dp); err != nil {
+			dropped++
+			continue
+		}
+		existingDP.SetStartTimestamp(start)
+		existingDP.SetTimestamp(timestamp)
+	}
+	return dropped
+}
+
 func (p *intervalProcessor) exportMetrics() {
 	md := func() pmetric.Metrics {
 		p.stateLock.Lock()
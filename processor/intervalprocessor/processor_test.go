@@ -23,8 +23,9 @@ func TestAggregation(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
-		name        string
-		passThrough bool
+		name            string
+		passThrough     bool
+		aggregateDeltas bool
 	}{
 		{name: "basic_aggregation"},
 		{name: "histograms_are_aggregated"},
@@ -35,6 +36,8 @@ func TestAggregation(t *testing.T) {
 		{name: "non_monotonic_sums_are_passed_through"}, // Non-monotonic sums are passed through even when aggregation is enabled
 		{name: "gauges_are_passed_through", passThrough: true},
 		{name: "summaries_are_passed_through", passThrough: true},
+		{name: "delta_sums_are_aggregated", aggregateDeltas: true},
+		{name: "delta_histograms_are_aggregated", aggregateDeltas: true},
 	}
 
 	ctx, cancel := context.WithCancel(t.Context())
@@ -42,7 +45,7 @@ func TestAggregation(t *testing.T) {
 
 	var config *Config
 	for _, tc := range testCases {
-		config = &Config{Interval: time.Second, PassThrough: PassThrough{Gauge: tc.passThrough, Summary: tc.passThrough}}
+		config = &Config{Interval: time.Second, PassThrough: PassThrough{Gauge: tc.passThrough, Summary: tc.passThrough}, AggregateDeltas: tc.aggregateDeltas}
 
 		t.Run(tc.name, func(t *testing.T) {
 			// next stores the results of the filter metric processor
@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegeradaptivesamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/jaegeradaptivesamplingprocessor"
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the Jaeger adaptive sampling processor.
+type Config struct {
+	// Extension is the ID of the jaegerremotesampling extension the traces are
+	// passed to, to calculate the adaptive sampling strategies from.
+	Extension component.ID `mapstructure:"extension"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
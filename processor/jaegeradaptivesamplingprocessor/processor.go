@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegeradaptivesamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/jaegeradaptivesamplingprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// tracesObserver is implemented by the jaegerremotesampling extension, which
+// calculates the adaptive sampling strategies from the observed traces.
+type tracesObserver interface {
+	ObserveTraces(ctx context.Context, td ptrace.Traces)
+}
+
+type adaptiveSamplingProcessor struct {
+	cfg      *Config
+	observer tracesObserver
+}
+
+func newAdaptiveSamplingProcessor(cfg *Config) *adaptiveSamplingProcessor {
+	return &adaptiveSamplingProcessor{cfg: cfg}
+}
+
+// start looks up the extension the traces are passed to.
+func (p *adaptiveSamplingProcessor) start(_ context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[p.cfg.Extension]
+	if !ok {
+		return fmt.Errorf("extension %q not found", p.cfg.Extension)
+	}
+	observer, ok := ext.(tracesObserver)
+	if !ok {
+		return fmt.Errorf("extension %q does not observe traces, it must be a jaegerremotesampling extension", p.cfg.Extension)
+	}
+	p.observer = observer
+	return nil
+}
+
+// processTraces passes the traces to the extension, and forwards them unmodified.
+func (p *adaptiveSamplingProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	p.observer.ObserveTraces(ctx, td)
+	return td, nil
+}
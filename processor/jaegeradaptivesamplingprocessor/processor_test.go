@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegeradaptivesamplingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/jaegeradaptivesamplingprocessor/internal/metadata"
+)
+
+// observerExtension records the traces it observes, like the jaegerremotesampling extension
+type observerExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	observed []ptrace.Traces
+}
+
+func (e *observerExtension) ObserveTraces(_ context.Context, td ptrace.Traces) {
+	e.observed = append(e.observed, td)
+}
+
+type extensionHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func newTracesProcessor(t *testing.T, next *consumertest.TracesSink) processor.Traces {
+	factory := NewFactory()
+	p, err := factory.CreateTraces(t.Context(), processortest.NewNopSettings(metadata.Type), factory.CreateDefaultConfig(), next)
+	require.NoError(t, err)
+	return p
+}
+
+func TestProcessorObservesTraces(t *testing.T) {
+	ext := &observerExtension{}
+	host := &extensionHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{defaultExtension: ext},
+	}
+
+	sink := &consumertest.TracesSink{}
+	p := newTracesProcessor(t, sink)
+	require.NoError(t, p.Start(t.Context(), host))
+	defer func() {
+		require.NoError(t, p.Shutdown(t.Context()))
+	}()
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /")
+	require.NoError(t, p.ConsumeTraces(t.Context(), td))
+
+	require.Len(t, ext.observed, 1)
+	assert.Equal(t, td, ext.observed[0])
+	// the traces are forwarded unmodified
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+}
+
+func TestProcessorExtensionNotFound(t *testing.T) {
+	p := newTracesProcessor(t, &consumertest.TracesSink{})
+	err := p.Start(t.Context(), componenttest.NewNopHost())
+	require.EqualError(t, err, `extension "jaegerremotesampling" not found`)
+	require.NoError(t, p.Shutdown(t.Context()))
+}
+
+func TestProcessorExtensionNotObserver(t *testing.T) {
+	host := &extensionHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{defaultExtension: struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{}},
+	}
+
+	p := newTracesProcessor(t, &consumertest.TracesSink{})
+	err := p.Start(t.Context(), host)
+	require.ErrorContains(t, err, `extension "jaegerremotesampling" does not observe traces`)
+	require.NoError(t, p.Shutdown(t.Context()))
+}
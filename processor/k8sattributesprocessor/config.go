@@ -76,8 +76,12 @@ func (cfg *Config) Validate() error {
 
 		switch f.From {
 		case "", kube.MetadataFromPod, kube.MetadataFromNamespace, kube.MetadataFromNode, kube.MetadataFromDeployment, kube.MetadataFromStatefulSet:
+		case kube.MetadataFromOwner:
+			if len(cfg.Extract.Owners) == 0 {
+				return fmt.Errorf("%s is only a valid choice for From when owners are configured", f.From)
+			}
 		default:
-			return fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, deployment, statefulset, node", f.From)
+			return fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, deployment, statefulset, node, owner", f.From)
 		}
 
 		if f.KeyRegex != "" {
@@ -88,6 +92,12 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	for _, owner := range cfg.Extract.Owners {
+		if owner.Version == "" || owner.Resource == "" || owner.Kind == "" {
+			return fmt.Errorf("owner %q must have a version, a resource and a kind", owner.Resource)
+		}
+	}
+
 	for _, field := range cfg.Extract.Metadata {
 		switch field {
 		case string(conventions.K8SNamespaceNameKey), string(conventions.K8SPodNameKey), string(conventions.K8SPodUIDKey),
@@ -173,6 +183,36 @@ type ExtractConfig struct {
 	// OtelAnnotations extracts all pod annotations with the prefix "resource.opentelemetry.io" as resource attributes
 	// E.g. "resource.opentelemetry.io/foo" becomes "foo"
 	OtelAnnotations bool `mapstructure:"otel_annotations"`
+
+	// Owners allows extracting metadata from resources owning pods, directly or through
+	// other owners, such as custom resources managing the workloads. For example, the pods
+	// of an Argo Rollout are owned by a ReplicaSet, owned by the Rollout.
+	// It is a list of OwnerConfig type. See OwnerConfig documentation for more details.
+	Owners []OwnerConfig `mapstructure:"owners"`
+}
+
+// OwnerConfig identifies a kind of resource owning pods, directly or through other owners.
+// The owner references of the pods are walked through the objects of the configured resources,
+// and of the built-in workloads (replicasets, deployments, statefulsets, daemonsets and jobs).
+//
+// For each object of the configured resources found, the k8s.<kind>.name and k8s.<kind>.uid
+// attributes are added, with the kind in lower case, e.g. k8s.rollout.name. Labels and annotations
+// can be extracted from the objects with from: owner.
+type OwnerConfig struct {
+	// Group is the API group of the resource, e.g. argoproj.io. It is empty for the core group.
+	Group string `mapstructure:"group"`
+
+	// Version is the API version of the resource, e.g. v1alpha1.
+	Version string `mapstructure:"version"`
+
+	// Resource is the plural name of the resource, e.g. rollouts.
+	Resource string `mapstructure:"resource"`
+
+	// Kind is the kind of the resource, as found in owner references, e.g. Rollout.
+	Kind string `mapstructure:"kind"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
 }
 
 // FieldExtractConfig allows specifying an extraction rule to extract a resource attribute from pod (or namespace)
@@ -208,7 +248,7 @@ type FieldExtractConfig struct {
 	KeyRegex string `mapstructure:"key_regex"`
 
 	// From represents the source of the labels/annotations.
-	// Allowed values are "pod", "namespace", and "node". The default is pod.
+	// Allowed values are "pod", "namespace", "node", "deployment", "statefulset" and "owner". The default is pod.
 	From string `mapstructure:"from"`
 }
 
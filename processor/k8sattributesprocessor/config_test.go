@@ -130,6 +130,28 @@ func TestLoadConfig(t *testing.T) {
 				WaitForMetadataTimeout: 10 * time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "owners"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+				Extract: ExtractConfig{
+					Metadata: enabledAttributes(),
+					Owners: []OwnerConfig{
+						{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts", Kind: "Rollout"},
+					},
+					Labels: []FieldExtractConfig{
+						{TagName: "team", Key: "team", From: kube.MetadataFromOwner},
+					},
+				},
+				Exclude: ExcludeConfig{
+					Pods: []ExcludePodConfig{
+						{Name: "jaeger-agent"},
+						{Name: "jaeger-collector"},
+					},
+				},
+				WaitForMetadataTimeout: 10 * time.Second,
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "too_many_sources"),
 		},
@@ -145,6 +167,12 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_from_annotations"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_from_owner_labels"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_owner"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_keyregex_labels"),
 		},
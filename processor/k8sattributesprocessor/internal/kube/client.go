@@ -21,9 +21,11 @@ import (
 	apps_v1 "k8s.io/api/apps/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
@@ -57,12 +59,14 @@ type WatchClient struct {
 	deleteMut              sync.Mutex
 	logger                 *zap.Logger
 	kc                     kubernetes.Interface
+	dc                     dynamic.Interface
 	informer               cache.SharedInformer
 	namespaceInformer      cache.SharedInformer
 	nodeInformer           cache.SharedInformer
 	deploymentInformer     cache.SharedInformer
 	statefulsetInformer    cache.SharedInformer
 	replicasetInformer     cache.SharedInformer
+	ownerInformers         []ownerInformer
 	replicasetRegex        *regexp.Regexp
 	cronJobRegex           *regexp.Regexp
 	deleteQueue            []deleteRequest
@@ -98,6 +102,10 @@ type WatchClient struct {
 	// Key is replicaset uid
 	ReplicaSets map[string]*ReplicaSet
 
+	// A map containing the owners of pods, watched to walk the owner chains.
+	// Key is owner uid
+	Owners map[string]*Owner
+
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
@@ -115,6 +123,8 @@ type InformersFactoryList struct {
 	newInformer           InformerProvider
 	newNamespaceInformer  InformerProviderNamespace
 	newReplicaSetInformer InformerProviderWorkload
+	newOwnerInformer      InformerProviderOwner
+	newDynamicClient      APIDynamicClientProvider
 }
 
 // New initializes a new k8s Client.
@@ -155,6 +165,7 @@ func New(
 	c.ReplicaSets = map[string]*ReplicaSet{}
 	c.Deployments = map[string]*Deployment{}
 	c.StatefulSets = map[string]*StatefulSet{}
+	c.Owners = map[string]*Owner{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -210,7 +221,7 @@ func New(
 
 	c.namespaceInformer = informersFactory.newNamespaceInformer(c.kc)
 
-	if rules.DeploymentName || rules.DeploymentUID {
+	if c.watchReplicaSets() {
 		if informersFactory.newReplicaSetInformer == nil {
 			informersFactory.newReplicaSetInformer = newReplicaSetSharedInformer
 		}
@@ -242,6 +253,37 @@ func New(
 		c.statefulsetInformer = newStatefulSetSharedInformer(c.kc, c.Filters.Namespace)
 	}
 
+	if len(rules.OwnerResources) > 0 {
+		if informersFactory.newDynamicClient == nil {
+			informersFactory.newDynamicClient = k8sconfig.MakeDynamicClient
+		}
+		if informersFactory.newOwnerInformer == nil {
+			informersFactory.newOwnerInformer = newOwnerSharedInformer
+		}
+		c.dc, err = informersFactory.newDynamicClient(apiCfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, oi := range ownerResourcesToWatch(rules.OwnerResources) {
+			oi.informer = informersFactory.newOwnerInformer(c.dc, oi.resource.groupVersionResource(), c.Filters.Namespace)
+			transit := oi.transit
+			err = oi.informer.SetTransform(
+				func(object any) (any, error) {
+					originalObject, success := object.(*unstructured.Unstructured)
+					if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+						return object, nil
+					}
+
+					return removeUnnecessaryOwnerData(originalObject, transit), nil
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+			c.ownerInformers = append(c.ownerInformers, oi)
+		}
+	}
+
 	return c, err
 }
 
@@ -250,7 +292,7 @@ func (c *WatchClient) Start() error {
 	synced := make([]cache.InformerSynced, 0)
 	// start the replicaSet informer first, as the replica sets need to be
 	// present at the time the pods are handled, to correctly establish the connection between pods and deployments
-	if c.Rules.DeploymentName || c.Rules.DeploymentUID {
+	if c.watchReplicaSets() {
 		reg, err := c.replicasetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handleReplicaSetAdd,
 			UpdateFunc: c.handleReplicaSetUpdate,
@@ -313,6 +355,16 @@ func (c *WatchClient) Start() error {
 		go c.statefulsetInformer.Run(c.stopCh)
 	}
 
+	// the owners need to be present at the time the pods are handled as well, to walk the owner chains
+	for _, oi := range c.ownerInformers {
+		reg, err = oi.informer.AddEventHandler(c.ownerEventHandler(oi))
+		if err != nil {
+			return err
+		}
+		synced = append(synced, reg.HasSynced)
+		go oi.informer.Run(c.stopCh)
+	}
+
 	reg, err = c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
@@ -727,6 +779,10 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		}
 	}
 
+	if len(c.Rules.OwnerResources) > 0 {
+		c.extractPodOwnersAttributes(pod, tags)
+	}
+
 	if c.Rules.Node {
 		tags[tagNodeName] = pod.Spec.NodeName
 	}
@@ -1407,6 +1463,12 @@ func needContainerAttributes(rules ExtractionRules) bool {
 		rules.ServiceInstanceID
 }
 
+// watchReplicaSets reports whether the replica sets are needed, to find the deployments of
+// the pods, or to walk their owner chains to the configured owner resources.
+func (c *WatchClient) watchReplicaSets() bool {
+	return c.Rules.DeploymentName || c.Rules.DeploymentUID || len(c.Rules.OwnerResources) > 0
+}
+
 func (c *WatchClient) handleReplicaSetAdd(obj any) {
 	c.telemetryBuilder.OtelsvcK8sReplicasetAdded.Add(context.Background(), 1)
 	if replicaset, ok := obj.(*apps_v1.ReplicaSet); ok {
@@ -1453,6 +1515,11 @@ func (c *WatchClient) addOrUpdateReplicaSet(replicaset *apps_v1.ReplicaSet) {
 			break
 		}
 	}
+	if len(c.Rules.OwnerResources) > 0 {
+		for _, ownerReference := range replicaset.OwnerReferences {
+			newReplicaSet.OwnerUIDs = append(newReplicaSet.OwnerUIDs, string(ownerReference.UID))
+		}
+	}
 
 	c.m.Lock()
 	if replicaset.UID != "" {
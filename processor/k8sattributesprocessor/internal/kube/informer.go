@@ -12,7 +12,10 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 )
@@ -48,6 +51,15 @@ type InformerProviderWorkload func(
 	namespace string,
 ) cache.SharedInformer
 
+// InformerProviderOwner defines a function type that returns a new SharedInformer. It is used to
+// allow passing custom shared informers to the watch client for fetching the owners of pods.
+// It's used for arbitrary resources, e.g. custom resources such as Argo Rollouts.
+type InformerProviderOwner func(
+	client dynamic.Interface,
+	resource schema.GroupVersionResource,
+	namespace string,
+) cache.SharedInformer
+
 func newSharedInformer(
 	client kubernetes.Interface,
 	namespace string,
@@ -208,3 +220,11 @@ func statefulsetWatchFuncWithSelectors(client kubernetes.Interface, namespace st
 		return client.AppsV1().StatefulSets(namespace).Watch(context.Background(), opts)
 	}
 }
+
+func newOwnerSharedInformer(
+	client dynamic.Interface,
+	resource schema.GroupVersionResource,
+	namespace string,
+) cache.SharedInformer {
+	return dynamicinformer.NewFilteredDynamicInformer(client, resource, namespace, watchSyncPeriod, cache.Indexers{}, nil).Informer()
+}
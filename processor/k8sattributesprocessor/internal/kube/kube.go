@@ -10,7 +10,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -33,7 +35,9 @@ const (
 	MetadataFromDeployment = "deployment"
 	// MetadataFromStatefulSet is used to specify to extract metadata/labels/annotations from statefulset
 	MetadataFromStatefulSet = "statefulset"
-	PodIdentifierMaxLength  = 4
+	// MetadataFromOwner is used to specify to extract metadata/labels/annotations from the configured owner resources
+	MetadataFromOwner      = "owner"
+	PodIdentifierMaxLength = 4
 
 	ResourceSource   = "resource_attribute"
 	ConnectionSource = "connection"
@@ -108,6 +112,10 @@ type ClientProvider func(component.TelemetrySettings, k8sconfig.APIConfig, Extra
 // Clientset object.
 type APIClientsetProvider func(config k8sconfig.APIConfig) (kubernetes.Interface, error)
 
+// APIDynamicClientProvider defines a func type that initializes and return a new kubernetes
+// dynamic client.
+type APIDynamicClientProvider func(config k8sconfig.APIConfig) (dynamic.Interface, error)
+
 // Pod represents a kubernetes pod.
 type Pod struct {
 	Name           string
@@ -255,6 +263,10 @@ type ExtractionRules struct {
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
+
+	// OwnerResources are the kinds of resources, typically custom resources, owning pods
+	// directly or through other owners, that metadata is extracted from.
+	OwnerResources []OwnerResource
 }
 
 // IncludesOwnerMetadata determines whether the ExtractionRules include metadata about Pod Owners
@@ -277,7 +289,7 @@ func (rules *ExtractionRules) IncludesOwnerMetadata() bool {
 			return true
 		}
 	}
-	return rules.ServiceName
+	return rules.ServiceName || len(rules.OwnerResources) > 0
 }
 
 // FieldExtractionRule is used to specify which fields to extract from pod fields
@@ -300,6 +312,7 @@ type FieldExtractionRule struct {
 	//  - node
 	//  - deployment
 	//  - statefulset
+	//  - owner
 	From string
 }
 
@@ -334,6 +347,12 @@ func (r *FieldExtractionRule) extractFromStatefulSetMetadata(metadata, tags map[
 	}
 }
 
+func (r *FieldExtractionRule) extractFromOwnerMetadata(metadata, tags map[string]string, formatter string) {
+	if r.From == MetadataFromOwner {
+		r.extractFromMetadata(metadata, tags, formatter)
+	}
+}
+
 func (r *FieldExtractionRule) extractFromMetadata(metadata, tags map[string]string, formatter string) {
 	if r.KeyRegex != nil {
 		for k, v := range metadata {
@@ -406,6 +425,9 @@ type ReplicaSet struct {
 	Namespace  string
 	UID        string
 	Deployment Deployment
+	// OwnerUIDs are the UIDs of the owners of the replica set, to walk the owner chains
+	// of the pods when owner resources are configured.
+	OwnerUIDs []string
 }
 
 // StatefulSet represents a kubernetes statefulset.
@@ -415,6 +437,31 @@ type StatefulSet struct {
 	Attributes map[string]string
 }
 
+// OwnerResource identifies a kind of resource that owns pods, directly or through other owners.
+type OwnerResource struct {
+	Group    string
+	Version  string
+	Resource string
+	// Kind is the kind of the resource in owner references, e.g. Rollout.
+	Kind string
+}
+
+func (r OwnerResource) groupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+// Owner represents a kubernetes object owning pods, directly or through other owners.
+type Owner struct {
+	Kind string
+	Name string
+	UID  string
+	// OwnerUIDs are the uids of the owners of this object.
+	OwnerUIDs []string
+	// Attributes holds the attributes extracted from the object. It is nil for the objects
+	// that are only walked through to reach the configured owner resources, e.g. replicasets.
+	Attributes map[string]string
+}
+
 func OtelAnnotations() FieldExtractionRule {
 	return FieldExtractionRule{
 		Name:                 "$1",
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	K8sOwnerName        = "k8s.%s.name"
+	K8sOwnerUID         = "k8s.%s.uid"
+	K8sOwnerLabels      = "k8s.owner.labels.%s"
+	K8sOwnerAnnotations = "k8s.owner.annotations.%s"
+
+	// maxOwnerChainDepth limits the number of owners walked through from a pod,
+	// to protect from owner reference cycles.
+	maxOwnerChainDepth = 10
+)
+
+// transitOwnerResources are the built-in workloads commonly found between pods and
+// the custom resources managing them, e.g. Pod -> Deployment -> Revision for Knative,
+// or Pod -> Job -> ScaledJob for KEDA. They are watched to walk the owner chains, but
+// no attributes are extracted from them. The replica sets, e.g. in Pod -> ReplicaSet
+// -> Rollout for Argo Rollouts, are walked through with the replica set informer.
+var transitOwnerResources = []OwnerResource{
+	{Group: "apps", Version: "v1", Resource: "deployments", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets", Kind: "StatefulSet"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets", Kind: "DaemonSet"},
+	{Group: "batch", Version: "v1", Resource: "jobs", Kind: "Job"},
+}
+
+// ownerInformer watches the objects of one owner resource.
+type ownerInformer struct {
+	resource OwnerResource
+	// transit reports whether the objects are only walked through.
+	transit  bool
+	informer cache.SharedInformer
+}
+
+// ownerResourcesToWatch returns the configured owner resources, followed by the
+// transit resources that are not configured.
+func ownerResourcesToWatch(resources []OwnerResource) []ownerInformer {
+	var informers []ownerInformer
+	configured := map[OwnerResource]bool{}
+	for _, resource := range resources {
+		key := OwnerResource{Group: resource.Group, Version: resource.Version, Resource: resource.Resource}
+		if configured[key] {
+			continue
+		}
+		configured[key] = true
+		informers = append(informers, ownerInformer{resource: resource})
+	}
+	for _, resource := range transitOwnerResources {
+		key := OwnerResource{Group: resource.Group, Version: resource.Version, Resource: resource.Resource}
+		if !configured[key] {
+			informers = append(informers, ownerInformer{resource: resource, transit: true})
+		}
+	}
+	return informers
+}
+
+func (c *WatchClient) ownerEventHandler(oi ownerInformer) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			c.handleOwnerAddOrUpdate(oi, obj)
+		},
+		UpdateFunc: func(_, newObj any) {
+			c.handleOwnerAddOrUpdate(oi, newObj)
+		},
+		DeleteFunc: func(obj any) {
+			c.handleOwnerDelete(oi, obj)
+		},
+	}
+}
+
+func (c *WatchClient) handleOwnerAddOrUpdate(oi ownerInformer, obj any) {
+	if object, ok := obj.(*unstructured.Unstructured); ok {
+		c.addOrUpdateOwner(oi, object)
+	} else {
+		c.logger.Error("object received was not of type unstructured.Unstructured", zap.String("kind", oi.resource.Kind), zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleOwnerDelete(oi ownerInformer, obj any) {
+	if object, ok := ignoreDeletedFinalStateUnknown(obj).(*unstructured.Unstructured); ok {
+		c.m.Lock()
+		delete(c.Owners, string(object.GetUID()))
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type unstructured.Unstructured", zap.String("kind", oi.resource.Kind), zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) addOrUpdateOwner(oi ownerInformer, object *unstructured.Unstructured) {
+	newOwner := &Owner{
+		Kind: oi.resource.Kind,
+		Name: object.GetName(),
+		UID:  string(object.GetUID()),
+	}
+	for _, ref := range object.GetOwnerReferences() {
+		newOwner.OwnerUIDs = append(newOwner.OwnerUIDs, string(ref.UID))
+	}
+	if !oi.transit {
+		newOwner.Attributes = c.extractOwnerAttributes(newOwner, object)
+	}
+
+	c.m.Lock()
+	if newOwner.UID != "" {
+		c.Owners[newOwner.UID] = newOwner
+	}
+	c.m.Unlock()
+}
+
+func (c *WatchClient) extractOwnerAttributes(owner *Owner, object *unstructured.Unstructured) map[string]string {
+	kind := strings.ToLower(owner.Kind)
+	tags := map[string]string{
+		fmt.Sprintf(K8sOwnerName, kind): owner.Name,
+		fmt.Sprintf(K8sOwnerUID, kind):  owner.UID,
+	}
+
+	for _, r := range c.Rules.Labels {
+		r.extractFromOwnerMetadata(object.GetLabels(), tags, K8sOwnerLabels)
+	}
+
+	for _, r := range c.Rules.Annotations {
+		r.extractFromOwnerMetadata(object.GetAnnotations(), tags, K8sOwnerAnnotations)
+	}
+
+	return tags
+}
+
+// getOwnerChain walks the owner references through the watched owners, and returns
+// the owners found, from the closest to the pod to the outermost one.
+func (c *WatchClient) getOwnerChain(refs []meta_v1.OwnerReference) []*Owner {
+	uids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		uids = append(uids, string(ref.UID))
+	}
+
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	var chain []*Owner
+	visited := map[string]bool{}
+	for depth := 0; len(uids) > 0 && depth < maxOwnerChainDepth; depth++ {
+		var next []string
+		for _, uid := range uids {
+			if visited[uid] {
+				continue
+			}
+			visited[uid] = true
+			if owner, ok := c.Owners[uid]; ok {
+				chain = append(chain, owner)
+				next = append(next, owner.OwnerUIDs...)
+			} else if replicaset, ok := c.ReplicaSets[uid]; ok {
+				next = append(next, replicaset.OwnerUIDs...)
+			}
+		}
+		uids = next
+	}
+	return chain
+}
+
+// extractPodOwnersAttributes adds the attributes of the configured owner resources
+// found in the owner chain of the pod. The owners closest to the pod take precedence,
+// except for the service name, which is the name of the outermost owner.
+func (c *WatchClient) extractPodOwnersAttributes(pod meta_v1.Object, tags map[string]string) {
+	chain := c.getOwnerChain(pod.GetOwnerReferences())
+	serviceName := ""
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].Attributes == nil {
+			continue
+		}
+		if serviceName == "" {
+			serviceName = chain[i].Name
+		}
+		maps.Copy(tags, chain[i].Attributes)
+	}
+	if c.Rules.ServiceName && serviceName != "" {
+		tags[string(conventions.ServiceNameKey)] = serviceName
+	}
+}
+
+// This function removes all data from the owner except what is required by extraction rules
+func removeUnnecessaryOwnerData(object *unstructured.Unstructured, transit bool) *unstructured.Unstructured {
+	transformedObject := &unstructured.Unstructured{}
+	transformedObject.SetAPIVersion(object.GetAPIVersion())
+	transformedObject.SetKind(object.GetKind())
+	transformedObject.SetName(object.GetName())
+	transformedObject.SetNamespace(object.GetNamespace())
+	transformedObject.SetUID(object.GetUID())
+	transformedObject.SetResourceVersion(object.GetResourceVersion())
+	transformedObject.SetOwnerReferences(object.GetOwnerReferences())
+	if !transit {
+		transformedObject.SetLabels(object.GetLabels())
+		transformedObject.SetAnnotations(object.GetAnnotations())
+	}
+	return transformedObject
+}
@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	apps_v1 "k8s.io/api/apps/v1"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+var (
+	rolloutResource    = OwnerResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts", Kind: "Rollout"}
+	revisionResource   = OwnerResource{Group: "serving.knative.dev", Version: "v1", Resource: "revisions", Kind: "Revision"}
+	serviceResource    = OwnerResource{Group: "serving.knative.dev", Version: "v1", Resource: "services", Kind: "Service"}
+	replicaSetResource = OwnerResource{Group: "apps", Version: "v1", Resource: "replicasets", Kind: "ReplicaSet"}
+)
+
+func newOwnerObject(resource OwnerResource, name, uid string, owners ...*unstructured.Unstructured) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(schema.GroupVersion{Group: resource.Group, Version: resource.Version}.String())
+	object.SetKind(resource.Kind)
+	object.SetNamespace("default")
+	object.SetName(name)
+	object.SetUID(types.UID(uid))
+	var refs []meta_v1.OwnerReference
+	for _, owner := range owners {
+		refs = append(refs, meta_v1.OwnerReference{
+			APIVersion: owner.GetAPIVersion(),
+			Kind:       owner.GetKind(),
+			Name:       owner.GetName(),
+			UID:        owner.GetUID(),
+		})
+	}
+	object.SetOwnerReferences(refs)
+	return object
+}
+
+// newReplicaSet returns the replica set received by the replica set informer for an owner object.
+func newReplicaSet(object *unstructured.Unstructured) *apps_v1.ReplicaSet {
+	return &apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            object.GetName(),
+			Namespace:       object.GetNamespace(),
+			UID:             object.GetUID(),
+			OwnerReferences: object.GetOwnerReferences(),
+		},
+	}
+}
+
+func newOwnedPod(owner *unstructured.Unstructured) *api_v1.Pod {
+	pod := &api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      owner.GetName() + "-xyz",
+			Namespace: "default",
+			UID:       types.UID(owner.GetName() + "-pod-uid"),
+			OwnerReferences: []meta_v1.OwnerReference{{
+				APIVersion: owner.GetAPIVersion(),
+				Kind:       owner.GetKind(),
+				Name:       owner.GetName(),
+				UID:        owner.GetUID(),
+			}},
+		},
+	}
+	pod.Status.PodIP = "1.1.1.1"
+	return pod
+}
+
+func newTestClientWithOwners(t *testing.T, rules ExtractionRules, dc dynamic.Interface) *WatchClient {
+	factory := InformersFactoryList{
+		newInformer:           NewFakeInformer,
+		newNamespaceInformer:  NewFakeNamespaceInformer,
+		newReplicaSetInformer: NewFakeReplicaSetInformer,
+		newDynamicClient: func(k8sconfig.APIConfig) (dynamic.Interface, error) {
+			return dc, nil
+		},
+	}
+	associations := []Association{
+		{
+			Sources: []AssociationSource{
+				{
+					From: "connection",
+				},
+			},
+		},
+	}
+	c, err := New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, rules, Filters{}, associations, Excludes{}, newFakeAPIClientset, factory, false, 10*time.Second)
+	require.NoError(t, err)
+	return c.(*WatchClient)
+}
+
+func newFakeDynamicClient(objects ...runtime.Object) dynamic.Interface {
+	gvrToListKind := map[schema.GroupVersionResource]string{}
+	for _, resource := range append([]OwnerResource{rolloutResource, revisionResource, serviceResource, replicaSetResource}, transitOwnerResources...) {
+		gvrToListKind[resource.groupVersionResource()] = resource.Kind + "List"
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), gvrToListKind, objects...)
+}
+
+func TestOwnerResourcesToWatch(t *testing.T) {
+	informers := ownerResourcesToWatch([]OwnerResource{rolloutResource, transitOwnerResources[0], rolloutResource})
+
+	var resources []OwnerResource
+	var transit []bool
+	for _, oi := range informers {
+		resources = append(resources, oi.resource)
+		transit = append(transit, oi.transit)
+	}
+	assert.Equal(t, []OwnerResource{rolloutResource, transitOwnerResources[0], transitOwnerResources[1], transitOwnerResources[2], transitOwnerResources[3]}, resources)
+	assert.Equal(t, []bool{false, false, true, true, true}, transit)
+
+	// the replica sets are walked through with the replica set informer, they are only watched
+	// as owners when configured
+	assert.NotContains(t, transitOwnerResources, replicaSetResource)
+	informers = ownerResourcesToWatch([]OwnerResource{replicaSetResource})
+	assert.Equal(t, replicaSetResource, informers[0].resource)
+	assert.False(t, informers[0].transit)
+}
+
+func TestOwnerExtractionRules(t *testing.T) {
+	rollout := newOwnerObject(rolloutResource, "checkout", "rollout-uid")
+	rollout.SetLabels(map[string]string{"team": "payments"})
+	rollout.SetAnnotations(map[string]string{"owner": "alice"})
+	rolloutReplicaSet := newOwnerObject(replicaSetResource, "checkout-5d8f7", "rollout-rs-uid", rollout)
+
+	service := newOwnerObject(serviceResource, "hello", "service-uid")
+	service.SetLabels(map[string]string{"team": "serverless"})
+	revision := newOwnerObject(revisionResource, "hello-00001", "revision-uid", service)
+	revision.SetLabels(map[string]string{"team": "revisions"})
+	deployment := newOwnerObject(transitOwnerResources[0], "hello-00001-deployment", "deployment-uid", revision)
+	revisionReplicaSet := newOwnerObject(replicaSetResource, "hello-00001-deployment-7c9b", "revision-rs-uid", deployment)
+
+	testCases := []struct {
+		name       string
+		rules      ExtractionRules
+		pod        *api_v1.Pod
+		attributes map[string]string
+	}{
+		{
+			name:  "names and uids",
+			rules: ExtractionRules{OwnerResources: []OwnerResource{rolloutResource}},
+			pod:   newOwnedPod(rolloutReplicaSet),
+			attributes: map[string]string{
+				"k8s.rollout.name": "checkout",
+				"k8s.rollout.uid":  "rollout-uid",
+			},
+		},
+		{
+			name: "labels and annotations",
+			rules: ExtractionRules{
+				OwnerResources: []OwnerResource{rolloutResource},
+				Annotations: []FieldExtractionRule{
+					{
+						Name: "a1",
+						Key:  "owner",
+						From: MetadataFromOwner,
+					},
+				},
+				Labels: []FieldExtractionRule{
+					{
+						KeyRegex: regexp.MustCompile("^(?:te.*)$"),
+						From:     MetadataFromOwner,
+					},
+				},
+			},
+			pod: newOwnedPod(rolloutReplicaSet),
+			attributes: map[string]string{
+				"k8s.rollout.name":      "checkout",
+				"k8s.rollout.uid":       "rollout-uid",
+				"a1":                    "alice",
+				"k8s.owner.labels.team": "payments",
+			},
+		},
+		{
+			name: "service name from the outermost owner",
+			rules: ExtractionRules{
+				OwnerResources: []OwnerResource{revisionResource, serviceResource},
+				ServiceName:    true,
+			},
+			pod: newOwnedPod(revisionReplicaSet),
+			attributes: map[string]string{
+				"k8s.revision.name": "hello-00001",
+				"k8s.revision.uid":  "revision-uid",
+				"k8s.service.name":  "hello",
+				"k8s.service.uid":   "service-uid",
+				"service.name":      "hello",
+			},
+		},
+		{
+			name: "labels from the closest owner",
+			rules: ExtractionRules{
+				OwnerResources: []OwnerResource{revisionResource, serviceResource},
+				Labels: []FieldExtractionRule{
+					{
+						Name: "team",
+						Key:  "team",
+						From: MetadataFromOwner,
+					},
+				},
+			},
+			pod: newOwnedPod(revisionReplicaSet),
+			attributes: map[string]string{
+				"k8s.revision.name": "hello-00001",
+				"k8s.revision.uid":  "revision-uid",
+				"k8s.service.name":  "hello",
+				"k8s.service.uid":   "service-uid",
+				"team":              "revisions",
+			},
+		},
+		{
+			name:       "owner not configured",
+			rules:      ExtractionRules{OwnerResources: []OwnerResource{serviceResource}},
+			pod:        newOwnedPod(rolloutReplicaSet),
+			attributes: map[string]string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClientWithOwners(t, tc.rules, newFakeDynamicClient())
+			c.handleReplicaSetAdd(newReplicaSet(rolloutReplicaSet))
+			c.handleReplicaSetAdd(newReplicaSet(revisionReplicaSet))
+			for _, oi := range c.ownerInformers {
+				for _, object := range []*unstructured.Unstructured{rollout, rolloutReplicaSet, service, revision, deployment, revisionReplicaSet} {
+					if object.GetKind() == oi.resource.Kind {
+						c.handleOwnerAddOrUpdate(oi, object)
+					}
+				}
+			}
+
+			c.handlePodAdd(tc.pod)
+			p, ok := c.GetPod(newPodIdentifier("connection", "", "1.1.1.1"))
+			require.True(t, ok)
+			assert.Equal(t, tc.attributes, p.Attributes)
+		})
+	}
+}
+
+func TestOwnerChainCycle(t *testing.T) {
+	c := newTestClientWithOwners(t, ExtractionRules{OwnerResources: []OwnerResource{rolloutResource}}, newFakeDynamicClient())
+
+	first := newOwnerObject(rolloutResource, "first", "first-uid")
+	second := newOwnerObject(rolloutResource, "second", "second-uid", first)
+	first = newOwnerObject(rolloutResource, "first", "first-uid", second)
+	c.handleOwnerAddOrUpdate(c.ownerInformers[0], first)
+	c.handleOwnerAddOrUpdate(c.ownerInformers[0], second)
+
+	chain := c.getOwnerChain(newOwnedPod(first).OwnerReferences)
+	require.Len(t, chain, 2)
+	assert.Equal(t, "first", chain[0].Name)
+	assert.Equal(t, "second", chain[1].Name)
+}
+
+func TestOwnerDelete(t *testing.T) {
+	c := newTestClientWithOwners(t, ExtractionRules{OwnerResources: []OwnerResource{rolloutResource}}, newFakeDynamicClient())
+
+	rollout := newOwnerObject(rolloutResource, "checkout", "rollout-uid")
+	c.handleOwnerAddOrUpdate(c.ownerInformers[0], rollout)
+	assert.Len(t, c.Owners, 1)
+
+	c.handleOwnerDelete(c.ownerInformers[0], rollout)
+	assert.Empty(t, c.Owners)
+
+	c.handleOwnerAddOrUpdate(c.ownerInformers[0], rollout)
+	c.handleOwnerDelete(c.ownerInformers[0], cache.DeletedFinalStateUnknown{Obj: rollout})
+	assert.Empty(t, c.Owners)
+}
+
+func TestOwnerInformers(t *testing.T) {
+	rollout := newOwnerObject(rolloutResource, "checkout", "rollout-uid")
+	rollout.SetLabels(map[string]string{"team": "payments"})
+	replicaset := newOwnerObject(replicaSetResource, "checkout-5d8f7", "rs-uid", rollout)
+
+	rules := ExtractionRules{
+		OwnerResources: []OwnerResource{rolloutResource},
+		Labels: []FieldExtractionRule{
+			{
+				Name: "team",
+				Key:  "team",
+				From: MetadataFromOwner,
+			},
+		},
+	}
+	c := newTestClientWithOwners(t, rules, newFakeDynamicClient(rollout))
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	// the replica sets aren't watched a second time through the dynamic client
+	for _, oi := range c.ownerInformers {
+		assert.NotEqual(t, replicaSetResource, oi.resource)
+	}
+
+	assert.Eventually(t, func() bool {
+		c.m.RLock()
+		defer c.m.RUnlock()
+		return len(c.Owners) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	c.handleReplicaSetAdd(newReplicaSet(replicaset))
+	rs, ok := c.getReplicaSet("rs-uid")
+	require.True(t, ok)
+	assert.Equal(t, []string{"rollout-uid"}, rs.OwnerUIDs)
+
+	c.handlePodAdd(newOwnedPod(replicaset))
+	p, ok := c.GetPod(newPodIdentifier("connection", "", "1.1.1.1"))
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"k8s.rollout.name": "checkout",
+		"k8s.rollout.uid":  "rollout-uid",
+		"team":             "payments",
+	}, p.Attributes)
+}
+
+func TestRemoveUnnecessaryOwnerData(t *testing.T) {
+	rollout := newOwnerObject(rolloutResource, "checkout", "rollout-uid")
+	rollout.SetLabels(map[string]string{"team": "payments"})
+	rollout.SetAnnotations(map[string]string{"owner": "alice"})
+	rollout.Object["spec"] = map[string]any{"replicas": int64(3)}
+
+	transformed := removeUnnecessaryOwnerData(rollout, false)
+	assert.NotContains(t, transformed.Object, "spec")
+	assert.Equal(t, rollout.GetLabels(), transformed.GetLabels())
+	assert.Equal(t, rollout.GetAnnotations(), transformed.GetAnnotations())
+
+	transformed = removeUnnecessaryOwnerData(rollout, true)
+	assert.Empty(t, transformed.GetLabels())
+	assert.Empty(t, transformed.GetAnnotations())
+	assert.Equal(t, rollout.GetUID(), transformed.GetUID())
+}
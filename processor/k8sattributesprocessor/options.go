@@ -286,6 +286,21 @@ func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.F
 	return rules, nil
 }
 
+// withExtractOwners allows specifying the resources owning pods to extract metadata from.
+func withExtractOwners(owners ...OwnerConfig) option {
+	return func(p *kubernetesprocessor) error {
+		for _, owner := range owners {
+			p.rules.OwnerResources = append(p.rules.OwnerResources, kube.OwnerResource{
+				Group:    owner.Group,
+				Version:  owner.Version,
+				Resource: owner.Resource,
+				Kind:     owner.Kind,
+			})
+		}
+		return nil
+	}
+}
+
 // withFilterNode allows specifying options to control filtering pods by a node/host.
 func withFilterNode(node, nodeFromEnvVar string) option {
 	return func(p *kubernetesprocessor) error {
@@ -779,3 +779,16 @@ func TestOtelAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestWithExtractOwners(t *testing.T) {
+	p := &kubernetesprocessor{}
+	opt := withExtractOwners(
+		OwnerConfig{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts", Kind: "Rollout"},
+		OwnerConfig{Group: "rabbitmq.com", Version: "v1beta1", Resource: "rabbitmqclusters", Kind: "RabbitmqCluster"},
+	)
+	assert.NoError(t, opt(p))
+	assert.Equal(t, []kube.OwnerResource{
+		{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts", Kind: "Rollout"},
+		{Group: "rabbitmq.com", Version: "v1beta1", Resource: "rabbitmqclusters", Kind: "RabbitmqCluster"},
+	}, p.rules.OwnerResources)
+}
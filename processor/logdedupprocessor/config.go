@@ -29,6 +29,12 @@ const (
 
 	// attributeField is the name of the attribute field
 	attributeField = "attributes"
+
+	// defaultSimilarityThreshold is the default share of tokens a log body must have in common with a template to match it
+	defaultSimilarityThreshold = 0.5
+
+	// defaultMaxSamples is the default number of samples of variable values kept per template
+	defaultMaxSamples = 3
 )
 
 // Config errors
@@ -37,16 +43,31 @@ var (
 	errInvalidInterval          = errors.New("interval must be greater than 0")
 	errCannotExcludeBody        = errors.New("cannot exclude the entire body")
 	errCannotIncludeBody        = errors.New("cannot include the entire body")
+	errInvalidSimilarity        = errors.New("templates.similarity_threshold must be greater than 0 and at most 1")
+	errInvalidMaxSamples        = errors.New("templates.max_samples must not be negative")
+	errTemplatesIncludeFields   = errors.New("cannot use templates with include_fields")
 )
 
 // Config is the config of the processor.
 type Config struct {
-	LogCountAttribute string        `mapstructure:"log_count_attribute"`
-	Interval          time.Duration `mapstructure:"interval"`
-	Timezone          string        `mapstructure:"timezone"`
-	ExcludeFields     []string      `mapstructure:"exclude_fields"`
-	IncludeFields     []string      `mapstructure:"include_fields"`
-	Conditions        []string      `mapstructure:"conditions"`
+	LogCountAttribute string          `mapstructure:"log_count_attribute"`
+	Interval          time.Duration   `mapstructure:"interval"`
+	Timezone          string          `mapstructure:"timezone"`
+	ExcludeFields     []string        `mapstructure:"exclude_fields"`
+	IncludeFields     []string        `mapstructure:"include_fields"`
+	Conditions        []string        `mapstructure:"conditions"`
+	Templates         TemplatesConfig `mapstructure:"templates"`
+}
+
+// TemplatesConfig is the config of the template based deduplication.
+type TemplatesConfig struct {
+	// Enabled clusters the string bodies of logs into templates, and deduplicates logs by template
+	// rather than by identical body.
+	Enabled bool `mapstructure:"enabled"`
+	// SimilarityThreshold is the minimum share of tokens a body must have in common with a template to match it.
+	SimilarityThreshold float64 `mapstructure:"similarity_threshold"`
+	// MaxSamples is the maximum number of samples of the variable values of a template added to the emitted log.
+	MaxSamples int `mapstructure:"max_samples"`
 }
 
 // createDefaultConfig returns the default config for the processor.
@@ -58,6 +79,10 @@ func createDefaultConfig() component.Config {
 		ExcludeFields:     []string{},
 		IncludeFields:     []string{},
 		Conditions:        []string{},
+		Templates: TemplatesConfig{
+			SimilarityThreshold: defaultSimilarityThreshold,
+			MaxSamples:          defaultMaxSamples,
+		},
 	}
 }
 
@@ -90,7 +115,7 @@ func (c Config) Validate() error {
 		return err
 	}
 
-	return nil
+	return c.validateTemplates()
 }
 
 // validateExcludeFields validates that all the exclude fields
@@ -146,3 +171,25 @@ func (c Config) validateIncludeFields() error {
 
 	return nil
 }
+
+// validateTemplates validates the template based deduplication config
+func (c Config) validateTemplates() error {
+	if !c.Templates.Enabled {
+		return nil
+	}
+
+	if c.Templates.SimilarityThreshold <= 0 || c.Templates.SimilarityThreshold > 1 {
+		return errInvalidSimilarity
+	}
+
+	if c.Templates.MaxSamples < 0 {
+		return errInvalidMaxSamples
+	}
+
+	// include_fields replaces the body in the deduplication key, so there is nothing to template
+	if len(c.IncludeFields) > 0 {
+		return errTemplatesIncludeFields
+	}
+
+	return nil
+}
@@ -16,6 +16,9 @@ func TestCreateDefaultProcessorConfig(t *testing.T) {
 	require.Equal(t, defaultLogCountAttribute, cfg.LogCountAttribute)
 	require.Equal(t, defaultTimezone, cfg.Timezone)
 	require.Equal(t, []string{}, cfg.ExcludeFields)
+	require.False(t, cfg.Templates.Enabled)
+	require.Equal(t, defaultSimilarityThreshold, cfg.Templates.SimilarityThreshold)
+	require.Equal(t, defaultMaxSamples, cfg.Templates.MaxSamples)
 }
 
 func TestValidateConfig(t *testing.T) {
@@ -148,6 +151,59 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: errors.New("cannot define both exclude_fields and include_fields"),
 		},
+		{
+			desc: "valid config templates",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				ExcludeFields:     []string{"attributes.otherthing"},
+				Templates:         TemplatesConfig{Enabled: true, SimilarityThreshold: 0.4, MaxSamples: 0},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "disabled templates are not validated",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				IncludeFields:     []string{"attributes.otherthing"},
+				Templates:         TemplatesConfig{SimilarityThreshold: 2},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "invalid templates similarity_threshold",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates:         TemplatesConfig{Enabled: true, SimilarityThreshold: 1.5, MaxSamples: defaultMaxSamples},
+			},
+			expectedErr: errInvalidSimilarity,
+		},
+		{
+			desc: "invalid templates max_samples",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates:         TemplatesConfig{Enabled: true, SimilarityThreshold: defaultSimilarityThreshold, MaxSamples: -1},
+			},
+			expectedErr: errInvalidMaxSamples,
+		},
+		{
+			desc: "invalid config defines both templates and include_fields",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				IncludeFields:     []string{"attributes.otherthing"},
+				Templates:         TemplatesConfig{Enabled: true, SimilarityThreshold: defaultSimilarityThreshold, MaxSamples: defaultMaxSamples},
+			},
+			expectedErr: errTemplatesIncludeFields,
+		},
 	}
 
 	for _, tc := range testCases {
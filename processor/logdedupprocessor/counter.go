@@ -30,16 +30,20 @@ type logAggregator struct {
 	timezone          *time.Location
 	telemetryBuilder  *metadata.TelemetryBuilder
 	dedupFields       []string
+	templatesConfig   TemplatesConfig
+	templates         *templater
 }
 
 // newLogAggregator creates a new LogCounter.
-func newLogAggregator(logCountAttribute string, timezone *time.Location, telemetryBuilder *metadata.TelemetryBuilder, dedupFields []string) *logAggregator {
+func newLogAggregator(logCountAttribute string, timezone *time.Location, telemetryBuilder *metadata.TelemetryBuilder, dedupFields []string, templatesConfig TemplatesConfig) *logAggregator {
 	return &logAggregator{
 		resources:         make(map[uint64]*resourceAggregator),
 		logCountAttribute: logCountAttribute,
 		timezone:          timezone,
 		telemetryBuilder:  telemetryBuilder,
 		dedupFields:       dedupFields,
+		templatesConfig:   templatesConfig,
+		templates:         newTemplater(templatesConfig),
 	}
 }
 
@@ -66,13 +70,16 @@ func (l *logAggregator) Export(ctx context.Context) plog.Logs {
 				lr.SetTimestamp(pcommon.NewTimestampFromTime(timeNow()))
 				lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(logAggregator.firstObservedTimestamp))
 
-				// Add attributes for log count and first/last observed timestamps
-				lr.Attributes().EnsureCapacity(lr.Attributes().Len() + 3)
+				// Add attributes for log count and first/last observed timestamps, and the template if any
+				lr.Attributes().EnsureCapacity(lr.Attributes().Len() + 5)
 				lr.Attributes().PutInt(l.logCountAttribute, logAggregator.count)
 				firstTimestampStr := logAggregator.firstObservedTimestamp.In(l.timezone).Format(time.RFC3339)
 				lr.Attributes().PutStr(firstObservedTSAttr, firstTimestampStr)
 				lastTimestampStr := logAggregator.lastObservedTimestamp.In(l.timezone).Format(time.RFC3339)
 				lr.Attributes().PutStr(lastObservedTSAttr, lastTimestampStr)
+				if logAggregator.template != nil {
+					logAggregator.template.putAttributes(lr.Attributes())
+				}
 			}
 		}
 	}
@@ -85,15 +92,17 @@ func (l *logAggregator) Add(resource pcommon.Resource, scope pcommon.Instrumenta
 	key := getResourceKey(resource)
 	resourceAggregator, ok := l.resources[key]
 	if !ok {
-		resourceAggregator = newResourceAggregator(resource, l.dedupFields)
+		resourceAggregator = newResourceAggregator(resource, l.dedupFields, l.templates)
 		l.resources[key] = resourceAggregator
 	}
 	resourceAggregator.Add(scope, logRecord)
 }
 
 // Reset resets the counter.
+// Templates are learned anew for each interval, which bounds the memory they use.
 func (l *logAggregator) Reset() {
 	l.resources = make(map[uint64]*resourceAggregator)
+	l.templates = newTemplater(l.templatesConfig)
 }
 
 // resourceAggregator dimensions the counter by resource.
@@ -101,14 +110,16 @@ type resourceAggregator struct {
 	resource      pcommon.Resource
 	scopeCounters map[uint64]*scopeAggregator
 	dedupFields   []string
+	templates     *templater
 }
 
 // newResourceAggregator creates a new ResourceCounter.
-func newResourceAggregator(resource pcommon.Resource, dedupFields []string) *resourceAggregator {
+func newResourceAggregator(resource pcommon.Resource, dedupFields []string, templates *templater) *resourceAggregator {
 	return &resourceAggregator{
 		resource:      resource,
 		scopeCounters: make(map[uint64]*scopeAggregator),
 		dedupFields:   dedupFields,
+		templates:     templates,
 	}
 }
 
@@ -117,7 +128,7 @@ func (r *resourceAggregator) Add(scope pcommon.InstrumentationScope, logRecord p
 	key := getScopeKey(scope)
 	scopeAggregator, ok := r.scopeCounters[key]
 	if !ok {
-		scopeAggregator = newScopeAggregator(scope, r.dedupFields)
+		scopeAggregator = newScopeAggregator(scope, r.dedupFields, r.templates)
 		r.scopeCounters[key] = scopeAggregator
 	}
 	scopeAggregator.Add(logRecord)
@@ -128,19 +139,27 @@ type scopeAggregator struct {
 	scope       pcommon.InstrumentationScope
 	logCounters map[uint64]*logCounter
 	dedupFields []string
+	templates   *templater
 }
 
 // newScopeAggregator creates a new ScopeCounter.
-func newScopeAggregator(scope pcommon.InstrumentationScope, dedupFields []string) *scopeAggregator {
+func newScopeAggregator(scope pcommon.InstrumentationScope, dedupFields []string, templates *templater) *scopeAggregator {
 	return &scopeAggregator{
 		scope:       scope,
 		logCounters: make(map[uint64]*logCounter),
 		dedupFields: dedupFields,
+		templates:   templates,
 	}
 }
 
 // Add increments the counter that the logRecord matches.
+// If templates are enabled, logRecords with a string body match by the template of the body.
 func (s *scopeAggregator) Add(logRecord plog.LogRecord) {
+	if s.templates != nil && logRecord.Body().Type() == pcommon.ValueTypeStr {
+		s.addTemplate(logRecord)
+		return
+	}
+
 	key := getLogKey(logRecord, s.dedupFields)
 	lc, ok := s.logCounters[key]
 	if !ok {
@@ -150,12 +169,28 @@ func (s *scopeAggregator) Add(logRecord plog.LogRecord) {
 	lc.Increment()
 }
 
+// addTemplate increments the counter of the template the body of logRecord belongs to.
+func (s *scopeAggregator) addTemplate(logRecord plog.LogRecord) {
+	cluster, tokens := s.templates.drain.Add(logRecord.Body().Str())
+	key := getTemplateKey(logRecord, cluster)
+	lc, ok := s.logCounters[key]
+	if !ok {
+		lc = newLogCounter(logRecord)
+		lc.template = &templateSamples{cluster: cluster}
+		s.logCounters[key] = lc
+	}
+	lc.template.add(tokens, s.templates.maxSamples)
+	lc.Increment()
+}
+
 // logCounter is a counter for a log record.
 type logCounter struct {
 	logRecord              plog.LogRecord
 	firstObservedTimestamp time.Time
 	lastObservedTimestamp  time.Time
 	count                  int64
+	// template is set if the log record is counted by the template of its body
+	template *templateSamples
 }
 
 // newLogCounter creates a new AttributeCounter.
@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drain clusters log messages into templates, following the Drain algorithm
+// described in "Drain: An Online Log Parsing Approach with Fixed Depth Tree" (He et al., 2017).
+package drain // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/drain"
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+const (
+	// Wildcard replaces the tokens that differ between the messages of a cluster.
+	Wildcard = "<*>"
+
+	// prefixDepth is the number of leading tokens used to descend the tree, below the
+	// level of the token count.
+	prefixDepth = 2
+
+	// maxChildren is the maximum number of children of a node. Once reached, messages
+	// with other tokens descend to the wildcard child.
+	maxChildren = 100
+)
+
+// mask replaces the matches of pattern with placeholder, unless valid rejects them. If
+// pattern has a group, only the group is replaced.
+type mask struct {
+	pattern     *regexp.Regexp
+	placeholder string
+	valid       func(string) bool
+}
+
+// masks are applied in order to every token, before it is clustered.
+var masks = []mask{
+	{
+		pattern:     regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`),
+		placeholder: "<UUID>",
+	},
+	{
+		pattern:     regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}(?::\d+)?\b`),
+		placeholder: "<IP>",
+	},
+	{
+		// RE2 has no lookbehind, so the leading boundary is matched outside of the group
+		pattern:     regexp.MustCompile(`(?:^|[^0-9a-zA-Z_])([0-9a-fA-F]*:[0-9a-fA-F:]*:[0-9a-fA-F]*)`),
+		placeholder: "<IP>",
+		valid: func(s string) bool {
+			return net.ParseIP(s) != nil
+		},
+	},
+	{
+		pattern:     regexp.MustCompile(`\b(?:0[xX][0-9a-fA-F]+|[0-9a-fA-F]{8,})\b`),
+		placeholder: "<HEX>",
+		valid: func(s string) bool {
+			// long runs of digits are numbers, and long runs of letters are words
+			return strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") ||
+				strings.ContainsAny(s, "0123456789") && strings.ContainsAny(s, "abcdefABCDEF")
+		},
+	},
+	{
+		// numbers may be followed by a unit, but not preceded by letters, as in v2
+		pattern:     regexp.MustCompile(`(?:^|[^0-9a-zA-Z_])(\d+(?:\.\d+)?)`),
+		placeholder: "<NUM>",
+	},
+}
+
+// Mask replaces the variable parts of token, such as UUIDs, IPs, hex values and numbers,
+// with placeholders.
+func Mask(token string) string {
+	// every mask matches at least one digit
+	if !hasDigit(token) {
+		return token
+	}
+	for _, m := range masks {
+		token = m.apply(token)
+	}
+	return token
+}
+
+func (m mask) apply(token string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range m.pattern.FindAllStringSubmatchIndex(token, -1) {
+		start, end := loc[0], loc[1]
+		if len(loc) > 2 {
+			start, end = loc[2], loc[3]
+		}
+		if m.valid != nil && !m.valid(token[start:end]) {
+			continue
+		}
+		b.WriteString(token[last:start])
+		b.WriteString(m.placeholder)
+		last = end
+	}
+	if b.Len() == 0 {
+		return token
+	}
+	b.WriteString(token[last:])
+	return b.String()
+}
+
+// variable reports whether the template token holds variable values.
+func variable(token string) bool {
+	if token == Wildcard {
+		return true
+	}
+	for _, m := range masks {
+		if strings.Contains(token, m.placeholder) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDigit(token string) bool {
+	return strings.ContainsAny(token, "0123456789")
+}
+
+// Cluster is a group of messages sharing the same template.
+type Cluster struct {
+	// ID identifies the cluster within its Drain.
+	ID     int
+	tokens []string
+}
+
+// Template returns the template of the messages of the cluster, where variable tokens
+// are replaced with Wildcard or a placeholder.
+func (c *Cluster) Template() string {
+	return strings.Join(c.tokens, " ")
+}
+
+// Params returns the tokens of a message of the cluster that are variable in its template.
+func (c *Cluster) Params(tokens []string) []string {
+	if len(tokens) != len(c.tokens) {
+		return nil
+	}
+	var params []string
+	for i, tok := range c.tokens {
+		if variable(tok) {
+			params = append(params, tokens[i])
+		}
+	}
+	return params
+}
+
+// similarity returns the share of tokens equal to the template, along with the number
+// of wildcards in the template.
+func (c *Cluster) similarity(tokens []string) (sim float64, wildcards int) {
+	if len(tokens) == 0 {
+		return 1, 0
+	}
+	equal := 0
+	for i, tok := range c.tokens {
+		switch tok {
+		case Wildcard:
+			wildcards++
+		case tokens[i]:
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(tokens)), wildcards
+}
+
+// merge replaces the tokens of the template that differ from tokens with Wildcard.
+func (c *Cluster) merge(tokens []string) {
+	for i, tok := range c.tokens {
+		if tok != tokens[i] {
+			c.tokens[i] = Wildcard
+		}
+	}
+}
+
+type node struct {
+	children map[string]*node
+	clusters []*Cluster
+}
+
+func newNode() *node {
+	return &node{children: map[string]*node{}}
+}
+
+// Drain clusters log messages. Messages are routed through a tree of fixed depth, by
+// their number of tokens and their leading tokens, to a leaf holding a few clusters.
+// The message joins the most similar of them, if similar enough, or starts a new one.
+type Drain struct {
+	threshold float64
+	roots     map[int]*node
+	clusters  int
+}
+
+// New creates a Drain adding messages to clusters whose share of equal tokens is at
+// least threshold.
+func New(threshold float64) *Drain {
+	return &Drain{
+		threshold: threshold,
+		roots:     map[int]*node{},
+	}
+}
+
+// Add adds message to the most similar cluster, creating it if needed, and returns it
+// along with the tokens of message. Tokens are separated by whitespace.
+func (d *Drain) Add(message string) (*Cluster, []string) {
+	tokens := strings.Fields(message)
+	masked := make([]string, len(tokens))
+	for i, tok := range tokens {
+		masked[i] = Mask(tok)
+	}
+
+	leaf := d.leaf(masked)
+	if c := d.match(leaf.clusters, masked); c != nil {
+		c.merge(masked)
+		return c, tokens
+	}
+
+	d.clusters++
+	c := &Cluster{ID: d.clusters, tokens: masked}
+	leaf.clusters = append(leaf.clusters, c)
+	return c, tokens
+}
+
+// leaf returns the leaf of the tree the tokens are routed to, creating it if needed.
+func (d *Drain) leaf(tokens []string) *node {
+	n, ok := d.roots[len(tokens)]
+	if !ok {
+		n = newNode()
+		d.roots[len(tokens)] = n
+	}
+
+	for _, tok := range tokens[:min(prefixDepth, len(tokens))] {
+		// tokens holding digits are likely variable, and would grow the tree
+		if variable(tok) || hasDigit(tok) {
+			tok = Wildcard
+		}
+		child, ok := n.children[tok]
+		if !ok && len(n.children) >= maxChildren {
+			tok = Wildcard
+			child, ok = n.children[tok]
+		}
+		if !ok {
+			child = newNode()
+			n.children[tok] = child
+		}
+		n = child
+	}
+	return n
+}
+
+// match returns the most similar cluster, preferring the one with more wildcards on
+// ties, or nil if none is similar enough.
+func (d *Drain) match(clusters []*Cluster, tokens []string) *Cluster {
+	var best *Cluster
+	bestSim, bestWildcards := -1.0, -1
+	for _, c := range clusters {
+		sim, wildcards := c.similarity(tokens)
+		if sim > bestSim || (sim == bestSim && wildcards > bestWildcards) {
+			best, bestSim, bestWildcards = c, sim, wildcards
+		}
+	}
+	if best == nil || bestSim < d.threshold {
+		return nil
+	}
+	return best
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		token    string
+		expected string
+	}{
+		{token: "connected", expected: "connected"},
+		{token: "42", expected: "<NUM>"},
+		{token: "took=3.5ms", expected: "took=<NUM>ms"},
+		{token: "v2", expected: "v2"},
+		{token: "8c5e0e6a-5d3b-4b8e-9a51-0f2d8f2f3e41", expected: "<UUID>"},
+		{token: "req-8c5e0e6a-5d3b-4b8e-9a51-0f2d8f2f3e41,", expected: "req-<UUID>,"},
+		{token: "10.0.0.1", expected: "<IP>"},
+		{token: "10.0.0.1:8080", expected: "<IP>"},
+		{token: "[fe80::1]:443", expected: "[<IP>]:<NUM>"},
+		{token: "addr=2001:db8::ff00:42:8329", expected: "addr=<IP>"},
+		{token: "std::vector<int>", expected: "std::vector<int>"},
+		{token: "12:30:45", expected: "<NUM>:<NUM>:<NUM>"},
+		{token: "0x1f", expected: "<HEX>"},
+		{token: "sha=9f86d081884c7d65", expected: "sha=<HEX>"},
+		{token: "12345678", expected: "<NUM>"},
+		{token: "deadbeefcafe", expected: "deadbeefcafe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Mask(tt.token))
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	d := New(0.5)
+
+	first, tokens := d.Add("user alice logged in from 10.0.0.1")
+	assert.Equal(t, "user alice logged in from <IP>", first.Template())
+	assert.Equal(t, []string{"10.0.0.1"}, first.Params(tokens))
+
+	// the leading tokens route to the same leaf, the other tokens are similar enough
+	c, tokens := d.Add("user alice logged out from 10.0.0.2")
+	require.Same(t, first, c)
+	assert.Equal(t, "user alice logged <*> from <IP>", c.Template())
+	assert.Equal(t, []string{"out", "10.0.0.2"}, c.Params(tokens))
+
+	// different number of tokens
+	c, _ = d.Add("user alice logged in")
+	assert.NotEqual(t, first.ID, c.ID)
+
+	// not similar enough
+	c, _ = d.Add("user alice has no permissions here")
+	assert.NotEqual(t, first.ID, c.ID)
+	assert.Equal(t, "user alice has no permissions here", c.Template())
+}
+
+func TestDrainNumericPrefix(t *testing.T) {
+	d := New(0.5)
+
+	// leading tokens holding digits are routed to the wildcard node
+	first, _ := d.Add("42 requests served in 120ms")
+	c, tokens := d.Add("7 requests served in 80ms")
+	require.Same(t, first, c)
+	assert.Equal(t, "<NUM> requests served in <NUM>ms", c.Template())
+	assert.Equal(t, []string{"7", "80ms"}, c.Params(tokens))
+}
+
+func TestDrainEmpty(t *testing.T) {
+	d := New(0.5)
+
+	first, tokens := d.Add("")
+	assert.Empty(t, tokens)
+	assert.Empty(t, first.Template())
+
+	c, _ := d.Add("   ")
+	assert.Same(t, first, c)
+}
+
+func TestDrainMaxChildren(t *testing.T) {
+	d := New(0.5)
+
+	words := make([]string, 0, maxChildren+1)
+	for i := range maxChildren + 1 {
+		words = append(words, string(rune('a'+i%26))+string(rune('a'+i/26)))
+	}
+	for _, w := range words[:maxChildren] {
+		d.Add(w + " started")
+	}
+	require.Len(t, d.roots[2].children, maxChildren)
+
+	// the root is full, so further tokens share the wildcard node
+	d.Add(words[maxChildren] + " started")
+	assert.Len(t, d.roots[2].children, maxChildren+1)
+	assert.Contains(t, d.roots[2].children, Wildcard)
+}
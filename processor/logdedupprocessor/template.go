@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"slices"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/drain"
+)
+
+// Attributes names for the template and samples of its variable values
+const (
+	logTemplateAttr        = "log_template"
+	logTemplateSamplesAttr = "log_template_samples"
+)
+
+// templater clusters the string bodies of log records into templates.
+type templater struct {
+	drain      *drain.Drain
+	maxSamples int
+}
+
+// newTemplater creates a new templater, or returns nil if templates are disabled.
+func newTemplater(cfg TemplatesConfig) *templater {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &templater{
+		drain:      drain.New(cfg.SimilarityThreshold),
+		maxSamples: cfg.MaxSamples,
+	}
+}
+
+// templateSamples holds the template of a log counter, and the tokens of a few of its bodies.
+type templateSamples struct {
+	cluster *drain.Cluster
+	samples [][]string
+}
+
+// add keeps the tokens as a sample, unless enough samples are kept or they are already kept.
+func (t *templateSamples) add(tokens []string, maxSamples int) {
+	if len(t.samples) >= maxSamples {
+		return
+	}
+	for _, sample := range t.samples {
+		if slices.Equal(sample, tokens) {
+			return
+		}
+	}
+	t.samples = append(t.samples, tokens)
+}
+
+// putAttributes adds the template and the variable values of the samples to the attributes.
+// The variable values are extracted at export, as the template is generalized by later bodies.
+func (t *templateSamples) putAttributes(attrs pcommon.Map) {
+	attrs.PutStr(logTemplateAttr, t.cluster.Template())
+
+	var values [][]string
+	for _, sample := range t.samples {
+		if params := t.cluster.Params(sample); len(params) > 0 {
+			values = append(values, params)
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	samples := attrs.PutEmptySlice(logTemplateSamplesAttr)
+	samples.EnsureCapacity(len(values))
+	for _, params := range values {
+		sample := samples.AppendEmpty().SetEmptySlice()
+		sample.EnsureCapacity(len(params))
+		for _, param := range params {
+			sample.AppendEmpty().SetStr(param)
+		}
+	}
+}
+
+// getTemplateKey creates a unique hash for the log record to use as a map key, where the body
+// is replaced by the template cluster it belongs to.
+func getTemplateKey(logRecord plog.LogRecord, cluster *drain.Cluster) uint64 {
+	return pdatautil.Hash64(
+		pdatautil.WithMap(logRecord.Attributes()),
+		pdatautil.WithString(strconv.Itoa(cluster.ID)),
+		pdatautil.WithString(logRecord.SeverityNumber().String()),
+		pdatautil.WithString(logRecord.SeverityText()),
+	)
+}
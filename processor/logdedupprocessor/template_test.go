@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/drain"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/metadata"
+)
+
+func Test_newTemplater(t *testing.T) {
+	require.Nil(t, newTemplater(TemplatesConfig{}))
+
+	templates := newTemplater(TemplatesConfig{Enabled: true, SimilarityThreshold: 0.5, MaxSamples: 2})
+	require.NotNil(t, templates)
+	require.NotNil(t, templates.drain)
+	require.Equal(t, 2, templates.maxSamples)
+}
+
+func Test_templateSamplesAdd(t *testing.T) {
+	ts := &templateSamples{}
+
+	ts.add([]string{"a", "1"}, 2)
+	ts.add([]string{"a", "1"}, 2)
+	ts.add([]string{"a", "2"}, 2)
+	ts.add([]string{"a", "3"}, 2)
+
+	require.Equal(t, [][]string{{"a", "1"}, {"a", "2"}}, ts.samples)
+}
+
+func Test_templateSamplesPutAttributes(t *testing.T) {
+	d := drain.New(0.5)
+	cluster, first := d.Add("job 12 done")
+	_, second := d.Add("job 7 done")
+
+	ts := &templateSamples{cluster: cluster}
+	ts.add(first, 3)
+	ts.add(second, 3)
+
+	attrs := pcommon.NewMap()
+	ts.putAttributes(attrs)
+	require.Equal(t, map[string]any{
+		logTemplateAttr:        "job <NUM> done",
+		logTemplateSamplesAttr: []any{[]any{"12"}, []any{"7"}},
+	}, attrs.AsRaw())
+
+	// no samples without variable values
+	cluster, tokens := drain.New(0.5).Add("job done")
+	ts = &templateSamples{cluster: cluster}
+	ts.add(tokens, 3)
+
+	attrs = pcommon.NewMap()
+	ts.putAttributes(attrs)
+	require.Equal(t, map[string]any{logTemplateAttr: "job done"}, attrs.AsRaw())
+}
+
+func Test_logAggregatorExportTemplates(t *testing.T) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	aggregator := newLogAggregator(defaultLogCountAttribute, time.UTC, telemetryBuilder, nil, TemplatesConfig{
+		Enabled:             true,
+		SimilarityThreshold: 0.5,
+		MaxSamples:          2,
+	})
+	resource := pcommon.NewResource()
+	scope := pcommon.NewInstrumentationScope()
+
+	for _, body := range []string{
+		"connection from 10.0.0.1 closed after 12ms",
+		"connection from 10.0.0.2 closed after 30ms",
+		"connection from 10.0.0.2 closed after 30ms",
+		"connection from 10.0.0.3 closed after 7ms",
+	} {
+		aggregator.Add(resource, scope, generateTestLogRecord(t, body))
+	}
+	// bodies that are not strings are deduplicated as usual
+	aggregator.Add(resource, scope, generateTestLogRecordWithMap(t))
+
+	exportedLogs := aggregator.Export(t.Context())
+	require.Equal(t, 2, exportedLogs.LogRecordCount())
+
+	records := map[pcommon.ValueType]plog.LogRecord{}
+	lrs := exportedLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < lrs.Len(); i++ {
+		records[lrs.At(i).Body().Type()] = lrs.At(i)
+	}
+
+	templated := records[pcommon.ValueTypeStr].Attributes().AsRaw()
+	require.Equal(t, "connection from 10.0.0.1 closed after 12ms", records[pcommon.ValueTypeStr].Body().Str())
+	require.Equal(t, int64(4), templated[defaultLogCountAttribute])
+	require.Equal(t, "connection from <IP> closed after <NUM>ms", templated[logTemplateAttr])
+	require.Equal(t, []any{[]any{"10.0.0.1", "12ms"}, []any{"10.0.0.2", "30ms"}}, templated[logTemplateSamplesAttr])
+
+	exact := records[pcommon.ValueTypeMap].Attributes().AsRaw()
+	require.Equal(t, int64(1), exact[defaultLogCountAttribute])
+	require.NotContains(t, exact, logTemplateAttr)
+}
+
+func Test_logAggregatorResetTemplates(t *testing.T) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	aggregator := newLogAggregator(defaultLogCountAttribute, time.UTC, telemetryBuilder, nil, TemplatesConfig{
+		Enabled:             true,
+		SimilarityThreshold: 0.5,
+	})
+	templates := aggregator.templates
+
+	aggregator.Reset()
+
+	require.NotNil(t, aggregator.templates)
+	require.NotSame(t, templates, aggregator.templates)
+}
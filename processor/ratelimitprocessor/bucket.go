@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// overflowTenant is the tenant sharing a single limit once max_tenants is reached.
+const overflowTenant = "_overflow"
+
+// tokenBucket is a token bucket refilled at a constant rate, up to its burst.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time, rate, burst float64) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed.Seconds()*rate)
+		b.last = now
+	}
+}
+
+// tenantBuckets holds the token bucket of each tenant.
+type tenantBuckets struct {
+	rate       float64
+	burst      float64
+	maxTenants int
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newTenantBuckets(rate, burst float64, maxTenants int) *tenantBuckets {
+	return &tenantBuckets{
+		rate:       rate,
+		burst:      burst,
+		maxTenants: maxTenants,
+		buckets:    map[string]*tokenBucket{},
+	}
+}
+
+// resolveLocked returns the tenant whose limit applies, which is the overflow
+// tenant when too many tenants are tracked.
+func (tb *tenantBuckets) resolveLocked(tenant string, now time.Time) string {
+	if _, ok := tb.buckets[tenant]; ok || len(tb.buckets) < tb.maxTenants {
+		return tenant
+	}
+	tb.pruneLocked(now)
+	if len(tb.buckets) < tb.maxTenants {
+		return tenant
+	}
+	return overflowTenant
+}
+
+// pruneLocked forgets the tenants whose bucket is full again, as a new
+// bucket would be in the same state.
+func (tb *tenantBuckets) pruneLocked(now time.Time) {
+	for tenant, b := range tb.buckets {
+		b.refill(now, tb.rate, tb.burst)
+		if b.tokens >= tb.burst {
+			delete(tb.buckets, tenant)
+		}
+	}
+}
+
+func (tb *tenantBuckets) bucketLocked(tenant string, now time.Time) *tokenBucket {
+	b, ok := tb.buckets[tenant]
+	if !ok {
+		b = &tokenBucket{tokens: tb.burst, last: now}
+		tb.buckets[tenant] = b
+	}
+	b.refill(now, tb.rate, tb.burst)
+	return b
+}
+
+// takeLocked takes n tokens from the bucket of the tenant. Requests larger
+// than the burst are allowed once the bucket is full, leaving it in debt, so
+// that they aren't rejected forever. When there aren't enough tokens, it
+// returns how long to wait until there are.
+func (tb *tenantBuckets) takeLocked(tenant string, n float64, now time.Time) (bool, time.Duration) {
+	b := tb.bucketLocked(tenant, now)
+	needed := math.Min(n, tb.burst)
+	if b.tokens < needed {
+		wait := time.Duration((needed - b.tokens) / tb.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens -= n
+	return true, 0
+}
+
+// giveBackLocked returns tokens taken by a request which wasn't accepted after all.
+func (tb *tenantBuckets) giveBackLocked(tenant string, n float64) {
+	if b, ok := tb.buckets[tenant]; ok {
+		b.tokens = math.Min(tb.burst, b.tokens+n)
+	}
+}
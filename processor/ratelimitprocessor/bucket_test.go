@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	buckets := newTenantBuckets(10, 20, 10)
+	now := time.Unix(1000, 0)
+	buckets.mu.Lock()
+	defer buckets.mu.Unlock()
+
+	// a new bucket starts full
+	ok, _ := buckets.takeLocked("a", 15, now)
+	assert.True(t, ok)
+	ok, wait := buckets.takeLocked("a", 10, now)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	// the bucket is refilled at the rate
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = buckets.takeLocked("a", 10, now)
+	assert.True(t, ok)
+
+	// tenants don't share their bucket
+	ok, _ = buckets.takeLocked("b", 20, now)
+	assert.True(t, ok)
+
+	// tokens given back are available again
+	buckets.giveBackLocked("b", 5)
+	ok, _ = buckets.takeLocked("b", 5, now)
+	assert.True(t, ok)
+}
+
+func TestTokenBucketLargerThanBurst(t *testing.T) {
+	buckets := newTenantBuckets(10, 20, 10)
+	now := time.Unix(1000, 0)
+	buckets.mu.Lock()
+	defer buckets.mu.Unlock()
+
+	// a request larger than the burst is accepted once the bucket is full,
+	// and the debt delays the next ones
+	ok, _ := buckets.takeLocked("a", 40, now)
+	assert.True(t, ok)
+	ok, wait := buckets.takeLocked("a", 1, now)
+	assert.False(t, ok)
+	assert.Equal(t, 2100*time.Millisecond, wait)
+
+	now = now.Add(4 * time.Second)
+	ok, _ = buckets.takeLocked("a", 40, now)
+	assert.True(t, ok)
+}
+
+func TestTenantBucketsMaxTenants(t *testing.T) {
+	buckets := newTenantBuckets(10, 20, 2)
+	now := time.Unix(1000, 0)
+	buckets.mu.Lock()
+	defer buckets.mu.Unlock()
+
+	for _, tenant := range []string{"a", "b"} {
+		assert.Equal(t, tenant, buckets.resolveLocked(tenant, now))
+		ok, _ := buckets.takeLocked(tenant, 20, now)
+		assert.True(t, ok)
+	}
+	// both tenants are busy, a new one shares the overflow limit
+	assert.Equal(t, overflowTenant, buckets.resolveLocked("c", now))
+	assert.Equal(t, "a", buckets.resolveLocked("a", now))
+
+	// once a tenant has been idle long enough to be full again, it is forgotten
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, "c", buckets.resolveLocked("c", now))
+	assert.Empty(t, buckets.buckets)
+}
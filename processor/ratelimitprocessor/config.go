@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// unitItems counts spans, metric data points and log records.
+	unitItems = "items"
+	// unitBytes counts the size of the telemetry encoded as OTLP protobuf.
+	unitBytes = "bytes"
+
+	// actionReject returns a retryable error for the whole request.
+	actionReject = "reject"
+	// actionDrop drops the telemetry of the tenants over their limit.
+	actionDrop = "drop"
+)
+
+// Config defines the configuration for the rate limit processor.
+type Config struct {
+	// MetadataKeys is a list of client.Metadata keys identifying the tenant
+	// of a request, such as the metadata set by authenticators.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// ResourceAttributes is a list of resource attributes identifying the
+	// tenant of the telemetry of a resource. They are combined with the
+	// MetadataKeys, if any. When both are empty, a single limit applies to
+	// all the telemetry.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// Rate is the number of units per second each tenant is allowed.
+	Rate float64 `mapstructure:"rate"`
+
+	// Burst is the number of units a tenant can send at once after being
+	// idle. Defaults to the Rate.
+	Burst float64 `mapstructure:"burst"`
+
+	// Unit is what the rate limits, either "items" or "bytes".
+	Unit string `mapstructure:"unit"`
+
+	// Action is what happens to telemetry over the limit, either "reject"
+	// or "drop".
+	Action string `mapstructure:"action"`
+
+	// MaxTenants limits the number of tenants tracked at once. Once
+	// reached, the tenants which aren't tracked share a single limit.
+	MaxTenants int `mapstructure:"max_tenants"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.Rate <= 0 {
+		errs = errors.Join(errs, errors.New("rate must be greater than zero"))
+	}
+	if cfg.Burst < 0 {
+		errs = errors.Join(errs, errors.New("burst must not be negative"))
+	}
+	switch cfg.Unit {
+	case unitItems, unitBytes:
+	default:
+		errs = errors.Join(errs, fmt.Errorf("unit must be %q or %q, got %q", unitItems, unitBytes, cfg.Unit))
+	}
+	switch cfg.Action {
+	case actionReject, actionDrop:
+	default:
+		errs = errors.Join(errs, fmt.Errorf("action must be %q or %q, got %q", actionReject, actionDrop, cfg.Action))
+	}
+	if cfg.MaxTenants <= 0 {
+		errs = errors.Join(errs, errors.New("max_tenants must be greater than zero"))
+	}
+	return errs
+}
+
+// burst returns the configured burst, or the rate when it isn't set.
+func (cfg *Config) burst() float64 {
+	if cfg.Burst == 0 {
+		return cfg.Rate
+	}
+	return cfg.Burst
+}
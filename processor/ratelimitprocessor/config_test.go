@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		expectedErrs []string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Rate:       1000,
+				Unit:       unitItems,
+				Action:     actionReject,
+				MaxTenants: defaultMaxTenants,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "tenants"),
+			expected: &Config{
+				MetadataKeys:       []string{"x-tenant-id"},
+				ResourceAttributes: []string{"service.namespace"},
+				Rate:               1048576,
+				Burst:              4194304,
+				Unit:               unitBytes,
+				Action:             actionDrop,
+				MaxTenants:         100,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_rate"),
+			expectedErrs: []string{"rate must be greater than zero"},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid"),
+			expectedErrs: []string{
+				"burst must not be negative",
+				`unit must be "items" or "bytes", got "spans"`,
+				`action must be "reject" or "drop", got "delay"`,
+				"max_tenants must be greater than zero",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = xconfmap.Validate(cfg)
+			if len(tt.expectedErrs) > 0 {
+				for _, expectedErr := range tt.expectedErrs {
+					assert.ErrorContains(t, err, expectedErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigBurst(t *testing.T) {
+	cfg := &Config{Rate: 10}
+	assert.Equal(t, 10.0, cfg.burst())
+	cfg.Burst = 25
+	assert.Equal(t, 25.0, cfg.burst())
+}
@@ -0,0 +1,328 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadata"
+)
+
+const tenantKey = "tenant"
+
+type rateLimiter struct {
+	metadataKeys       []string
+	resourceAttributes []string
+	unit               string
+	action             string
+	buckets            *tenantBuckets
+	now                func() time.Time
+
+	telemetryBuilder *metadata.TelemetryBuilder
+}
+
+func newRateLimiter(set processor.Settings, cfg *Config) (*rateLimiter, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimiter{
+		metadataKeys:       cfg.MetadataKeys,
+		resourceAttributes: cfg.ResourceAttributes,
+		unit:               cfg.Unit,
+		action:             cfg.Action,
+		buckets:            newTenantBuckets(cfg.Rate, cfg.burst(), cfg.MaxTenants),
+		now:                time.Now,
+		telemetryBuilder:   telemetryBuilder,
+	}, nil
+}
+
+func (rl *rateLimiter) shutdown(context.Context) error {
+	rl.telemetryBuilder.Shutdown()
+	return nil
+}
+
+// usage is the telemetry of a tenant within a request.
+type usage struct {
+	weight float64
+	items  int64
+
+	// resolved is the tenant whose limit applies, see tenantBuckets.resolveLocked.
+	resolved string
+	accepted bool
+}
+
+// requestTenant identifies the tenant of a request from its client metadata.
+// The metadata values are hashed, as they can be credentials such as API keys,
+// and the tenant is reported in the telemetry of the processor.
+func (rl *rateLimiter) requestTenant(ctx context.Context) string {
+	if len(rl.metadataKeys) == 0 {
+		return ""
+	}
+	info := client.FromContext(ctx)
+	parts := make([]string, len(rl.metadataKeys))
+	for i, key := range rl.metadataKeys {
+		parts[i] = key + "=" + hashMetadata(info.Metadata.Get(key))
+	}
+	return strings.Join(parts, ";")
+}
+
+// hashMetadata returns the first 8 bytes of the SHA-256 hash of the values,
+// hex encoded, or an empty string when there are no values.
+func hashMetadata(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.Join(values, ",")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// resourceTenant identifies the tenant of the telemetry of a resource.
+func (rl *rateLimiter) resourceTenant(requestTenant string, res pcommon.Resource) string {
+	if len(rl.resourceAttributes) == 0 {
+		return requestTenant
+	}
+	parts := make([]string, 0, len(rl.resourceAttributes)+1)
+	if requestTenant != "" {
+		parts = append(parts, requestTenant)
+	}
+	for _, key := range rl.resourceAttributes {
+		var value string
+		if v, ok := res.Attributes().Get(key); ok {
+			value = v.AsString()
+		}
+		parts = append(parts, key+"="+value)
+	}
+	return strings.Join(parts, ";")
+}
+
+// addUsage accounts for the telemetry of a resource. The size is only
+// computed when limiting bytes.
+func (rl *rateLimiter) addUsage(usages map[string]*usage, tenant string, items int, size func() int) {
+	u, ok := usages[tenant]
+	if !ok {
+		u = &usage{}
+		usages[tenant] = u
+	}
+	u.items += int64(items)
+	if rl.unit == unitBytes {
+		u.weight += float64(size())
+	} else {
+		u.weight += float64(items)
+	}
+}
+
+// admit takes the tokens needed by each tenant of a request. With the drop
+// action, it returns which tenants are accepted. With the reject action,
+// either all the tenants are accepted or the request is rejected with a
+// retryable error, and no tokens are taken.
+func (rl *rateLimiter) admit(ctx context.Context, usages map[string]*usage) (map[string]bool, error) {
+	now := rl.now()
+	resolved := make(map[string]*usage, len(usages))
+
+	rl.buckets.mu.Lock()
+	for tenant, u := range usages {
+		u.resolved = rl.buckets.resolveLocked(tenant, now)
+		rl.buckets.bucketLocked(u.resolved, now)
+		total, ok := resolved[u.resolved]
+		if !ok {
+			total = &usage{}
+			resolved[u.resolved] = total
+		}
+		total.weight += u.weight
+		total.items += u.items
+	}
+	throttled := false
+	var maxWait time.Duration
+	for tenant, total := range resolved {
+		var wait time.Duration
+		total.accepted, wait = rl.buckets.takeLocked(tenant, total.weight, now)
+		if !total.accepted {
+			throttled = true
+			maxWait = max(maxWait, wait)
+		}
+	}
+	rejected := throttled && rl.action == actionReject
+	if rejected {
+		for tenant, total := range resolved {
+			if total.accepted {
+				rl.buckets.giveBackLocked(tenant, total.weight)
+			}
+		}
+	}
+	rl.buckets.mu.Unlock()
+
+	for tenant, total := range resolved {
+		attrs := metric.WithAttributes(attribute.String(tenantKey, tenant))
+		switch {
+		case !total.accepted:
+			rl.telemetryBuilder.ProcessorRatelimitThrottledItems.Add(ctx, total.items, attrs)
+		case !rejected:
+			rl.telemetryBuilder.ProcessorRatelimitAcceptedItems.Add(ctx, total.items, attrs)
+		}
+	}
+	if rejected {
+		return nil, newThrottledError(maxWait)
+	}
+
+	accepted := make(map[string]bool, len(usages))
+	for tenant, u := range usages {
+		accepted[tenant] = resolved[u.resolved].accepted
+	}
+	return accepted, nil
+}
+
+// newThrottledError returns a RESOURCE_EXHAUSTED status, which receivers
+// translate to a 429 or RESOURCE_EXHAUSTED response. The RetryInfo detail
+// makes it retryable, and tells clients when to retry.
+func newThrottledError(wait time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+func (rl *rateLimiter) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	if rss.Len() == 0 {
+		return td, nil
+	}
+	requestTenant := rl.requestTenant(ctx)
+	tenants := make([]string, rss.Len())
+	usages := map[string]*usage{}
+	sizer := ptrace.ProtoMarshaler{}
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		tenants[i] = rl.resourceTenant(requestTenant, rs.Resource())
+		spans := 0
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans += rs.ScopeSpans().At(j).Spans().Len()
+		}
+		rl.addUsage(usages, tenants[i], spans, func() int { return sizer.ResourceSpansSize(rs) })
+	}
+
+	accepted, err := rl.admit(ctx, usages)
+	if err != nil {
+		return td, err
+	}
+	i := 0
+	rss.RemoveIf(func(ptrace.ResourceSpans) bool {
+		drop := !accepted[tenants[i]]
+		i++
+		return drop
+	})
+	if rss.Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+func (rl *rateLimiter) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	if rms.Len() == 0 {
+		return md, nil
+	}
+	requestTenant := rl.requestTenant(ctx)
+	tenants := make([]string, rms.Len())
+	usages := map[string]*usage{}
+	sizer := pmetric.ProtoMarshaler{}
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenants[i] = rl.resourceTenant(requestTenant, rm.Resource())
+		rl.addUsage(usages, tenants[i], dataPointCount(rm), func() int { return sizer.ResourceMetricsSize(rm) })
+	}
+
+	accepted, err := rl.admit(ctx, usages)
+	if err != nil {
+		return md, err
+	}
+	i := 0
+	rms.RemoveIf(func(pmetric.ResourceMetrics) bool {
+		drop := !accepted[tenants[i]]
+		i++
+		return drop
+	})
+	if rms.Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+func (rl *rateLimiter) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	if rls.Len() == 0 {
+		return ld, nil
+	}
+	requestTenant := rl.requestTenant(ctx)
+	tenants := make([]string, rls.Len())
+	usages := map[string]*usage{}
+	sizer := plog.ProtoMarshaler{}
+	for i := 0; i < rls.Len(); i++ {
+		resLogs := rls.At(i)
+		tenants[i] = rl.resourceTenant(requestTenant, resLogs.Resource())
+		records := 0
+		for j := 0; j < resLogs.ScopeLogs().Len(); j++ {
+			records += resLogs.ScopeLogs().At(j).LogRecords().Len()
+		}
+		rl.addUsage(usages, tenants[i], records, func() int { return sizer.ResourceLogsSize(resLogs) })
+	}
+
+	accepted, err := rl.admit(ctx, usages)
+	if err != nil {
+		return ld, err
+	}
+	i := 0
+	rls.RemoveIf(func(plog.ResourceLogs) bool {
+		drop := !accepted[tenants[i]]
+		i++
+		return drop
+	})
+	if rls.Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+func dataPointCount(rm pmetric.ResourceMetrics) int {
+	count := 0
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		metrics := rm.ScopeMetrics().At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			m := metrics.At(j)
+			switch m.Type() {
+			case pmetric.MetricTypeGauge:
+				count += m.Gauge().DataPoints().Len()
+			case pmetric.MetricTypeSum:
+				count += m.Sum().DataPoints().Len()
+			case pmetric.MetricTypeHistogram:
+				count += m.Histogram().DataPoints().Len()
+			case pmetric.MetricTypeExponentialHistogram:
+				count += m.ExponentialHistogram().DataPoints().Len()
+			case pmetric.MetricTypeSummary:
+				count += m.Summary().DataPoints().Len()
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadatatest"
+)
+
+func newTestRateLimiter(t *testing.T, cfg *Config) *rateLimiter {
+	rl, err := newRateLimiter(processortest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	rl.now = func() time.Time { return now }
+	return rl
+}
+
+// newTraces returns traces with a resource per tenant, each with the given number of spans.
+func newTraces(spansByTenant map[string]int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for tenant, spans := range spansByTenant {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("tenant.id", tenant)
+		ss := rs.ScopeSpans().AppendEmpty()
+		for range spans {
+			ss.Spans().AppendEmpty().SetName("span")
+		}
+	}
+	return td
+}
+
+func tenantsOf(td ptrace.Traces) []string {
+	var tenants []string
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		v, _ := td.ResourceSpans().At(i).Resource().Attributes().Get("tenant.id")
+		tenants = append(tenants, v.Str())
+	}
+	return tenants
+}
+
+func TestDropByResourceAttribute(t *testing.T) {
+	rl := newTestRateLimiter(t, &Config{
+		ResourceAttributes: []string{"tenant.id"},
+		Rate:               10,
+		Unit:               unitItems,
+		Action:             actionDrop,
+		MaxTenants:         10,
+	})
+	ctx := t.Context()
+
+	td, err := rl.processTraces(ctx, newTraces(map[string]int{"a": 6, "b": 6}))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b"}, tenantsOf(td))
+
+	// a is over its limit, b isn't
+	td, err = rl.processTraces(ctx, newTraces(map[string]int{"a": 6, "b": 4}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, tenantsOf(td))
+
+	// the tokens of a weren't used by the dropped spans
+	td, err = rl.processTraces(ctx, newTraces(map[string]int{"a": 4}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, tenantsOf(td))
+
+	_, err = rl.processTraces(ctx, newTraces(map[string]int{"a": 1, "b": 1}))
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestRejectByMetadata(t *testing.T) {
+	rl := newTestRateLimiter(t, &Config{
+		MetadataKeys:       []string{"x-tenant-id"},
+		ResourceAttributes: []string{"tenant.id"},
+		Rate:               10,
+		Unit:               unitItems,
+		Action:             actionReject,
+		MaxTenants:         10,
+	})
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(t.Context(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"x-tenant-id": {tenant}}),
+		})
+	}
+
+	_, err := rl.processTraces(tenantCtx("acme"), newTraces(map[string]int{"a": 8}))
+	require.NoError(t, err)
+
+	// the whole request is rejected, including the telemetry of b, with a retry hint
+	_, err = rl.processTraces(tenantCtx("acme"), newTraces(map[string]int{"a": 4, "b": 4}))
+	st := status.Convert(err)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, 200*time.Millisecond, retryInfo.RetryDelay.AsDuration())
+
+	// b didn't use its tokens, and another client is a different tenant
+	_, err = rl.processTraces(tenantCtx("acme"), newTraces(map[string]int{"b": 10}))
+	require.NoError(t, err)
+	_, err = rl.processTraces(tenantCtx("other"), newTraces(map[string]int{"a": 10}))
+	require.NoError(t, err)
+}
+
+func TestRequestTenantHashesMetadata(t *testing.T) {
+	rl := &rateLimiter{metadataKeys: []string{"authorization", "x-tenant-id"}}
+	tenantCtx := func(token string) context.Context {
+		return client.NewContext(t.Context(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"authorization": {token}}),
+		})
+	}
+
+	tenant := rl.requestTenant(tenantCtx("Bearer secret"))
+	assert.Regexp(t, `^authorization=[0-9a-f]{16};x-tenant-id=$`, tenant)
+	assert.NotContains(t, tenant, "secret")
+	assert.Equal(t, tenant, rl.requestTenant(tenantCtx("Bearer secret")))
+	assert.NotEqual(t, tenant, rl.requestTenant(tenantCtx("Bearer other")))
+}
+
+func TestLimitBytes(t *testing.T) {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("0123456789")
+	size := (&plog.ProtoMarshaler{}).LogsSize(ld)
+
+	rl := newTestRateLimiter(t, &Config{
+		Rate:       float64(size),
+		Unit:       unitBytes,
+		Action:     actionReject,
+		MaxTenants: 10,
+	})
+	_, err := rl.processLogs(t.Context(), ld)
+	require.NoError(t, err)
+	_, err = rl.processLogs(t.Context(), ld)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLimitDataPoints(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	sum := metrics.AppendEmpty().SetEmptySum()
+	sum.DataPoints().AppendEmpty()
+	sum.DataPoints().AppendEmpty()
+
+	rl := newTestRateLimiter(t, &Config{
+		Rate:       5,
+		Unit:       unitItems,
+		Action:     actionDrop,
+		MaxTenants: 10,
+	})
+	_, err := rl.processMetrics(t.Context(), md)
+	require.NoError(t, err)
+	_, err = rl.processMetrics(t.Context(), md)
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ResourceAttributes = []string{"tenant.id"}
+	cfg.Rate = 5
+	cfg.Action = actionDrop
+	sink := new(consumertest.TracesSink)
+	tp, err := factory.CreateTraces(t.Context(), metadatatest.NewSettings(tel), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, tp.ConsumeTraces(t.Context(), newTraces(map[string]int{"b": 5})))
+	require.NoError(t, tp.ConsumeTraces(t.Context(), newTraces(map[string]int{"a": 4, "b": 1})))
+	require.Equal(t, 9, sink.SpanCount())
+
+	metadatatest.AssertEqualProcessorRatelimitAcceptedItems(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 4, Attributes: attribute.NewSet(attribute.String(tenantKey, "tenant.id=a"))},
+			{Value: 5, Attributes: attribute.NewSet(attribute.String(tenantKey, "tenant.id=b"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorRatelimitThrottledItems(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 1, Attributes: attribute.NewSet(attribute.String(tenantKey, "tenant.id=b"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tp.Shutdown(t.Context()))
+}
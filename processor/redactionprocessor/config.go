@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/db"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
 )
 
 var _ encoding.TextUnmarshaler = (*HashFunction)(nil)
@@ -52,6 +53,15 @@ type Config struct {
 	// By default only string values are redacted.
 	RedactAllTypes bool `mapstructure:"redact_all_types"`
 
+	// RedactSpanNames applies the blocked values and the detectors to span
+	// names. Span names that match are masked, and reported as the `span.name`
+	// key in the summary attributes of the span.
+	RedactSpanNames bool `mapstructure:"redact_span_names"`
+
+	// RedactSpanLinks applies the redaction rules to the attributes of span
+	// links, like they are applied to the attributes of span events.
+	RedactSpanLinks bool `mapstructure:"redact_span_links"`
+
 	// BlockedValues is a list of regular expressions for blocking values of
 	// allowed span attributes. Values that match are masked.
 	BlockedValues []string `mapstructure:"blocked_values"`
@@ -60,6 +70,11 @@ type Config struct {
 	// blocked span attributes. Values that match are not masked.
 	AllowedValues []string `mapstructure:"allowed_values"`
 
+	// Detectors is a list of built-in detectors of personally identifiable
+	// information, e.g. email addresses or credit card numbers, to mask in
+	// the values of allowed span attributes.
+	Detectors []pii.DetectorConfig `mapstructure:"detectors"`
+
 	// DBSanitizer is a flag to enable database query sanitization.
 	DBSanitizer db.DBSanitizerConfig `mapstructure:"db_sanitizer"`
 
@@ -71,6 +86,17 @@ type Config struct {
 	Summary string `mapstructure:"summary"`
 }
 
+// Validate checks the configuration of the detectors.
+func (cfg *Config) Validate() error {
+	var errs []error
+	for _, detector := range cfg.Detectors {
+		if err := detector.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (u HashFunction) String() string {
 	return string(u)
 }
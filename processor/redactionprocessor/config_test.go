@@ -16,6 +16,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/db"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -35,7 +36,12 @@ func TestLoadConfig(t *testing.T) {
 				BlockedKeyPatterns: []string{".*token.*", ".*api_key.*"},
 				HashFunction:       MD5,
 				AllowedValues:      []string{".+@mycompany.com"},
-				Summary:            debug,
+				Detectors: []pii.DetectorConfig{
+					{Name: "email", Masking: pii.MaskingKeepDomain},
+					{Name: "credit_card", Masking: pii.MaskingKeepLast4},
+					{Name: "jwt"},
+				},
+				Summary: debug,
 				DBSanitizer: db.DBSanitizerConfig{
 					SQLConfig: db.SQLConfig{
 						Enabled: false,
@@ -73,6 +79,18 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestLoadInvalidDetectorConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	cfg := NewFactory().CreateDefaultConfig()
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "invalid_detector").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	assert.ErrorContains(t, xconfmap.Validate(cfg), `masking "keep_domain" is not supported by the "ipv4" detector`)
+}
+
 func TestValidateConfig(t *testing.T) {
 	tests := []struct {
 		name     string
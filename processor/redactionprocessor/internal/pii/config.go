@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pii // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaskingMode defines how the values found by a detector are masked.
+type MaskingMode string
+
+const (
+	// MaskingDefault masks the whole value like blocked values are masked,
+	// with the configured hash function or a fixed string.
+	MaskingDefault MaskingMode = ""
+	// MaskingKeepLast4 masks all the letters and digits except the last 4,
+	// keeping separators, e.g. "**** **** **** 1111".
+	MaskingKeepLast4 MaskingMode = "keep_last_4"
+	// MaskingKeepDomain masks the local part of email addresses, e.g. "****@example.com".
+	MaskingKeepDomain MaskingMode = "keep_domain"
+	// MaskingTruncateIP replaces IP addresses with their network, /24 for
+	// IPv4 addresses and /48 for IPv6 addresses, e.g. "192.0.2.0".
+	MaskingTruncateIP MaskingMode = "truncate_ip"
+)
+
+// DetectorConfig enables a built-in detector of personally identifiable information.
+type DetectorConfig struct {
+	// Name of the detector, see Names for the available detectors.
+	Name string `mapstructure:"name"`
+
+	// Masking defines how the detected values are masked. By default, the
+	// values are masked like blocked values.
+	Masking MaskingMode `mapstructure:"masking"`
+}
+
+// Validate checks that the detector exists and supports the masking mode.
+func (cfg DetectorConfig) Validate() error {
+	def, ok := definitions[cfg.Name]
+	if !ok {
+		return fmt.Errorf("unknown detector %q, available detectors are %s", cfg.Name, strings.Join(Names(), ", "))
+	}
+	switch cfg.Masking {
+	case MaskingDefault, MaskingKeepLast4:
+	case MaskingKeepDomain, MaskingTruncateIP:
+		if !slices.Contains(def.maskings, cfg.Masking) {
+			return fmt.Errorf("masking %q is not supported by the %q detector", cfg.Masking, cfg.Name)
+		}
+	default:
+		return fmt.Errorf("unknown masking %q, available maskings are %s, %s and %s", cfg.Masking, MaskingKeepLast4, MaskingKeepDomain, MaskingTruncateIP)
+	}
+	return nil
+}
+
+// Names returns the sorted names of the built-in detectors.
+func Names() []string {
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pii // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
+
+import (
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// definition describes a built-in detector. Candidates are found with a
+// regular expression, and valid reports whether a candidate really is the
+// kind of data the detector looks for, e.g. with a checksum.
+type definition struct {
+	pattern *regexp.Regexp
+	valid   func(string) bool
+	// trim reports whether an invalid candidate is checked again without its
+	// trailing groups of digits, e.g. a card number followed by its CVV, as
+	// the pattern matches as many digits as it can.
+	trim bool
+	// maskings lists the masking modes specific to the kind of data.
+	maskings []MaskingMode
+}
+
+var definitions = map[string]definition{
+	"email": {
+		pattern:  regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`),
+		maskings: []MaskingMode{MaskingKeepDomain},
+	},
+	"credit_card": {
+		pattern: regexp.MustCompile(`\d(?:[ \-]?\d){12,18}`),
+		valid:   validCreditCard,
+		trim:    true,
+	},
+	"iban": {
+		pattern: regexp.MustCompile(`[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}`),
+		valid:   validIBAN,
+	},
+	"phone": {
+		// international numbers, numbers with an area code in parentheses,
+		// and the North American national format, e.g. 555-123-4567
+		pattern: regexp.MustCompile(`(?:\+\d{1,3}|\(\d{2,4}\))[ .\-]?\d(?:[ .\-]?\d){5,13}|\d{3}[ .\-]\d{3}[ .\-]\d{4}`),
+		valid:   validPhone,
+	},
+	"ipv4": {
+		pattern:  regexp.MustCompile(`(?:\d{1,3}\.){3}\d{1,3}`),
+		valid:    validIP(netip.Addr.Is4),
+		maskings: []MaskingMode{MaskingTruncateIP},
+	},
+	"ipv6": {
+		pattern:  regexp.MustCompile(`(?i)(?:[0-9a-f]{0,4}:){2,7}(?:(?:\d{1,3}\.){3}\d{1,3}|[0-9a-f]{0,4})`),
+		valid:    validIP(netip.Addr.Is6),
+		maskings: []MaskingMode{MaskingTruncateIP},
+	},
+	"jwt": {
+		// the header and the payload are base64url encoded JSON objects, starting with `{"`
+		pattern: regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`),
+	},
+	"aws_access_key": {
+		pattern: regexp.MustCompile(`(?:AKIA|ASIA|ABIA|ACCA)[A-Z0-9]{16}`),
+	},
+}
+
+// Detector finds and masks one kind of personally identifiable information in strings.
+type Detector struct {
+	name string
+	definition
+	mask func(string) string
+}
+
+// NewDetectors creates the configured detectors. defaultMask masks the values
+// of the detectors using the default masking mode, and the parts of the values
+// that the other masking modes hide.
+func NewDetectors(cfgs []DetectorConfig, defaultMask func(string) string) ([]*Detector, error) {
+	detectors := make([]*Detector, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+		detectors = append(detectors, &Detector{
+			name:       cfg.Name,
+			definition: definitions[cfg.Name],
+			mask:       masker(cfg.Masking, defaultMask),
+		})
+	}
+	return detectors, nil
+}
+
+// Name returns the name of the detector.
+func (d *Detector) Name() string {
+	return d.name
+}
+
+// Redact masks the values found in the string. Values are only found when
+// they aren't part of a longer word, so that e.g. the digits of an
+// identifier aren't mistaken for a credit card number.
+func (d *Detector) Redact(value string) string {
+	var sb strings.Builder
+	last := 0
+	for pos := 0; pos < len(value); {
+		match := d.pattern.FindStringIndex(value[pos:])
+		if match == nil {
+			break
+		}
+		start, end := pos+match[0], pos+match[1]
+		// the search continues after the match, or after the valid value when
+		// it was trimmed, so that the trimmed digits can start another value
+		pos = end
+		if end = d.find(value, start, end); end < 0 {
+			continue
+		}
+		sb.WriteString(value[last:start])
+		sb.WriteString(d.mask(value[start:end]))
+		last, pos = end, end
+	}
+	if last == 0 {
+		return value
+	}
+	sb.WriteString(value[last:])
+	return sb.String()
+}
+
+// find returns the end of the value matched between start and end, or -1 if
+// the match isn't a valid value. When the detector trims candidates, the
+// match is shortened by a group of digits at a time until a valid value is
+// found.
+func (d *Detector) find(value string, start, end int) int {
+	for {
+		if isBoundary(value, start, end) && (d.valid == nil || d.valid(value[start:end])) {
+			return end
+		}
+		sep := strings.LastIndexAny(value[start:end], " -")
+		if !d.trim || sep < 0 {
+			return -1
+		}
+		end = start + sep
+	}
+}
+
+// isBoundary reports whether the match isn't directly preceded or followed by
+// a letter or a digit.
+func isBoundary(value string, start, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(value[:start]); start > 0 && isWordRune(before) {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(value[end:]); end < len(value) && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func digits(value string) []int {
+	var ds []int
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			ds = append(ds, int(r-'0'))
+		}
+	}
+	return ds
+}
+
+// cardRange is a range of issuer identification numbers (IIN) of a card
+// network, and the numbers of digits of the cards it issues.
+type cardRange struct {
+	// first and last are the lowest and highest prefixes of the range, which
+	// have the same number of digits.
+	first, last int
+	lengths     []int
+}
+
+// cardRanges lists the IIN ranges of the major card networks. Numbers that
+// don't start with one of them, e.g. timestamps, aren't card numbers even
+// when they happen to pass the Luhn check.
+var cardRanges = []cardRange{
+	{first: 4, last: 4, lengths: []int{13, 16, 19}},                       // Visa
+	{first: 51, last: 55, lengths: []int{16}},                             // Mastercard
+	{first: 2221, last: 2720, lengths: []int{16}},                         // Mastercard
+	{first: 34, last: 34, lengths: []int{15}},                             // American Express
+	{first: 37, last: 37, lengths: []int{15}},                             // American Express
+	{first: 6011, last: 6011, lengths: []int{16, 17, 18, 19}},             // Discover
+	{first: 644, last: 649, lengths: []int{16, 17, 18, 19}},               // Discover
+	{first: 65, last: 65, lengths: []int{16, 17, 18, 19}},                 // Discover
+	{first: 3528, last: 3589, lengths: []int{16, 17, 18, 19}},             // JCB
+	{first: 300, last: 305, lengths: []int{14, 15, 16, 17, 18, 19}},       // Diners Club
+	{first: 36, last: 36, lengths: []int{14, 15, 16, 17, 18, 19}},         // Diners Club
+	{first: 38, last: 39, lengths: []int{16, 17, 18, 19}},                 // Diners Club
+	{first: 62, last: 62, lengths: []int{16, 17, 18, 19}},                 // UnionPay
+	{first: 5018, last: 5018, lengths: []int{13, 14, 15, 16, 17, 18, 19}}, // Maestro
+	{first: 5020, last: 5020, lengths: []int{13, 14, 15, 16, 17, 18, 19}}, // Maestro
+	{first: 5038, last: 5038, lengths: []int{13, 14, 15, 16, 17, 18, 19}}, // Maestro
+	{first: 5893, last: 5893, lengths: []int{13, 14, 15, 16, 17, 18, 19}}, // Maestro
+	{first: 6304, last: 6304, lengths: []int{13, 14, 15, 16, 17, 18, 19}}, // Maestro
+	{first: 6759, last: 6763, lengths: []int{13, 14, 15, 16, 17, 18, 19}}, // Maestro
+}
+
+// matches reports whether the card number ds is in the range and has one of
+// its lengths.
+func (r cardRange) matches(ds []int) bool {
+	if !slices.Contains(r.lengths, len(ds)) {
+		return false
+	}
+	prefix := 0
+	for _, d := range ds[:len(strconv.Itoa(r.last))] {
+		prefix = prefix*10 + d
+	}
+	return prefix >= r.first && prefix <= r.last
+}
+
+// validCreditCard checks that the number starts with the IIN of a known card
+// network, has one of the lengths of the network and passes the Luhn checksum.
+func validCreditCard(value string) bool {
+	ds := digits(value)
+	if !slices.ContainsFunc(cardRanges, func(r cardRange) bool { return r.matches(ds) }) {
+		return false
+	}
+	sum := 0
+	for i := range ds {
+		d := ds[len(ds)-1-i]
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// validIBAN checks the length and the ISO 7064 MOD 97-10 checksum.
+func validIBAN(value string) bool {
+	iban := strings.ReplaceAll(value, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	// the country code and check digits are moved to the end, and letters
+	// are replaced by numbers, A being 10
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+// validPhone checks that the number has between 8 and 15 digits, the maximum of E.164.
+func validPhone(value string) bool {
+	n := len(digits(value))
+	return n >= 8 && n <= 15
+}
+
+func validIP(family func(netip.Addr) bool) func(string) bool {
+	return func(value string) bool {
+		addr, err := netip.ParseAddr(value)
+		return err == nil && family(addr)
+	}
+}
+
+func masker(mode MaskingMode, defaultMask func(string) string) func(string) string {
+	switch mode {
+	case MaskingKeepLast4:
+		return keepLast4
+	case MaskingKeepDomain:
+		return func(value string) string {
+			at := strings.LastIndexByte(value, '@')
+			return defaultMask(value[:at]) + value[at:]
+		}
+	case MaskingTruncateIP:
+		return truncateIP
+	default:
+		return defaultMask
+	}
+}
+
+// keepLast4 replaces the letters and digits with '*', except the last 4.
+func keepLast4(value string) string {
+	runes := []rune(value)
+	kept := 0
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !isWordRune(runes[i]) {
+			continue
+		}
+		if kept < 4 {
+			kept++
+			continue
+		}
+		runes[i] = '*'
+	}
+	return string(runes)
+}
+
+func truncateIP(value string) string {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return value
+	}
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, _ := addr.Prefix(bits)
+	return prefix.Addr().String()
+}
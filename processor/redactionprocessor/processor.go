@@ -23,6 +23,7 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/db"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
 )
 
 const attrValuesSeparator = ","
@@ -46,6 +47,8 @@ type redaction struct {
 	logger *zap.Logger
 	// Database obfuscator
 	dbObfuscator *db.Obfuscator
+	// Detectors of personally identifiable information
+	detectors []*pii.Detector
 }
 
 // newRedaction creates a new instance of the redaction processor
@@ -71,7 +74,7 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 
 	dbObfuscator := db.NewObfuscator(config.DBSanitizer)
 
-	return &redaction{
+	s := &redaction{
 		allowList:         allowList,
 		ignoreList:        ignoreList,
 		blockRegexList:    blockRegexList,
@@ -81,7 +84,12 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 		config:            config,
 		logger:            logger,
 		dbObfuscator:      dbObfuscator,
-	}, nil
+	}
+	s.detectors, err = pii.NewDetectors(config.Detectors, s.hashValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create detectors: %w", err)
+	}
+	return s, nil
 }
 
 // processTraces implements ProcessMetricsFunc. It processes the incoming data
@@ -129,6 +137,13 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 
 			// Attributes can also be part of span events
 			s.processSpanEvents(ctx, span.Events())
+
+			if s.config.RedactSpanLinks {
+				s.processSpanLinks(ctx, span.Links())
+			}
+			if s.config.RedactSpanNames {
+				s.processSpanName(span)
+			}
 		}
 	}
 }
@@ -139,6 +154,27 @@ func (s *redaction) processSpanEvents(ctx context.Context, events ptrace.SpanEve
 	}
 }
 
+func (s *redaction) processSpanLinks(ctx context.Context, links ptrace.SpanLinkSlice) {
+	for i := 0; i < links.Len(); i++ {
+		s.processAttrs(ctx, links.At(i).Attributes())
+	}
+}
+
+// processSpanName masks the blocked values in the span name, and reports the
+// masking in the summary attributes of the span with the spanNameKey key.
+func (s *redaction) processSpanName(span ptrace.Span) {
+	name := span.Name()
+	if s.shouldAllowValue(name) {
+		s.addMetaAttrs([]string{spanNameKey}, span.Attributes(), redactionAllowedKeys, redactionAllowedCount)
+		return
+	}
+	maskedName := s.maskBlockedValues(name)
+	if maskedName != name {
+		span.SetName(maskedName)
+		s.addMetaAttrs([]string{spanNameKey}, span.Attributes(), redactionMaskedKeys, redactionMaskedCount)
+	}
+}
+
 // processResourceLog processes the log resource and all of its logs and then returns the last
 // view metric context. The context can be used for tests
 func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs) {
@@ -348,21 +384,25 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	s.addMetaAttrs(ignoredKeys, attributes, "", redactionIgnoredCount)
 }
 
-//nolint:gosec
 func (s *redaction) maskValue(val string, regex *regexp.Regexp) string {
-	hashFunc := func(match string) string {
-		switch s.hashFunction {
-		case SHA1:
-			return hashString(match, sha1.New())
-		case SHA3:
-			return hashString(match, sha3.New256())
-		case MD5:
-			return hashString(match, md5.New())
-		default:
-			return "****"
-		}
+	return regex.ReplaceAllStringFunc(val, s.hashValue)
+}
+
+// hashValue hashes the value with the configured hash function, or masks it
+// with a fixed string.
+//
+//nolint:gosec
+func (s *redaction) hashValue(match string) string {
+	switch s.hashFunction {
+	case SHA1:
+		return hashString(match, sha1.New())
+	case SHA3:
+		return hashString(match, sha3.New256())
+	case MD5:
+		return hashString(match, md5.New())
+	default:
+		return "****"
 	}
-	return regex.ReplaceAllStringFunc(val, hashFunc)
 }
 
 func hashString(input string, hasher hash.Hash) string {
@@ -393,7 +433,9 @@ func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map,
 	}
 }
 
-func (s *redaction) processStringValueForAttribute(strVal, attributeKey string) string {
+// maskBlockedValues masks the parts of the value matching a blocked value or
+// found by a detector.
+func (s *redaction) maskBlockedValues(strVal string) string {
 	for _, compiledRE := range s.blockRegexList {
 		match := compiledRE.MatchString(strVal)
 		if match {
@@ -401,6 +443,16 @@ func (s *redaction) processStringValueForAttribute(strVal, attributeKey string)
 		}
 	}
 
+	for _, detector := range s.detectors {
+		strVal = detector.Redact(strVal)
+	}
+
+	return strVal
+}
+
+func (s *redaction) processStringValueForAttribute(strVal, attributeKey string) string {
+	strVal = s.maskBlockedValues(strVal)
+
 	if s.dbObfuscator != nil {
 		obfuscatedQuery, err := s.dbObfuscator.ObfuscateAttribute(strVal, attributeKey)
 		if err != nil {
@@ -414,12 +466,7 @@ func (s *redaction) processStringValueForAttribute(strVal, attributeKey string)
 
 func (s *redaction) processStringValueForLogBody(strVal string) string {
 	// Mask any blocked values for the other attributes
-	for _, compiledRE := range s.blockRegexList {
-		match := compiledRE.MatchString(strVal)
-		if match {
-			strVal = s.maskValue(strVal, compiledRE)
-		}
-	}
+	strVal = s.maskBlockedValues(strVal)
 
 	if s.dbObfuscator != nil {
 		obfuscatedQuery, err := s.dbObfuscator.Obfuscate(strVal)
@@ -469,6 +516,9 @@ func (s *redaction) shouldRedactKey(k string) bool {
 }
 
 const (
+	// spanNameKey identifies the span name in the summary attributes
+	spanNameKey = "span.name"
+
 	debug                      = "debug"
 	info                       = "info"
 	redactionRedactedKeys      = "redaction.redacted.keys"
@@ -16,6 +16,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
 )
 
 type testConfig struct {
@@ -840,6 +842,159 @@ func TestSpanEventRedacted(t *testing.T) {
 	require.Equal(t, "foobar", val.Str())
 }
 
+func TestSpanLinkRedacted(t *testing.T) {
+	newTraces := func() ptrace.Traces {
+		inBatch := ptrace.NewTraces()
+		span := inBatch.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName("first-batch-first-span")
+		link := span.Links().AppendEmpty()
+		link.Attributes().PutStr("password", "xyzxyz")
+		link.Attributes().PutStr("username", "foobar")
+		return inBatch
+	}
+
+	tests := []struct {
+		name             string
+		redactSpanLinks  bool
+		expectedPassword string
+	}{
+		{
+			name:             "disabled",
+			expectedPassword: "xyzxyz",
+		},
+		{
+			name:             "enabled",
+			redactSpanLinks:  true,
+			expectedPassword: "****",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				AllowAllKeys:    true,
+				BlockedValues:   []string{"xyzxyz"},
+				RedactSpanLinks: tt.redactSpanLinks,
+				Summary:         "debug",
+			}
+			processor, err := newRedaction(t.Context(), config, zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			outTraces, err := processor.processTraces(t.Context(), newTraces())
+			require.NoError(t, err)
+
+			attr := outTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Links().At(0).Attributes()
+
+			val, ok := attr.Get("password")
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedPassword, val.Str())
+
+			val, ok = attr.Get("username")
+			require.True(t, ok)
+			assert.Equal(t, "foobar", val.Str())
+
+			_, ok = attr.Get(redactionMaskedKeys)
+			assert.Equal(t, tt.redactSpanLinks, ok)
+		})
+	}
+}
+
+func TestSpanNameRedacted(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *Config
+		spanName     string
+		expectedName string
+		maskedKeys   string
+		allowedKeys  string
+	}{
+		{
+			name: "disabled",
+			config: &Config{
+				AllowAllKeys:  true,
+				BlockedValues: []string{"[0-9]{16}"},
+				Summary:       "debug",
+			},
+			spanName:     "GET /cards/4111111111111111",
+			expectedName: "GET /cards/4111111111111111",
+		},
+		{
+			name: "blocked value",
+			config: &Config{
+				AllowAllKeys:    true,
+				BlockedValues:   []string{"[0-9]{16}"},
+				RedactSpanNames: true,
+				Summary:         "debug",
+			},
+			spanName:     "GET /cards/4111111111111111",
+			expectedName: "GET /cards/****",
+			maskedKeys:   "span.name",
+		},
+		{
+			name: "detector",
+			config: &Config{
+				AllowAllKeys:    true,
+				Detectors:       []pii.DetectorConfig{{Name: "email", Masking: pii.MaskingKeepDomain}},
+				RedactSpanNames: true,
+				Summary:         "debug",
+			},
+			spanName:     "notify john@example.com",
+			expectedName: "notify ****@example.com",
+			maskedKeys:   "span.name",
+		},
+		{
+			name: "allowed value",
+			config: &Config{
+				AllowAllKeys:    true,
+				BlockedValues:   []string{"[0-9]{16}"},
+				AllowedValues:   []string{"^GET /health"},
+				RedactSpanNames: true,
+				Summary:         "debug",
+			},
+			spanName:     "GET /health/4111111111111111",
+			expectedName: "GET /health/4111111111111111",
+			allowedKeys:  "span.name",
+		},
+		{
+			name: "nothing to mask",
+			config: &Config{
+				AllowAllKeys:    true,
+				BlockedValues:   []string{"[0-9]{16}"},
+				RedactSpanNames: true,
+				Summary:         "debug",
+			},
+			spanName:     "GET /cards",
+			expectedName: "GET /cards",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inBatch := ptrace.NewTraces()
+			span := inBatch.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName(tt.spanName)
+			span.Attributes().PutStr("http.request.method", "GET")
+
+			processor, err := newRedaction(t.Context(), tt.config, zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			outTraces, err := processor.processTraces(t.Context(), inBatch)
+			require.NoError(t, err)
+
+			outSpan := outTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expectedName, outSpan.Name())
+
+			summary := func(key string) string {
+				val, ok := outSpan.Attributes().Get(key)
+				if !ok {
+					return ""
+				}
+				return val.Str()
+			}
+			assert.Equal(t, tt.maskedKeys, summary(redactionMaskedKeys))
+			assert.Equal(t, tt.allowedKeys, summary(redactionAllowedKeys))
+		})
+	}
+}
+
 func TestLogBodyRedactionDifferentTypes(t *testing.T) {
 	stringBody := pcommon.NewValueStr("placeholder 4111111111111111")
 	tc := testConfig{
@@ -942,6 +1097,57 @@ func TestLogBodyRedactionDifferentTypes(t *testing.T) {
 }
 
 // runTest transforms the test input data and passes it through the processor
+// TestDetectors validates that the values found by the detectors are masked with their masking mode
+func TestDetectors(t *testing.T) {
+	config := &Config{
+		AllowAllKeys: true,
+		HashFunction: MD5,
+		Detectors: []pii.DetectorConfig{
+			{Name: "email", Masking: pii.MaskingKeepDomain},
+			{Name: "credit_card", Masking: pii.MaskingKeepLast4},
+			{Name: "ipv4", Masking: pii.MaskingTruncateIP},
+			{Name: "jwt"},
+		},
+		Summary: "debug",
+	}
+	processor, err := newRedaction(t.Context(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	attrs := pcommon.NewMap()
+	assert.NoError(t, attrs.FromRaw(map[string]any{
+		"user":    "jane@example.com",
+		"payment": "card 4111 1111 1111 1111",
+		"client":  "203.0.113.42",
+		"token":   "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxMjM0In0.sig",
+		"order":   "1234567890",
+	}))
+	processor.processAttrs(t.Context(), attrs)
+
+	expected := map[string]string{
+		"user":    "5844a15e76563fedd11840fd6f40ea7b@example.com",
+		"payment": "card **** **** **** 1111",
+		"client":  "203.0.113.0",
+		"token":   "d9655661f16621a3023c7e3318c8a305",
+		"order":   "1234567890",
+	}
+	for k, v := range expected {
+		val, found := attrs.Get(k)
+		assert.True(t, found)
+		assert.Equal(t, v, val.Str(), k)
+	}
+	val, found := attrs.Get(redactionMaskedKeys)
+	assert.True(t, found)
+	assert.Equal(t, "client,payment,token,user", val.Str())
+
+	body := pcommon.NewValueStr("login from 198.51.100.7 by bob@example.org")
+	logAttrs := pcommon.NewMap()
+	processor.processLogBody(t.Context(), body, logAttrs)
+	assert.Equal(t, "login from 198.51.100.0 by 9f9d51bc70ef21ca5c14f307980a29d8@example.org", body.Str())
+	val, found = logAttrs.Get(redactionBodyMaskedKeys)
+	assert.True(t, found)
+	assert.Equal(t, "body", val.Str())
+}
+
 func runTest(
 	t *testing.T,
 	cfg testConfig,
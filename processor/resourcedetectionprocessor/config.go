@@ -4,6 +4,8 @@
 package resourcedetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
@@ -14,8 +16,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/lambda"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/baremetal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/file"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
@@ -32,6 +36,9 @@ type Config struct {
 	// Override indicates whether any existing resource attributes
 	// should be overridden or preserved. Defaults to true.
 	Override bool `mapstructure:"override"`
+	// RefreshInterval is the interval at which the detectors are run again, to update
+	// the detected resource. The resource is only detected at startup when not set.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 	// DetectorConfig is a list of settings specific to all detectors
 	DetectorConfig DetectorConfig `mapstructure:",squash"`
 	// HTTP client settings for the detector
@@ -66,12 +73,18 @@ type DetectorConfig struct {
 	// Aks contains user-specified configurations for the aks detector
 	AksConfig aks.Config `mapstructure:"aks"`
 
+	// BareMetalConfig contains user-specified configurations for the baremetal detector
+	BareMetalConfig baremetal.Config `mapstructure:"baremetal"`
+
 	// ConsulConfig contains user-specified configurations for the Consul detector
 	ConsulConfig consul.Config `mapstructure:"consul"`
 
 	// DockerConfig contains user-specified configurations for the docker detector
 	DockerConfig docker.Config `mapstructure:"docker"`
 
+	// FileConfig contains user-specified configurations for the file detector
+	FileConfig file.Config `mapstructure:"file"`
+
 	// GcpConfig contains user-specified configurations for the gcp detector
 	GcpConfig gcp.Config `mapstructure:"gcp"`
 
@@ -100,8 +113,10 @@ func detectorCreateDefaultConfig() DetectorConfig {
 		LambdaConfig:           lambda.CreateDefaultConfig(),
 		AzureConfig:            azure.CreateDefaultConfig(),
 		AksConfig:              aks.CreateDefaultConfig(),
+		BareMetalConfig:        baremetal.CreateDefaultConfig(),
 		ConsulConfig:           consul.CreateDefaultConfig(),
 		DockerConfig:           docker.CreateDefaultConfig(),
+		FileConfig:             file.CreateDefaultConfig(),
 		GcpConfig:              gcp.CreateDefaultConfig(),
 		HerokuConfig:           heroku.CreateDefaultConfig(),
 		SystemConfig:           system.CreateDefaultConfig(),
@@ -127,10 +142,14 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.AzureConfig
 	case aks.TypeStr:
 		return d.AksConfig
+	case baremetal.TypeStr:
+		return d.BareMetalConfig
 	case consul.TypeStr:
 		return d.ConsulConfig
 	case docker.TypeStr:
 		return d.DockerConfig
+	case file.TypeStr:
+		return d.FileConfig
 	case gcp.TypeStr:
 		return d.GcpConfig
 	case heroku.TypeStr:
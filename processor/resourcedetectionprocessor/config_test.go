@@ -19,6 +19,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/lambda"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/baremetal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/file"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
@@ -54,6 +56,14 @@ func TestLoadConfig(t *testing.T) {
 		ResourceAttributes: system.CreateDefaultConfig().ResourceAttributes,
 	}
 
+	fileConfig := detectorCreateDefaultConfig()
+	fileConfig.FileConfig = file.Config{
+		Files: []file.FileConfig{
+			{Path: "/etc/otel/resource.d/*.yaml"},
+			{Path: "/etc/provisioning", Format: "env", KeyPrefix: "provisioning."},
+		},
+	}
+
 	resourceAttributesConfig := detectorCreateDefaultConfig()
 	ec2ResourceAttributesConfig := ec2.CreateDefaultConfig()
 	ec2ResourceAttributesConfig.ResourceAttributes.HostName.Enabled = false
@@ -115,6 +125,20 @@ func TestLoadConfig(t *testing.T) {
 				DetectorConfig: detectorCreateDefaultConfig(),
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "file"),
+			expected: &Config{
+				Detectors:       []string{"env", "file"},
+				ClientConfig:    cfg,
+				Override:        false,
+				RefreshInterval: time.Minute,
+				DetectorConfig:  fileConfig,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_file_format"),
+			errorMessage: `files: invalid format "toml" for path "/etc/otel/resource.toml", must be one of "json", "yaml" or "env"`,
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "lambda"),
 			expected: &Config{
@@ -204,6 +228,18 @@ func TestGetConfigFromType(t *testing.T) {
 			inputDetectorConfig: herokuDetectorConfig,
 			expectedConfig:      herokuDetectorConfig.HerokuConfig,
 		},
+		{
+			name:         "Get BareMetal Config",
+			detectorType: baremetal.TypeStr,
+			inputDetectorConfig: DetectorConfig{
+				BareMetalConfig: baremetal.Config{
+					RootPath: "/hostfs",
+				},
+			},
+			expectedConfig: baremetal.Config{
+				RootPath: "/hostfs",
+			},
+		},
 		{
 			name:                "Get AWS Lambda Config",
 			detectorType:        lambda.TypeStr,
@@ -9,6 +9,7 @@
 //go:generate mdatagen internal/aws/lambda/metadata.yaml
 //go:generate mdatagen internal/azure/aks/metadata.yaml
 //go:generate mdatagen internal/azure/metadata.yaml
+//go:generate mdatagen internal/baremetal/metadata.yaml
 //go:generate mdatagen internal/consul/metadata.yaml
 //go:generate mdatagen internal/docker/metadata.yaml
 //go:generate mdatagen internal/gcp/metadata.yaml
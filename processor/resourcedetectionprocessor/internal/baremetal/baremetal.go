@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package baremetal // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/baremetal"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/baremetal/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "baremetal"
+)
+
+// Paths of the metadata files, relative to the configured root path.
+const (
+	dmiDir                    = "sys/class/dmi/id"
+	cloudInitInstanceDataPath = "run/cloud-init/instance-data.json"
+	cloudInitInstanceIDPath   = "var/lib/cloud/data/instance-id"
+	osReleasePath             = "etc/os-release"
+	osReleaseFallbackPath     = "usr/lib/os-release"
+	machineIDPath             = "etc/machine-id"
+	machineIDFallbackPath     = "var/lib/dbus/machine-id"
+	hostnamePath              = "etc/hostname"
+)
+
+// dmiPlaceholders are values left in DMI fields by vendors that did not fill them in.
+var dmiPlaceholders = map[string]bool{
+	"":                       true,
+	"0123456789":             true,
+	"chassis manufacturer":   true,
+	"default string":         true,
+	"n/a":                    true,
+	"none":                   true,
+	"not applicable":         true,
+	"not specified":          true,
+	"o.e.m.":                 true,
+	"system manufacturer":    true,
+	"system product name":    true,
+	"system serial number":   true,
+	"to be filled by o.e.m.": true,
+	"unknown":                true,
+}
+
+// chassisTypes maps the SMBIOS chassis type codes to their names, see
+// the System Enclosure or Chassis Types table of the SMBIOS specification.
+var chassisTypes = map[string]string{
+	"1":  "Other",
+	"2":  "Unknown",
+	"3":  "Desktop",
+	"4":  "Low Profile Desktop",
+	"5":  "Pizza Box",
+	"6":  "Mini Tower",
+	"7":  "Tower",
+	"8":  "Portable",
+	"9":  "Laptop",
+	"10": "Notebook",
+	"11": "Hand Held",
+	"12": "Docking Station",
+	"13": "All in One",
+	"14": "Sub Notebook",
+	"15": "Space-saving",
+	"16": "Lunch Box",
+	"17": "Main Server Chassis",
+	"18": "Expansion Chassis",
+	"19": "SubChassis",
+	"20": "Bus Expansion Chassis",
+	"21": "Peripheral Chassis",
+	"22": "RAID Chassis",
+	"23": "Rack Mount Chassis",
+	"24": "Sealed-case PC",
+	"25": "Multi-system chassis",
+	"26": "Compact PCI",
+	"27": "Advanced TCA",
+	"28": "Blade",
+	"29": "Blade Enclosure",
+	"30": "Tablet",
+	"31": "Convertible",
+	"32": "Detachable",
+	"33": "IoT Gateway",
+	"34": "Embedded PC",
+	"35": "Mini PC",
+	"36": "Stick PC",
+}
+
+// cloudProviders maps the cloud-init cloud names of the clouds known to the semantic
+// conventions to their cloud.provider values. Other clouds, e.g. maas or openstack,
+// have no cloud.provider value and are not reported.
+var cloudProviders = map[string]string{
+	"aliyun":   conventions.CloudProviderAlibabaCloud.Value.AsString(),
+	"aws":      conventions.CloudProviderAWS.Value.AsString(),
+	"azure":    conventions.CloudProviderAzure.Value.AsString(),
+	"gce":      conventions.CloudProviderGCP.Value.AsString(),
+	"ibmcloud": "ibm_cloud",
+	"oracle":   "oracle_cloud",
+}
+
+// cloudInitInstanceData holds the standardized keys of the cloud-init instance data.
+// See https://cloudinit.readthedocs.io/en/latest/explanation/instancedata.html
+type cloudInitInstanceData struct {
+	V1 struct {
+		AvailabilityZone string `json:"availability_zone"`
+		CloudName        string `json:"cloud_name"`
+		InstanceID       string `json:"instance_id"`
+		LocalHostname    string `json:"local_hostname"`
+		Region           string `json:"region"`
+	} `json:"v1"`
+}
+
+// NewDetector returns a detector which can detect resource attributes of on-premises
+// and bare-metal hosts from DMI, cloud-init and systemd metadata files.
+func NewDetector(set processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	rootPath := cfg.RootPath
+	if rootPath == "" {
+		rootPath = "/"
+	}
+	return &detector{
+		logger:   set.Logger,
+		rootPath: rootPath,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+type detector struct {
+	logger   *zap.Logger
+	rootPath string
+	rb       *metadata.ResourceBuilder
+}
+
+// Detect detects the host metadata and returns a resource with the available ones.
+// Missing metadata files are skipped.
+func (d *detector) Detect(_ context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	d.detectDMI()
+	d.detectOSRelease()
+
+	hostID := d.readFirstFile(machineIDPath, machineIDFallbackPath)
+	hostname := d.readFirstFile(hostnamePath)
+	if data, ok := d.readCloudInitInstanceData(); ok {
+		if data.V1.InstanceID != "" {
+			hostID = data.V1.InstanceID
+		}
+		if data.V1.LocalHostname != "" {
+			hostname = data.V1.LocalHostname
+		}
+		if provider, ok := cloudProviders[strings.ToLower(data.V1.CloudName)]; ok {
+			d.rb.SetCloudProvider(provider)
+		}
+		if data.V1.Region != "" {
+			d.rb.SetCloudRegion(data.V1.Region)
+		}
+		if data.V1.AvailabilityZone != "" {
+			d.rb.SetCloudAvailabilityZone(data.V1.AvailabilityZone)
+		}
+	} else if instanceID := d.readFirstFile(cloudInitInstanceIDPath); instanceID != "" {
+		hostID = instanceID
+	}
+	if hostID != "" {
+		d.rb.SetHostID(hostID)
+	}
+	if hostname != "" {
+		d.rb.SetHostName(hostname)
+	}
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
+
+func (d *detector) detectDMI() {
+	if v := d.readDMI("sys_vendor"); v != "" {
+		d.rb.SetDmiSystemVendor(v)
+	}
+	if v := d.readDMI("product_name"); v != "" {
+		d.rb.SetHostType(v)
+	}
+	if v := d.readDMI("product_serial"); v != "" {
+		d.rb.SetDmiSystemSerial(v)
+	}
+	if v := d.readDMI("chassis_vendor"); v != "" {
+		d.rb.SetDmiChassisVendor(v)
+	}
+	if v := d.readDMI("chassis_type"); v != "" {
+		if name, ok := chassisTypes[v]; ok {
+			v = name
+		}
+		d.rb.SetDmiChassisType(v)
+	}
+}
+
+func (d *detector) detectOSRelease() {
+	data := d.readFirstFile(osReleasePath, osReleaseFallbackPath)
+	if data == "" {
+		return
+	}
+	values := parseOSRelease(data)
+	if v := values["NAME"]; v != "" {
+		d.rb.SetOSName(v)
+	}
+	if v := values["VERSION_ID"]; v != "" {
+		d.rb.SetOSVersion(v)
+	}
+	if v := values["PRETTY_NAME"]; v != "" {
+		d.rb.SetOSDescription(v)
+	}
+	if v := values["BUILD_ID"]; v != "" {
+		d.rb.SetOSBuildID(v)
+	}
+}
+
+func (d *detector) readCloudInitInstanceData() (cloudInitInstanceData, bool) {
+	var data cloudInitInstanceData
+	content := d.readFirstFile(cloudInitInstanceDataPath)
+	if content == "" {
+		return data, false
+	}
+	if err := json.Unmarshal([]byte(content), &data); err != nil {
+		d.logger.Debug("Failed to parse cloud-init instance data", zap.Error(err))
+		return data, false
+	}
+	return data, true
+}
+
+// readDMI returns the value of a DMI field, or an empty string when it is not
+// available or holds a placeholder value.
+func (d *detector) readDMI(field string) string {
+	v := d.readFirstFile(filepath.Join(dmiDir, field))
+	if dmiPlaceholders[strings.ToLower(v)] {
+		return ""
+	}
+	return v
+}
+
+// readFirstFile returns the trimmed content of the first non empty file among the
+// given paths, or an empty string when none can be read.
+func (d *detector) readFirstFile(paths ...string) string {
+	for _, path := range paths {
+		content, err := os.ReadFile(filepath.Join(d.rootPath, path))
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				d.logger.Debug("Failed to read host metadata file", zap.String("path", path), zap.Error(err))
+			}
+			continue
+		}
+		if v := strings.TrimSpace(string(content)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseOSRelease parses the content of an os-release file.
+// See https://www.freedesktop.org/software/systemd/man/latest/os-release.html
+func parseOSRelease(data string) map[string]string {
+	values := map[string]string{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(key)] = unquoteOSReleaseValue(strings.TrimSpace(value))
+	}
+	return values
+}
+
+func unquoteOSReleaseValue(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		value = value[1 : len(value)-1]
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\$`, `$`, "\\`", "`").Replace(value)
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package baremetal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+)
+
+func TestDetectProvisioned(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.RootPath = filepath.Join("testdata", "provisioned")
+	detector, err := NewDetector(processortest.NewNopSettings(processortest.NopType), cfg)
+	require.NoError(t, err)
+	res, schemaURL, err := detector.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.availability_zone": "rack-12",
+		"dmi.chassis.type":        "Rack Mount Chassis",
+		"dmi.chassis.vendor":      "Dell Inc.",
+		"dmi.system.vendor":       "Dell Inc.",
+		"host.id":                 "node-4y7tqm",
+		"host.name":               "compute-042",
+		"host.type":               "PowerEdge R740",
+		"os.description":          "Ubuntu 22.04.4 LTS",
+		"os.name":                 "Ubuntu",
+		"os.version":              "22.04",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectUnprovisioned(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.RootPath = filepath.Join("testdata", "unprovisioned")
+	cfg.ResourceAttributes.DmiSystemSerial.Enabled = true
+	detector, err := NewDetector(processortest.NewNopSettings(processortest.NopType), cfg)
+	require.NoError(t, err)
+	res, _, err := detector.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"dmi.chassis.type": "Desktop",
+		"host.id":          "9f86d081884c7d659a2feaa0c55ad015",
+		"host.name":        "workstation-7",
+		"host.type":        "B450M Pro4",
+		"os.build.id":      "39.20240210.3.0",
+		"os.description":   `Fedora CoreOS 39.20240210.3.0 ("CoreOS")`,
+		"os.name":          "Fedora Linux",
+		"os.version":       "39",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectSystemSerial(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.RootPath = filepath.Join("testdata", "provisioned")
+	cfg.ResourceAttributes.DmiSystemSerial.Enabled = true
+	detector, err := NewDetector(processortest.NewNopSettings(processortest.NopType), cfg)
+	require.NoError(t, err)
+	res, _, err := detector.Detect(t.Context())
+	require.NoError(t, err)
+	serial, ok := res.Attributes().Get("dmi.system.serial")
+	require.True(t, ok)
+	assert.Equal(t, "ABC1234", serial.Str())
+}
+
+func TestDetectCloudProvider(t *testing.T) {
+	tests := []struct {
+		cloudName string
+		want      any
+	}{
+		{cloudName: "aws", want: "aws"},
+		{cloudName: "gce", want: "gcp"},
+		{cloudName: "aliyun", want: "alibaba_cloud"},
+		{cloudName: "maas"},
+		{cloudName: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cloudName, func(t *testing.T) {
+			rootPath := t.TempDir()
+			path := filepath.Join(rootPath, cloudInitInstanceDataPath)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, []byte(`{"v1": {"cloud_name": "`+tt.cloudName+`"}}`), 0o600))
+
+			cfg := CreateDefaultConfig()
+			cfg.RootPath = rootPath
+			detector, err := NewDetector(processortest.NewNopSettings(processortest.NopType), cfg)
+			require.NoError(t, err)
+			res, _, err := detector.Detect(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res.Attributes().AsRaw()["cloud.provider"])
+		})
+	}
+}
+
+func TestDetectNoMetadata(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.RootPath = t.TempDir()
+	detector, err := NewDetector(processortest.NewNopSettings(processortest.NopType), cfg)
+	require.NoError(t, err)
+	res, _, err := detector.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
+
+func TestParseOSRelease(t *testing.T) {
+	values := parseOSRelease(`# comment
+NAME=Debian
+PRETTY_NAME="Debian GNU/Linux 12 (bookworm)"
+VERSION_ID='12'
+VARIANT="Cost \$0 \"edition\""
+
+malformed
+`)
+	assert.Equal(t, map[string]string{
+		"NAME":        "Debian",
+		"PRETTY_NAME": "Debian GNU/Linux 12 (bookworm)",
+		"VERSION_ID":  "12",
+		"VARIANT":     `Cost $0 "edition"`,
+	}, values)
+}
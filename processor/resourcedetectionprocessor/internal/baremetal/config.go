@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package baremetal // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/baremetal"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/baremetal/internal/metadata"
+)
+
+// Config defines user-specified configurations unique to the baremetal detector
+type Config struct {
+	// RootPath is the path of the host root filesystem the metadata files are read from,
+	// e.g. `/hostfs` when the collector runs in a container with the host filesystem
+	// mounted. (**default**: `/`)
+	RootPath string `mapstructure:"root_path"`
+
+	ResourceAttributes metadata.ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		RootPath:           "/",
+		ResourceAttributes: metadata.DefaultResourceAttributesConfig(),
+	}
+}
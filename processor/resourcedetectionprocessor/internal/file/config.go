@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/file"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+	formatEnv  = "env"
+)
+
+// Config defines user-specified configurations unique to the file detector
+type Config struct {
+	// Files is the list of files the resource attributes are read from. When an attribute
+	// is found in several files, the value read last is kept.
+	Files []FileConfig `mapstructure:"files"`
+}
+
+// FileConfig defines files to read resource attributes from.
+type FileConfig struct {
+	// Path of the files, which can be a glob pattern, e.g. `/etc/otel/resource.d/*.yaml`.
+	// The files matching a pattern are read in lexical order.
+	Path string `mapstructure:"path"`
+
+	// Format of the files, one of `json`, `yaml` or `env`. When not set, it is guessed
+	// from the extension of the files, and defaults to `env`.
+	Format string `mapstructure:"format"`
+
+	// KeyPrefix is prepended to the keys of the attributes read from the files.
+	KeyPrefix string `mapstructure:"key_prefix"`
+}
+
+// Validate config
+func (cfg *Config) Validate() error {
+	for _, file := range cfg.Files {
+		if file.Path == "" {
+			return errors.New("files: path must not be empty")
+		}
+		if _, err := filepath.Match(file.Path, ""); err != nil {
+			return fmt.Errorf("files: invalid path %q: %w", file.Path, err)
+		}
+		switch file.Format {
+		case "", formatJSON, formatYAML, formatEnv:
+		default:
+			return fmt.Errorf("files: invalid format %q for path %q, must be one of %q, %q or %q", file.Format, file.Path, formatJSON, formatYAML, formatEnv)
+		}
+	}
+	return nil
+}
+
+func CreateDefaultConfig() Config {
+	return Config{}
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package file provides a detector that loads resource information from
+// JSON, YAML or env files, e.g. written by a provisioning system. Nested
+// keys of JSON and YAML files are flattened with dots.
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/file"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// TypeStr is type of detector.
+const TypeStr = "file"
+
+var _ internal.Detector = (*detector)(nil)
+
+type detector struct {
+	logger *zap.Logger
+	files  []FileConfig
+}
+
+// NewDetector returns a detector which reads resource attributes from files.
+func NewDetector(set processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &detector{
+		logger: set.Logger,
+		files:  cfg.Files,
+	}, nil
+}
+
+// Detect reads the configured files, and returns a resource with the attributes found.
+// Paths not matching any file are skipped, as the files may not have been written yet.
+func (d *detector) Detect(context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	res := pcommon.NewResource()
+	for _, file := range d.files {
+		paths, err := filepath.Glob(file.Path)
+		if err != nil {
+			return res, "", fmt.Errorf("invalid path %q: %w", file.Path, err)
+		}
+		if len(paths) == 0 {
+			d.logger.Debug("No file found to read resource attributes from", zap.String("path", file.Path))
+			continue
+		}
+		for _, path := range paths {
+			if err := readFile(res.Attributes(), path, file); err != nil {
+				res.Attributes().Clear()
+				return res, "", fmt.Errorf("failed reading resource attributes from %q: %w", path, err)
+			}
+		}
+	}
+	return res, "", nil
+}
+
+func readFile(am pcommon.Map, path string, file FileConfig) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil
+	}
+
+	format := file.Format
+	if format == "" {
+		format = formatFromExtension(path)
+	}
+	switch format {
+	case formatJSON:
+		var values map[string]any
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&values); err != nil {
+			return err
+		}
+		putValues(am, file.KeyPrefix, values)
+	case formatYAML:
+		var values map[string]any
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			return err
+		}
+		putValues(am, file.KeyPrefix, values)
+	default:
+		values, err := parseEnv(content)
+		if err != nil {
+			return err
+		}
+		for key, value := range values {
+			am.PutStr(file.KeyPrefix+key, value)
+		}
+	}
+	return nil
+}
+
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".yaml", ".yml":
+		return formatYAML
+	default:
+		return formatEnv
+	}
+}
+
+// putValues puts the values in the map, flattening nested maps with dots.
+// Null values are skipped.
+func putValues(am pcommon.Map, prefix string, values map[string]any) {
+	for key, value := range values {
+		key = prefix + key
+		switch v := value.(type) {
+		case nil:
+		case map[string]any:
+			putValues(am, key+".", v)
+		case []any:
+			// The slice can't hold invalid values once normalized.
+			_ = am.PutEmptySlice(key).FromRaw(normalizeSlice(v))
+		default:
+			_ = am.PutEmpty(key).FromRaw(normalize(v))
+		}
+	}
+}
+
+// normalize converts the values decoded from JSON and YAML to the types supported
+// by pcommon.Value.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		normalized := make(map[string]any, len(v))
+		for key, value := range v {
+			normalized[key] = normalize(value)
+		}
+		return normalized
+	case []any:
+		return normalizeSlice(v)
+	case nil, string, bool, int, int64, float64:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func normalizeSlice(values []any) []any {
+	normalized := make([]any, len(values))
+	for i, value := range values {
+		normalized[i] = normalize(value)
+	}
+	return normalized
+}
+
+// parseEnv parses KEY=VALUE lines. Blank lines, comments and export keywords are
+// ignored, and quotes surrounding values are removed.
+func parseEnv(content []byte) (map[string]string, error) {
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid line %d, expected KEY=VALUE", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func newDetector(t *testing.T, files ...FileConfig) internal.Detector {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), Config{Files: files})
+	require.NoError(t, err)
+	return d
+}
+
+func TestDetectGlob(t *testing.T) {
+	d := newDetector(t, FileConfig{Path: filepath.Join("testdata", "resource.d", "*")})
+	res, schemaURL, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, schemaURL)
+	assert.Equal(t, map[string]any{
+		"host.name":              "compute-042",
+		"host.rack":              "r13",
+		"node.cpus":              int64(64),
+		"node.load_factor":       0.75,
+		"node.maintenance":       false,
+		"node.roles":             []any{"compute", "storage"},
+		"k8s.cluster.name":       "prod-eu-1",
+		"deployment.environment": "production",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectEnvWithKeyPrefix(t *testing.T) {
+	d := newDetector(t, FileConfig{Path: filepath.Join("testdata", "provisioning.env"), KeyPrefix: "provisioning."})
+	res, _, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"provisioning.DATACENTER":  "fra1",
+		"provisioning.OWNER":       "team-storage",
+		"provisioning.COST_CENTER": "cc-4711",
+		"provisioning.EMPTY":       "",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resource")
+	require.NoError(t, os.WriteFile(path, []byte(`{"service.namespace": "storage"}`), 0o600))
+
+	d := newDetector(t, FileConfig{Path: path, Format: formatJSON})
+	res, _, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"service.namespace": "storage"}, res.Attributes().AsRaw())
+}
+
+func TestDetectReadsFilesAgain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cluster.yaml")
+	d := newDetector(t, FileConfig{Path: path})
+
+	res, _, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+
+	require.NoError(t, os.WriteFile(path, []byte("k8s.cluster.name: blue\n"), 0o600))
+	res, _, err = d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"k8s.cluster.name": "blue"}, res.Attributes().AsRaw())
+
+	require.NoError(t, os.WriteFile(path, []byte("k8s.cluster.name: green\n"), 0o600))
+	res, _, err = d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"k8s.cluster.name": "green"}, res.Attributes().AsRaw())
+}
+
+func TestDetectError(t *testing.T) {
+	for _, name := range []string{"invalid.env", "invalid.json"} {
+		t.Run(name, func(t *testing.T) {
+			d := newDetector(t,
+				FileConfig{Path: filepath.Join("testdata", "provisioning.env")},
+				FileConfig{Path: filepath.Join("testdata", name)},
+			)
+			res, _, err := d.Detect(t.Context())
+			assert.ErrorContains(t, err, name)
+			assert.True(t, internal.IsEmptyResource(res))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg: Config{Files: []FileConfig{
+				{Path: "/etc/otel/resource.d/*.json"},
+				{Path: "/etc/provisioning", Format: formatEnv, KeyPrefix: "provisioning."},
+			}},
+		},
+		{
+			name:        "empty path",
+			cfg:         Config{Files: []FileConfig{{Format: formatYAML}}},
+			expectedErr: "files: path must not be empty",
+		},
+		{
+			name:        "invalid pattern",
+			cfg:         Config{Files: []FileConfig{{Path: "/etc/otel/[a-"}}},
+			expectedErr: `files: invalid path "/etc/otel/[a-": syntax error in pattern`,
+		},
+		{
+			name:        "invalid format",
+			cfg:         Config{Files: []FileConfig{{Path: "/etc/otel/resource.toml", Format: "toml"}}},
+			expectedErr: `files: invalid format "toml" for path "/etc/otel/resource.toml", must be one of "json", "yaml" or "env"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -100,6 +100,13 @@ type ResourceProvider struct {
 	detectedResource *resourceResult
 	once             sync.Once
 	attributesToKeep map[string]struct{}
+
+	// mu guards detectedResource, replaced when the resource is refreshed.
+	mu sync.RWMutex
+
+	refreshMu     sync.Mutex
+	cancelRefresh context.CancelFunc
+	refreshWg     sync.WaitGroup
 }
 
 type resourceResult struct {
@@ -122,14 +129,91 @@ func (p *ResourceProvider) Get(ctx context.Context, client *http.Client) (resour
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
 		defer cancel()
-		p.detectResource(ctx, client.Timeout)
+		result := p.detectResource(ctx, client.Timeout)
+		if !allowErrorPropagationFeatureGate.IsEnabled() {
+			result.err = nil
+		}
+		p.mu.Lock()
+		p.detectedResource = result
+		p.mu.Unlock()
 	})
 
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	return p.detectedResource.resource, p.detectedResource.schemaURL, p.detectedResource.err
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Duration) {
-	p.detectedResource = &resourceResult{}
+// Detected returns the last detected resource, or an empty resource when Get was not called yet.
+func (p *ResourceProvider) Detected() (resource pcommon.Resource, schemaURL string) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.detectedResource == nil {
+		return pcommon.NewResource(), ""
+	}
+	return p.detectedResource.resource, p.detectedResource.schemaURL
+}
+
+// Refresh runs the detectors again, and replaces the detected resource when all of them
+// succeed. The previously detected resource is kept otherwise, so that a detector failing
+// temporarily does not remove its attributes from the telemetry.
+func (p *ResourceProvider) Refresh(ctx context.Context, client *http.Client) {
+	ctx, cancel := context.WithTimeout(ctx, client.Timeout)
+	defer cancel()
+	result := p.detectResource(ctx, client.Timeout)
+	if result.err != nil {
+		p.logger.Warn("failed to refresh resource information, keeping the previously detected one", zap.Error(result.err))
+		return
+	}
+	p.mu.Lock()
+	p.detectedResource = result
+	p.mu.Unlock()
+}
+
+// StartRefreshing refreshes the detected resource every interval until StopRefreshing is
+// called. The provider is shared by the processors of all the signals, so it does nothing
+// when the refreshing was already started.
+func (p *ResourceProvider) StartRefreshing(ctx context.Context, interval time.Duration, client *http.Client) {
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+	if p.cancelRefresh != nil {
+		return
+	}
+
+	// Keep the values of the context, e.g. the HTTP client used by some detectors,
+	// but not its cancellation, as the context of the caller ends with the startup.
+	ctx, p.cancelRefresh = context.WithCancel(context.WithoutCancel(ctx))
+	p.refreshWg.Add(1)
+	go func() {
+		defer p.refreshWg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.Refresh(ctx, client)
+			}
+		}
+	}()
+}
+
+// StopRefreshing stops the refreshing started by StartRefreshing, and waits for an
+// in-flight refresh to complete.
+func (p *ResourceProvider) StopRefreshing() {
+	p.refreshMu.Lock()
+	cancel := p.cancelRefresh
+	p.cancelRefresh = nil
+	p.refreshMu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		p.refreshWg.Wait()
+	}
+}
+
+func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Duration) *resourceResult {
+	detected := &resourceResult{}
 
 	res := pcommon.NewResource()
 	mergedSchemaURL := ""
@@ -174,9 +258,7 @@ func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Dura
 	for _, ch := range resultsChan {
 		result := <-ch
 		if result.err != nil {
-			if allowErrorPropagationFeatureGate.IsEnabled() {
-				p.detectedResource.err = errors.Join(p.detectedResource.err, result.err)
-			}
+			detected.err = errors.Join(detected.err, result.err)
 		} else {
 			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, result.schemaURL)
 			MergeResource(res, result.resource, false)
@@ -190,8 +272,9 @@ func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Dura
 		p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
 	}
 
-	p.detectedResource.resource = res
-	p.detectedResource.schemaURL = mergedSchemaURL
+	detected.resource = res
+	detected.schemaURL = mergedSchemaURL
+	return detected
 }
 
 func MergeSchemaURL(currentSchemaURL, newSchemaURL string) string {
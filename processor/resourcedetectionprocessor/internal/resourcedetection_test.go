@@ -292,6 +292,84 @@ func TestDetectResource_Reconnect(t *testing.T) {
 	md2.AssertNumberOfCalls(t, "Detect", 2) // 1 error + 1 success
 }
 
+func TestResourceProvider_Refresh(t *testing.T) {
+	md := &mockDetector{}
+	res1 := pcommon.NewResource()
+	res1.Attributes().PutStr("k8s.cluster.name", "blue")
+	res2 := pcommon.NewResource()
+	res2.Attributes().PutStr("k8s.cluster.name", "green")
+	md.On("Detect").Return(res1, nil).Once()
+	md.On("Detect").Return(res2, nil)
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	client := &http.Client{Timeout: time.Second}
+
+	detected, _, err := p.Get(t.Context(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"k8s.cluster.name": "blue"}, detected.Attributes().AsRaw())
+
+	p.Refresh(t.Context(), client)
+	detected, _ = p.Detected()
+	assert.Equal(t, map[string]any{"k8s.cluster.name": "green"}, detected.Attributes().AsRaw())
+
+	// Get does not run the detectors again.
+	detected, _, err = p.Get(t.Context(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"k8s.cluster.name": "green"}, detected.Attributes().AsRaw())
+	md.AssertNumberOfCalls(t, "Detect", 2)
+}
+
+func TestResourceProvider_RefreshError(t *testing.T) {
+	md1 := &mockDetector{}
+	res1 := pcommon.NewResource()
+	res1.Attributes().PutStr("a", "1")
+	md1.On("Detect").Return(res1, nil)
+
+	md2 := &mockDetector{}
+	res2 := pcommon.NewResource()
+	res2.Attributes().PutStr("b", "2")
+	md2.On("Detect").Return(res2, nil).Once()
+	md2.On("Detect").Return(pcommon.NewResource(), errors.New("connection error"))
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
+
+	_, _, err := p.Get(t.Context(), &http.Client{Timeout: time.Second})
+	require.NoError(t, err)
+
+	// The previously detected resource is kept when a detector fails.
+	p.Refresh(t.Context(), &http.Client{Timeout: 10 * time.Millisecond})
+	detected, _ := p.Detected()
+	assert.Equal(t, map[string]any{"a": "1", "b": "2"}, detected.Attributes().AsRaw())
+}
+
+func TestResourceProvider_StartRefreshing(t *testing.T) {
+	md := &mockDetector{}
+	res1 := pcommon.NewResource()
+	res1.Attributes().PutStr("k8s.cluster.name", "blue")
+	res2 := pcommon.NewResource()
+	res2.Attributes().PutStr("k8s.cluster.name", "green")
+	md.On("Detect").Return(res1, nil).Once()
+	md.On("Detect").Return(res2, nil)
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	client := &http.Client{Timeout: time.Second}
+
+	_, _, err := p.Get(t.Context(), client)
+	require.NoError(t, err)
+
+	p.StartRefreshing(t.Context(), 10*time.Millisecond, client)
+	// Starting again, e.g. from the processor of another signal, is a no-op.
+	p.StartRefreshing(t.Context(), 10*time.Millisecond, client)
+	assert.Eventually(t, func() bool {
+		detected, _ := p.Detected()
+		value, ok := detected.Attributes().Get("k8s.cluster.name")
+		return ok && value.Str() == "green"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	p.StopRefreshing()
+	p.StopRefreshing()
+}
+
 func TestFilterAttributes_Match(t *testing.T) {
 	m := map[string]struct{}{
 		"host.name": {},
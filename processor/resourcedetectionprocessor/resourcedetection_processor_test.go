@@ -384,3 +384,41 @@ func BenchmarkConsumeProfilesAll(b *testing.B) {
 	cfg := &Config{Override: true, Detectors: []string{env.TypeStr, gcp.TypeStr}}
 	benchmarkConsumeProfiles(b, cfg)
 }
+
+func TestResourceProcessorRefresh(t *testing.T) {
+	factory := &factory{providers: map[component.ID]*internal.ResourceProvider{}}
+
+	md := &mockDetector{}
+	res1 := pcommon.NewResource()
+	res1.Attributes().PutStr("k8s.cluster.name", "blue")
+	res2 := pcommon.NewResource()
+	res2.Attributes().PutStr("k8s.cluster.name", "green")
+	md.On("Detect").Return(res1, nil).Once()
+	md.On("Detect").Return(res2, nil)
+	factory.resourceProviderFactory = internal.NewProviderFactory(
+		map[internal.DetectorType]internal.DetectorFactory{"mock": func(processor.Settings, internal.DetectorConfig) (internal.Detector, error) {
+			return md, nil
+		}})
+
+	cfg := &Config{
+		Override:        true,
+		Detectors:       []string{"mock"},
+		ClientConfig:    confighttp.ClientConfig{Timeout: time.Second},
+		RefreshInterval: 10 * time.Millisecond,
+	}
+
+	sink := new(consumertest.TracesSink)
+	rtp, err := factory.createTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rtp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, rtp.Shutdown(t.Context())) }()
+
+	assert.Eventually(t, func() bool {
+		td := ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty()
+		assert.NoError(t, rtp.ConsumeTraces(t.Context(), td))
+		traces := sink.AllTraces()
+		value, ok := traces[len(traces)-1].ResourceSpans().At(0).Resource().Attributes().Get("k8s.cluster.name")
+		return ok && value.Str() == "green"
+	}, 5*time.Second, 10*time.Millisecond)
+}